@@ -2,8 +2,11 @@ package app
 
 import (
 	appService "server/service/app"
+	systemService "server/service/system"
 )
 
+var authorityService = systemService.AuthorityServiceApp
+
 // ArticleApi article
 type ArticleApi struct{}
 
@@ -176,7 +176,7 @@ func (*ArticleApi) GetArticleList(c fiber.Ctx) error {
 	IsImportant := c.Query("is_important", "0")
 	pageInfo.IsImportant, _ = strconv.Atoi(IsImportant)
 	// log.Println("origin: ", c.Get("Origin"))
-	if list, total, err := articleService.GetArticleInfoList(&pageInfo); err != nil {
+	if list, total, err := articleService.GetArticleInfoList(&pageInfo, authorityService.DataScope(utils.GetUserInfo(c), "user_id")); err != nil {
 		return response.FailWithDetailed(map[string]string{
 			"msg": err.Error(),
 		}, "获取失败", 3, err, c)
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"errors"
 	"strconv"
 
 	appService "server/service/app"
@@ -94,11 +95,15 @@ func (commentApi *CommentApi) UpdateComment(c fiber.Ctx) error {
 	if err != nil {
 		return response.FailWithMessage("获取id失败", 3, err, c)
 	}
-	comment2.ID = uint(id)
 	err = c.Bind().Body(&comment2)
 	if err != nil {
 		return response.FailWithMessage("获取数据失败", 3, err, c)
 	}
+	// 归属校验只看路径id, 请求体里的id不能指向别的评论
+	if comment2.ID != 0 && comment2.ID != uint(id) {
+		return response.FailWithMessage("获取数据失败", 3, errors.New("请求体id与路径id不一致"), c)
+	}
+	comment2.ID = uint(id)
 	if err = commentService.UpdateComment(&comment2); err != nil {
 		return response.FailWithMessage("更新失败"+err.Error(), 3, err, c)
 	}
@@ -145,7 +150,7 @@ func (commentApi *CommentApi) GetCommentList(c fiber.Ctx) error {
 	if pageInfo.PageSize == 0 {
 		pageInfo.PageSize = 10
 	}
-	if list, total, err := commentService.GetCommentInfoList(&pageInfo); err != nil {
+	if list, total, err := commentService.GetCommentInfoList(&pageInfo, authorityService.DataScope(utils.GetUserInfo(c), "user_id")); err != nil {
 		return response.FailWithMessage("获取失败"+err.Error(), 3, err, c)
 	} else {
 		return response.OkWithDetailed(response.PageResult{
@@ -6,8 +6,11 @@ import (
 	"strings"
 	"testing"
 
+	global "server/model"
+
 	"github.com/gofiber/fiber/v3"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 )
 
 func setupTestApp() *fiber.App {
@@ -170,3 +173,19 @@ func TestCommentApi_PutLikeItOrDislike_Binding(t *testing.T) {
 		assert.Contains(t, string(bodyBytes), `"user_id":100`)
 	})
 }
+
+func TestCommentApi_UpdateComment_BodyIdMismatch(t *testing.T) {
+	global.LOG = zap.NewNop()
+	app := setupTestApp()
+	app.Put("/comment/updateComment/:id", (&CommentApi{}).UpdateComment)
+
+	// 请求体里的id与路径不一致时直接拒绝, 不会走到更新
+	body := strings.NewReader(`{"ID":2,"content":"改别人的评论"}`)
+	req := httptest.NewRequest("PUT", "/comment/updateComment/1", body)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, 400, resp.StatusCode)
+	assert.Contains(t, string(bodyBytes), "请求体id与路径id不一致")
+}
@@ -156,11 +156,7 @@ func (e *CustomerApi) GetExaCustomerList(c fiber.Ctx) error {
 	if err := utils.Verify(pageInfo, utils.PageInfoVerify); err != nil {
 		return response.FailWithMessage(err.Error(), 3, err, c)
 	}
-	authorityId, err := utils.GetUserAuthorityId(c)
-	if err != nil {
-		return response.FailWithMessage(err.Error(), 3, err, c)
-	}
-	customerList, total, err := customerService.GetCustomerInfoList(authorityId, &pageInfo)
+	customerList, total, err := customerService.GetCustomerInfoList(&pageInfo, authorityService.DataScope(utils.GetUserInfo(c), "sys_user_id"))
 	if err != nil {
 		return response.FailWithMessage("获取失败"+err.Error(), 3, err, c)
 	} else {
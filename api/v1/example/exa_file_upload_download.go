@@ -8,6 +8,7 @@ import (
 	"server/model/common/request"
 	"server/model/common/response"
 	"server/model/example"
+	"server/utils"
 	"strconv"
 
 	global "server/model"
@@ -36,6 +37,7 @@ import (
 func (u *FileUploadAndDownloadApi) UploadFile(c fiber.Ctx) error {
 	var file example.ExaFileUploadAndDownload
 	noSave := c.Query("noSave", "0")
+	userId, _ := utils.GetUserID(c)
 	isCropper, err := strconv.Atoi(c.Query("is_cropper", "1"))
 	if err != nil {
 	}
@@ -52,7 +54,7 @@ func (u *FileUploadAndDownloadApi) UploadFile(c fiber.Ctx) error {
 		fileDimension.Height = 2
 		fileDimension.Width = 1
 		fileDimension.Proportion = 2.00
-		file, err = fileUploadAndDownloadService.UploadFile(fileImages, noSave, &fileDimension, isCropper) // 文件上传后拿到文件路径
+		file, err = fileUploadAndDownloadService.UploadFile(fileImages, noSave, &fileDimension, isCropper, userId) // 文件上传后拿到文件路径
 		if err != nil {
 			return response.FailWithMessage("修改数据库链接失败", 3, err, c)
 		}
@@ -68,7 +70,7 @@ func (u *FileUploadAndDownloadApi) UploadFile(c fiber.Ctx) error {
 		fileDimension.Width = fileCtx.Dx()
 		fileDimension.Proportion = float64(fileCtx.Dx()) / float64(fileCtx.Dy())
 
-		file, err = fileUploadAndDownloadService.UploadFile(fileImages, noSave, &fileDimension, isCropper) // 文件上传后拿到文件路径
+		file, err = fileUploadAndDownloadService.UploadFile(fileImages, noSave, &fileDimension, isCropper, userId) // 文件上传后拿到文件路径
 		if err != nil {
 			return response.FailWithMessage("修改数据库链接失败"+err.Error(), 3, err, c)
 		}
@@ -141,7 +143,7 @@ func (u *FileUploadAndDownloadApi) DeleteFile(c fiber.Ctx) error {
 func (u *FileUploadAndDownloadApi) GetFileList(c fiber.Ctx) error {
 	var pageInfo request.PageInfo
 	_ = c.Bind().Query(&pageInfo)
-	list, total, err := fileUploadAndDownloadService.GetFileRecordInfoList(&pageInfo)
+	list, total, err := fileUploadAndDownloadService.GetFileRecordInfoList(&pageInfo, authorityService.DataScope(utils.GetUserInfo(c), "user_id"))
 	if err != nil {
 		return response.FailWithMessage("获取失败"+err.Error(), 3, err, c)
 	} else {
@@ -1,6 +1,9 @@
 package example
 
-import exampleServer "server/service/example"
+import (
+	exampleServer "server/service/example"
+	systemServer "server/service/system"
+)
 
 type FileUploadAndDownloadApi struct{}
 type ExcelApi struct{}
@@ -11,3 +14,4 @@ type CustomerApi struct{}
 
 var customerService = exampleServer.CustomerServiceApp
 var fileUploadAndDownloadService = exampleServer.FileUploadAndDownloadServiceApp
+var authorityService = systemServer.AuthorityServiceApp
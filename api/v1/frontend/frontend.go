@@ -13,3 +13,5 @@ var imagesServiceApp = frontend.ImagesServiceApp
 
 var userService = systemService.UserServiceApp
 var jwtService = systemService.JwtServiceApp
+var refreshTokenService = systemService.RefreshTokenServiceApp
+var sessionService = systemService.SessionServiceApp
@@ -1,18 +1,25 @@
 package frontend
 
 import (
+	"errors"
+	systemApi "server/api/v1/system"
 	global "server/model"
 	"server/model/common/response"
 	"server/model/frontend"
 	loginRequest "server/model/frontend/request"
 	"server/model/system"
 	"server/utils"
+	"strings"
+	"time"
 
 	systemReq "server/model/system/request"
 	systemRes "server/model/system/response"
 
 	"github.com/gofiber/fiber/v3"
+	jwt "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
 )
 
 type User struct{}
@@ -57,11 +64,19 @@ func (b *User) Login(c fiber.Ctx) error {
 	if err := utils.Verify(l, utils.LoginVerifyFrontend); err != nil {
 		return response.FailWithMessage(err.Error(), 3, err, c)
 	}
-	if user, err := userService.Login(l.Username, l.Password); err != nil {
+	attempt := systemApi.NewLoginAttempt(c, system.RefreshChannelFrontend, l.Username)
+	if state, err := systemApi.LoginGuardCheck(attempt, l.CaptchaId, l.Captcha, false); err != nil {
+		return systemApi.LoginGuardFail(c, attempt, state, err)
+	}
+	user, err := userService.Login(l.Username, l.Password)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return systemApi.LoginFailed(c, attempt, 0, "password", "用户名不存在或者密码错误", err)
+	}
+	if err != nil {
 		return response.FailWithMessage("用户名不存在或者密码错误", 3, err, c)
-	} else {
-		return b.tokenNext(c, *user)
 	}
+	systemApi.LoginSucceeded(attempt, user.ID)
+	return b.tokenNext(c, *user)
 	// if store.Verify(l.CaptchaId, l.Captcha, true) {
 
 	// } else {
@@ -69,8 +84,21 @@ func (b *User) Login(c fiber.Ctx) error {
 	// }
 }
 
-// 登录以后签发jwt
+// 登录以后签发jwt, 同时开启新的刷新令牌族
 func (u *User) tokenNext(c fiber.Ctx, user system.SysUser) error {
+	jti := uuid.NewString()
+	refreshToken, refreshExpiresAt, err := refreshTokenService.Issue(system.RefreshChannelFrontend, user.ID, jti)
+	if err != nil {
+		return response.FailWithMessage("签发刷新令牌失败", 3, err, c)
+	}
+	return u.accessTokenNext(c, user, jti, refreshToken, refreshExpiresAt)
+}
+
+// 签发访问令牌并记录会话, 多点登录拦截时作废该用户上一次的令牌
+func (u *User) accessTokenNext(c fiber.Ctx, user system.SysUser, jti, refreshToken string, refreshExpiresAt time.Time) error {
+	if err := sessionService.Record(system.RefreshChannelFrontend, user.ID, jti, systemApi.NewSessionClient(c), refreshExpiresAt); err != nil {
+		return response.FailWithMessage("记录登录会话失败", 3, err, c)
+	}
 	j := utils.NewJWT() // 唯一签名
 	claims := j.CreateClaims(systemReq.BaseClaims{
 		UUID:        user.UUID,
@@ -79,28 +107,29 @@ func (u *User) tokenNext(c fiber.Ctx, user system.SysUser) error {
 		Username:    user.Username,
 		AuthorityId: user.AuthorityId,
 	})
+	claims.RegisteredClaims.ID = jti
+	claims.RegisteredClaims.Audience = jwt.ClaimStrings{system.RefreshChannelFrontend} // 区分签发渠道, 后台接口只接受后台令牌
 	token, err := j.CreateToken(claims)
 	if err != nil {
 		return response.FailWithMessage("获取token失败", 3, err, c)
 	}
 	c.Locals("frontend_user", user)
+	loginResponse := systemRes.LoginResponse{
+		User:             user,
+		Token:            token,
+		ExpiresAt:        claims.RegisteredClaims.ExpiresAt.Unix(),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refreshExpiresAt.Unix(),
+	}
 	if !global.CONFIG.System.UseMultipoint {
-		return response.OkWithDetailed(systemRes.LoginResponse{
-			User:      user,
-			Token:     token,
-			ExpiresAt: claims.RegisteredClaims.ExpiresAt.Unix(),
-		}, "登录成功", c)
+		return response.OkWithDetailed(loginResponse, "登录成功", c)
 	}
 
 	if jwtStr, err := jwtService.GetRedisJWT(user.Username); err == redis.Nil {
 		if err := jwtService.SetRedisJWT(token, user.Username); err != nil {
 			return response.FailWithMessage("设置登录状态失败", 3, err, c)
 		}
-		return response.OkWithDetailed(systemRes.LoginResponse{
-			User:      user,
-			Token:     token,
-			ExpiresAt: claims.RegisteredClaims.ExpiresAt.Unix(),
-		}, "登录成功", c)
+		return response.OkWithDetailed(loginResponse, "登录成功", c)
 	} else if err != nil {
 		return response.FailWithMessage("设置登录状态失败", 3, err, c)
 	} else {
@@ -112,14 +141,196 @@ func (u *User) tokenNext(c fiber.Ctx, user system.SysUser) error {
 		if err := jwtService.SetRedisJWT(token, user.Username); err != nil {
 			return response.FailWithMessage("设置登录状态失败", 3, err, c)
 		}
-		return response.OkWithDetailed(systemRes.LoginResponse{
-			User:      user,
-			Token:     token,
-			ExpiresAt: claims.RegisteredClaims.ExpiresAt.Unix(),
-		}, "登录成功", c)
+		return response.OkWithDetailed(loginResponse, "登录成功", c)
 	}
 }
 
+// RefreshToken 前台用户使用刷新令牌换新
+// @Tags Frontend User
+// @Summary 使用刷新令牌换取新的token
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.RefreshToken true "刷新令牌"
+// @Success 200 {object} response.Response{data=systemRes.LoginResponse,msg=string} "换新成功"
+// @Failure 401 {object} response.Response{msg=string} "刷新令牌无效"
+// @Router /frontend/refresh [post]
+func (u *User) RefreshToken(c fiber.Ctx) error {
+	var req systemReq.RefreshToken
+	if err := c.Bind().Body(&req); err != nil || req.RefreshToken == "" {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	record, refreshToken, refreshExpiresAt, err := refreshTokenService.Rotate(system.RefreshChannelFrontend, req.RefreshToken)
+	if err != nil {
+		return response.FailWithDetailed401(fiber.Map{"reload": true}, err.Error(), err, c)
+	}
+	user, err := userService.FindUserById(int(record.UserId))
+	if err != nil {
+		return response.FailWithDetailed401(fiber.Map{"reload": true}, "用户不存在", err, c)
+	}
+	return u.accessTokenNext(c, *user, record.FamilyId, refreshToken, refreshExpiresAt)
+}
+
+// Logout 前台用户退出登录
+// @Tags Frontend User
+// @Summary 退出登录, 作废当前token和刷新令牌
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.Logout false "刷新令牌, all 为 true 时退出前台全部设备"
+// @Success 200 {object} response.Response{msg=string} "退出成功"
+// @Router /frontend/logout [post]
+func (u *User) Logout(c fiber.Ctx) error {
+	var req systemReq.Logout
+	_ = c.Bind().Body(&req)
+	var err error
+	if claims, claimsErr := utils.GetClaims(c); claimsErr == nil {
+		err = refreshTokenService.Logout(system.RefreshChannelFrontend, claims.BaseClaims.ID, claims.RegisteredClaims.ID, req)
+	} else if req.RefreshToken != "" {
+		err = refreshTokenService.Revoke(system.RefreshChannelFrontend, req.RefreshToken)
+	}
+	if err != nil {
+		return response.FailWithMessage("退出失败", 3, err, c)
+	}
+	token := strings.TrimPrefix(c.Get("Authorization"), "Bearer ")
+	if err = jwtService.JsonInBlacklist(system.JwtBlacklist{Jwt: token}); err != nil {
+		return response.FailWithMessage("退出失败", 3, err, c)
+	}
+	return response.OkWithMessage("退出成功", c)
+}
+
+// ForgotPassword 前台找回密码
+// @Tags Frontend User
+// @Summary 通过邮箱找回密码
+// @Description 向注册邮箱发送重置链接, 按邮箱和 ip 限流, 不会提示邮箱是否注册
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.ForgotPassword true "邮箱"
+// @Success 200 {object} response.Response{msg=string} "已受理"
+// @Router /frontend/forgotPassword [post]
+func (u *User) ForgotPassword(c fiber.Ctx) error {
+	return systemApi.ForgotPassword(c, system.RefreshChannelFrontend)
+}
+
+// ConfirmPasswordReset 前台重置密码
+// @Tags Frontend User
+// @Summary 使用邮件中的令牌设置新密码
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.ConfirmPasswordReset true "令牌, 新密码"
+// @Success 200 {object} response.Response{msg=string} "重置成功"
+// @Router /frontend/confirmPasswordReset [post]
+func (u *User) ConfirmPasswordReset(c fiber.Ctx) error {
+	return systemApi.ConfirmPasswordReset(c)
+}
+
+// OidcProviders 前台可用的身份提供方
+// @Tags Frontend User
+// @Summary 获取可用的 OIDC 身份提供方
+// @Produce application/json
+// @Success 200 {object} response.Response{data=[]string,msg=string} "获取成功"
+// @Router /frontend/oidc/providers [get]
+func (u *User) OidcProviders(c fiber.Ctx) error {
+	return systemApi.OidcProviders(c)
+}
+
+// OidcAuthorize 前台发起 OIDC 登录
+// @Tags Frontend User
+// @Summary 发起 OIDC 登录
+// @Produce application/json
+// @Param provider path string true "身份提供方"
+// @Success 200 {object} response.Response{data=systemRes.OidcAuthorizeResponse,msg=string} "获取成功"
+// @Router /frontend/oidc/{provider}/authorize [get]
+func (u *User) OidcAuthorize(c fiber.Ctx) error {
+	return systemApi.OidcAuthorize(c, system.RefreshChannelFrontend, 0)
+}
+
+// OidcCallback 前台 OIDC 登录回调
+// @Tags Frontend User
+// @Summary OIDC 登录回调
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.OidcCallback true "code, state"
+// @Success 200 {object} response.Response{msg=string} "登录成功"
+// @Router /frontend/oidc/callback [post]
+func (u *User) OidcCallback(c fiber.Ctx) error {
+	return systemApi.OidcCallback(c, system.RefreshChannelFrontend, func(user *system.SysUser) error {
+		return u.tokenNext(c, *user)
+	})
+}
+
+// LinkOidc 前台用户关联外部账号
+// @Tags Frontend User
+// @Summary 当前用户关联外部账号
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Param provider path string true "身份提供方"
+// @Success 200 {object} response.Response{data=systemRes.OidcAuthorizeResponse,msg=string} "获取成功"
+// @Router /frontend/oidc/{provider}/link [post]
+func (u *User) LinkOidc(c fiber.Ctx) error {
+	return systemApi.OidcLink(c, system.RefreshChannelFrontend)
+}
+
+// GetOidcIdentities 前台用户已关联的外部账号
+// @Tags Frontend User
+// @Summary 获取当前用户已关联的外部账号
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Success 200 {object} response.Response{data=[]system.SysUserIdentity,msg=string} "获取成功"
+// @Router /frontend/oidc/identities [get]
+func (u *User) GetOidcIdentities(c fiber.Ctx) error {
+	return systemApi.OidcIdentities(c)
+}
+
+// UnlinkOidc 前台用户解除外部账号关联
+// @Tags Frontend User
+// @Summary 解除当前用户的外部账号关联
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "关联记录ID"
+// @Success 200 {object} response.Response{msg=string} "解除成功"
+// @Router /frontend/oidc/unlink [post]
+func (u *User) UnlinkOidc(c fiber.Ctx) error {
+	return systemApi.OidcUnlink(c)
+}
+
+// GetSessions 前台用户已登录的设备
+// @Tags Frontend User
+// @Summary 获取当前用户已登录的设备
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Success 200 {object} response.Response{data=[]system.SysSession,msg=string} "获取成功"
+// @Router /frontend/sessions [get]
+func (u *User) GetSessions(c fiber.Ctx) error {
+	return systemApi.SessionList(c, systemApi.SysUserChannels)
+}
+
+// RevokeSession 前台用户退出指定设备
+// @Tags Frontend User
+// @Summary 退出指定设备
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "会话ID"
+// @Success 200 {object} response.Response{msg=string} "退出成功"
+// @Router /frontend/sessions/revoke [post]
+func (u *User) RevokeSession(c fiber.Ctx) error {
+	return systemApi.SessionRevoke(c, systemApi.SysUserChannels)
+}
+
+// RevokeAllSessions 前台用户退出全部设备
+// @Tags Frontend User
+// @Summary 退出全部设备
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.RevokeSessions true "是否保留当前会话"
+// @Success 200 {object} response.Response{msg=string} "退出成功"
+// @Router /frontend/sessions/revokeAll [post]
+func (u *User) RevokeAllSessions(c fiber.Ctx) error {
+	return systemApi.SessionRevokeAll(c, systemApi.SysUserChannels)
+}
+
 func (*User) RegisterUser(c fiber.Ctx) error {
 	var userInfo loginRequest.RegisterUser
 	err := c.Bind().Body(&userInfo)
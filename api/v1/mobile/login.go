@@ -1,9 +1,15 @@
 package mobile
 
 import (
+	"errors"
+
+	systemApi "server/api/v1/system"
 	"server/model/common/response"
 	"server/model/mobile"
 	"server/model/mobile/request"
+	"server/model/system"
+	systemReq "server/model/system/request"
+	mobileServiceApp "server/service/mobile"
 	"server/utils"
 
 	"github.com/gofiber/fiber/v3"
@@ -11,6 +17,8 @@ import (
 
 type LoginApi struct{}
 
+var mobileChannels = []string{system.RefreshChannelMobile}
+
 func mobileUserID(c fiber.Ctx) (uint, bool) {
 	switch userID := c.Locals("user_id").(type) {
 	case uint:
@@ -44,13 +52,96 @@ func (*LoginApi) Login(c fiber.Ctx) error {
 	if err := utils.Verify(l, utils.MobileLoginVerify); err != nil { // 验证用户密码的规则
 		return response.FailWithMessage(err.Error(), 3, err, c)
 	}
-	loginResponse, err := loginService.Login(&l)
+	attempt := systemApi.NewLoginAttempt(c, system.RefreshChannelMobile, l.Username)
+	if state, err := systemApi.LoginGuardCheck(attempt, l.CaptchaId, l.Captcha, false); err != nil {
+		return systemApi.LoginGuardFail(c, attempt, state, err)
+	}
+	loginResponse, err := loginService.Login(&l, systemApi.NewSessionClient(c))
+	if errors.Is(err, mobileServiceApp.ErrLoginFailed) {
+		return systemApi.LoginFailed(c, attempt, 0, "password", "用户名不存在或者密码错误", err)
+	}
 	if err != nil {
 		return response.FailWithMessage400("用户名不存在或者密码错误", 3, err, c)
-	} else {
-		return response.OkWithDetailed(loginResponse, "登录成功", c)
 	}
+	systemApi.LoginSucceeded(attempt, loginResponse.UserId)
+	return response.OkWithDetailed(loginResponse, "登录成功", c)
+}
 
+// RefreshToken 移动端使用刷新令牌换新
+// @Tags Mobile Login
+// @Summary 使用刷新令牌换取新的token
+// @Description 旧的刷新令牌立即失效, 重复使用已失效的刷新令牌会作废该次登录的全部令牌
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.RefreshToken true "刷新令牌"
+// @Success 200 {object} response.Response{msg=string} "换新成功"
+// @Failure 401 {object} response.Response{msg=string} "刷新令牌无效"
+// @Router /mobile/refresh [post]
+func (*LoginApi) RefreshToken(c fiber.Ctx) error {
+	var req systemReq.RefreshToken
+	if err := c.Bind().Body(&req); err != nil || req.RefreshToken == "" {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	loginResponse, err := loginService.Refresh(req.RefreshToken, systemApi.NewSessionClient(c))
+	if err != nil {
+		return response.FailWithMessage401("刷新令牌无效，请重新登录", 3, err, c)
+	}
+	return response.OkWithDetailed(loginResponse, "换新成功", c)
+}
+
+// Logout 移动端退出登录
+// @Tags Mobile Login
+// @Summary 退出登录, 作废刷新令牌
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.Logout false "刷新令牌, all 为 true 时退出移动端全部设备"
+// @Success 200 {object} response.Response{msg=string} "退出成功"
+// @Router /mobile/logout [post]
+func (*LoginApi) Logout(c fiber.Ctx) error {
+	var req systemReq.Logout
+	_ = c.Bind().Body(&req)
+	claims, ok := c.Locals("mobile_claims").(*utils.MobileClaims)
+	if !ok || claims.ID == 0 {
+		return response.FailWithMessage401("token 失效，请重新登录", 3, nil, c)
+	}
+	if err := loginService.Logout(req, claims.ID, claims.RegisteredClaims.ID); err != nil {
+		return response.FailWithMessage("退出失败", 3, err, c)
+	}
+	return response.OkWithMessage("退出成功", c)
+}
+
+// GetSessions 移动端已登录的设备
+// @Tags Mobile Login
+// @Summary 获取当前用户已登录的设备
+// @Produce application/json
+// @Success 200 {object} response.Response{data=[]system.SysSession,msg=string} "获取成功"
+// @Router /mobile/sessions [get]
+func (*LoginApi) GetSessions(c fiber.Ctx) error {
+	return systemApi.SessionList(c, mobileChannels)
+}
+
+// RevokeSession 移动端退出指定设备
+// @Tags Mobile Login
+// @Summary 退出指定设备
+// @Accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "会话ID"
+// @Success 200 {object} response.Response{msg=string} "退出成功"
+// @Router /mobile/sessions/revoke [post]
+func (*LoginApi) RevokeSession(c fiber.Ctx) error {
+	return systemApi.SessionRevoke(c, mobileChannels)
+}
+
+// RevokeAllSessions 移动端退出全部设备
+// @Tags Mobile Login
+// @Summary 退出全部设备
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.RevokeSessions true "是否保留当前会话"
+// @Success 200 {object} response.Response{msg=string} "退出成功"
+// @Router /mobile/sessions/revokeAll [post]
+func (*LoginApi) RevokeAllSessions(c fiber.Ctx) error {
+	return systemApi.SessionRevokeAll(c, mobileChannels)
 }
 
 // GetUserInfo 获取移动端用户信息
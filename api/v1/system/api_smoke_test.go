@@ -27,6 +27,7 @@ func TestAPIHandlerStructsConstructible(t *testing.T) {
 		{"AuthorityBtnApi", &AuthorityBtnApi{}},
 		{"SystemApiApi", &SystemApiApi{}},
 		{"UserProblem", &UserProblem{}},
+		{"MigrationApi", &MigrationApi{}},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -18,6 +18,16 @@ var initDBService = systemServer.InitDBServiceApp
 var jwtService = systemServer.JwtServiceApp
 var baseMenuService = systemServer.BaseMenuServiceApp
 var operationRecordService = systemServer.OperationRecordServiceApp
+var operationRecordWriter = systemServer.OperationRecordWriterApp
 var systemConfigService = systemServer.SystemConfigServiceApp
 var userProblem = systemServer.ProblemApp
 var userService = systemServer.UserServiceApp
+var migrationService = systemServer.MigrationServiceApp
+var refreshTokenService = systemServer.RefreshTokenServiceApp
+var totpService = systemServer.TotpServiceApp
+var passwordResetService = systemServer.PasswordResetServiceApp
+var loginGuardService = systemServer.LoginGuardServiceApp
+var auditLogService = systemServer.AuditLogServiceApp
+var oidcService = systemServer.OidcServiceApp
+var apiKeyService = systemServer.ApiKeyServiceApp
+var sessionService = systemServer.SessionServiceApp
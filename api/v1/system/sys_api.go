@@ -7,6 +7,7 @@ import (
 	"server/model/common/response"
 	"server/model/system"
 	systemReq "server/model/system/request"
+	systemRes "server/model/system/response"
 	"server/utils"
 
 	"github.com/gofiber/fiber/v3"
@@ -184,3 +185,37 @@ func (s *SystemApiApi) DeleteApisByIds(c fiber.Ctx) error {
 		return response.OkWithMessage("删除成功", c)
 	}
 }
+
+// @Tags SysApi
+// @Summary 对比已注册路由和api列表
+// @Description 返回已注册但未录入、已录入但路由不存在、同一路径方法变化的接口, 不做修改
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Success 200 {object} response.Response{data=systemRes.SysApiSyncResponse,msg=string} "获取成功"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器错误"
+// @Router /api/syncApi [get]
+func (s *SystemApiApi) SyncApi(c fiber.Ctx) (err error) {
+	var result systemRes.SysApiSyncResponse
+	if result, err = apiService.SyncApi(c.App().GetRoutes(true)); err != nil {
+		return response.FailWithMessage("获取失败", 3, err, c)
+	}
+	return response.OkWithDetailed(result, "获取成功", c)
+}
+
+// @Tags SysApi
+// @Summary 同步已注册路由到api列表
+// @Description 录入新接口, 删除失效接口及其权限, 方法变化时同步更新 casbin 规则
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Success 200 {object} response.Response{data=systemRes.SysApiSyncResponse,msg=string} "同步成功"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器错误"
+// @Router /api/enterSyncApi [post]
+func (s *SystemApiApi) EnterSyncApi(c fiber.Ctx) (err error) {
+	var result systemRes.SysApiSyncResponse
+	if result, err = apiService.EnterSyncApi(c.App().GetRoutes(true)); err != nil {
+		return response.FailWithMessage("同步失败", 3, err, c)
+	}
+	return response.OkWithDetailed(result, "同步成功", c)
+}
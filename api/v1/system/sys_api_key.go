@@ -0,0 +1,131 @@
+package system
+
+import (
+	"server/model/common/request"
+	"server/model/common/response"
+	"server/model/system"
+	systemReq "server/model/system/request"
+	systemRes "server/model/system/response"
+	"server/utils"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type ApiKeyApi struct{}
+
+// CreateApiKey 创建 API Key
+// @Tags ApiKey
+// @Summary 为当前用户创建 API Key
+// @Description 返回的 key 只显示这一次, 请求时放在 X-Api-Key 或 Authorization: Bearer 中; 使用 API Key 不能再创建 API Key
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.CreateApiKey true "名称, 有效天数, 接口范围"
+// @Success 200 {object} response.Response{data=systemRes.ApiKeyCreateResponse,msg=string} "创建成功"
+// @Router /apiKey/create [post]
+func (a *ApiKeyApi) CreateApiKey(c fiber.Ctx) error {
+	if _, ok := c.Locals("apiKey").(*system.SysApiKey); ok {
+		return response.FailWithMessage403("不能使用 API Key 创建 API Key", 3, nil, c)
+	}
+	var req systemReq.CreateApiKey
+	if err := c.Bind().Body(&req); err != nil {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	userId, err := utils.GetUserID(c)
+	if err != nil || userId == 0 {
+		return response.FailWithMessage401("未登录", 3, err, c)
+	}
+	key, record, err := apiKeyService.Create(userId, req)
+	if err != nil {
+		return response.FailWithMessage(err.Error(), 3, err, c)
+	}
+	return response.OkWithDetailed(systemRes.ApiKeyCreateResponse{Key: key, ApiKey: record}, "创建成功, 请妥善保存 API Key", c)
+}
+
+// GetApiKeyList 获取当前用户的 API Key
+// @Tags ApiKey
+// @Summary 获取当前用户的 API Key
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Success 200 {object} response.Response{data=[]system.SysApiKey,msg=string} "获取成功"
+// @Router /apiKey/getList [get]
+func (a *ApiKeyApi) GetApiKeyList(c fiber.Ctx) error {
+	userId, err := utils.GetUserID(c)
+	if err != nil {
+		return response.FailWithMessage401("未登录", 3, err, c)
+	}
+	list, err := apiKeyService.GetList(userId)
+	if err != nil {
+		return response.FailWithMessage("获取失败", 3, err, c)
+	}
+	return response.OkWithDetailed(list, "获取成功", c)
+}
+
+// RevokeApiKey 作废当前用户的 API Key
+// @Tags ApiKey
+// @Summary 作废当前用户的 API Key
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "API Key ID"
+// @Success 200 {object} response.Response{msg=string} "作废成功"
+// @Router /apiKey/revoke [post]
+func (a *ApiKeyApi) RevokeApiKey(c fiber.Ctx) error {
+	var req request.GetById
+	if err := c.Bind().Body(&req); err != nil || req.ID == 0 {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	userId, err := utils.GetUserID(c)
+	if err != nil {
+		return response.FailWithMessage401("未登录", 3, err, c)
+	}
+	if err = apiKeyService.Revoke(userId, req.Uint()); err != nil {
+		return response.FailWithMessage("作废失败", 3, err, c)
+	}
+	return response.OkWithMessage("作废成功", c)
+}
+
+// GetAllApiKeyList 分页获取全部 API Key
+// @Tags ApiKey
+// @Summary 管理员分页获取全部 API Key
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Param data query systemReq.SysApiKeySearch true "用户ID, 名称, 页码, 每页大小"
+// @Success 200 {object} response.Response{data=response.PageResult{list=[]system.SysApiKey,total=int64,page=int,pageSize=int},msg=string} "获取成功"
+// @Router /apiKey/getAllList [get]
+func (a *ApiKeyApi) GetAllApiKeyList(c fiber.Ctx) error {
+	var search systemReq.SysApiKeySearch
+	if err := c.Bind().Query(&search); err != nil {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	list, total, err := apiKeyService.GetApiKeyList(search)
+	if err != nil {
+		return response.FailWithMessage("获取失败", 3, err, c)
+	}
+	return response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     search.Page,
+		PageSize: search.PageSize,
+	}, "获取成功", c)
+}
+
+// RevokeApiKeyById 管理员作废 API Key
+// @Tags ApiKey
+// @Summary 管理员作废任意用户的 API Key
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "API Key ID"
+// @Success 200 {object} response.Response{msg=string} "作废成功"
+// @Router /apiKey/revokeById [post]
+func (a *ApiKeyApi) RevokeApiKeyById(c fiber.Ctx) error {
+	var req request.GetById
+	if err := c.Bind().Body(&req); err != nil || req.ID == 0 {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	if err := apiKeyService.RevokeById(req.Uint()); err != nil {
+		return response.FailWithMessage("作废失败", 3, err, c)
+	}
+	return response.OkWithMessage("作废成功", c)
+}
@@ -0,0 +1,33 @@
+package system
+
+import (
+	"server/model/common/response"
+	systemReq "server/model/system/request"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type AuditLogApi struct{}
+
+// GetAuditLogList 获取审计记录
+// @Tags AuditLog
+// @Summary 分页获取审计记录
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Param data query systemReq.SysAuditLogSearch true "页码, 每页大小, 搜索条件"
+// @Success 200 {object} response.Response{data=response.PageResult{list=[]system.SysAuditLog,total=int64,page=int,pageSize=int},msg=string} "获取成功"
+// @Router /auditLog/getAuditLogList [get]
+func (a *AuditLogApi) GetAuditLogList(c fiber.Ctx) error {
+	var pageInfo systemReq.SysAuditLogSearch
+	_ = c.Bind().Query(&pageInfo)
+	list, total, err := auditLogService.GetAuditLogList(pageInfo)
+	if err != nil {
+		return response.FailWithMessage("获取失败", 3, err, c)
+	}
+	return response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
@@ -75,3 +75,48 @@ func (a *AuthorityBtnApi) CanRemoveAuthorityBtn(c fiber.Ctx) error {
 		return response.OkWithMessage("删除成功", c)
 	}
 }
+
+// @Tags AuthorityBtn
+// @Summary 获取按钮绑定的接口
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Param btnID query uint true "按钮id"
+// @Success 200 {object} response.Response{data=[]system.SysApi,msg=string} "查询成功"
+// @Failure 400 {object} response.Response{msg=string} "参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器错误"
+// @Router /authorityBtn/getBtnApis [get]
+func (a *AuthorityBtnApi) GetBtnApis(c fiber.Ctx) error {
+	var req request.SysBtnApisReq
+	if err := c.Bind().Query(&req); err != nil || req.BtnID == 0 {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	apis, err := authorityBtnService.GetBtnApis(req.BtnID)
+	if err != nil {
+		return response.FailWithMessage("查询失败", 3, err, c)
+	}
+	return response.OkWithDetailed(apis, "查询成功", c)
+}
+
+// @Tags AuthorityBtn
+// @Summary 设置按钮绑定的接口
+// @Description 绑定后调用这些接口时要求角色拥有该按钮, apiIds 为空时解除绑定
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.SysBtnApisReq true "按钮id, 接口id"
+// @Success 200 {object} response.Response{msg=string} "设置成功"
+// @Failure 400 {object} response.Response{msg=string} "参数错误"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器错误"
+// @Router /authorityBtn/setBtnApis [post]
+func (a *AuthorityBtnApi) SetBtnApis(c fiber.Ctx) error {
+	var req request.SysBtnApisReq
+	if err := c.Bind().Body(&req); err != nil || req.BtnID == 0 {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	if err := authorityBtnService.SetBtnApis(req.BtnID, req.ApiIds); err != nil {
+		return response.FailWithMessage("设置失败", 3, err, c)
+	}
+	return response.OkWithMessage("设置成功", c)
+}
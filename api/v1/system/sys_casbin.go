@@ -3,6 +3,7 @@ package system
 import (
 	"server/model/common/response"
 	"server/model/system/request"
+	systemRes "server/model/system/response"
 	"server/utils"
 
 	"github.com/gofiber/fiber/v3"
@@ -61,3 +62,67 @@ func (cas *CasbinApi) GetPolicyPathByAuthorityId(c fiber.Ctx) error {
 	paths := casbinService.GetPolicyPathByAuthorityId(casbin.AuthorityId)
 	return response.OkWithDetailed(paths, "获取成功", c)
 }
+
+// GetEffectivePolicyByAuthorityId 获取角色实际生效的权限
+// @Tags Casbin
+// @Summary 获取角色实际生效的权限
+// @Description 包含角色自身的权限和从父角色继承的权限, authorityId 为授予该权限的角色
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Param id path string true "角色ID"
+// @Success 200 {object} response.Response{data=[]systemRes.EffectivePolicy,msg=string} "获取成功"
+// @Failure 400 {object} response.Response "参数错误"
+// @Failure 401 {object} response.Response "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器错误"
+// @Router /casbin/getEffectivePolicyByAuthorityId/{id} [get]
+func (cas *CasbinApi) GetEffectivePolicyByAuthorityId(c fiber.Ctx) error {
+	casbin := request.CasbinInReceive{AuthorityId: c.Params("id")}
+	if err := utils.Verify(casbin, utils.AuthorityIdVerify); err != nil {
+		return response.FailWithMessage(err.Error(), 3, err, c)
+	}
+	var policies []systemRes.EffectivePolicy
+	policies, err := casbinService.GetEffectivePolicy(casbin.AuthorityId)
+	if err != nil {
+		return response.FailWithMessage("获取失败", 3, err, c)
+	}
+	return response.OkWithDetailed(policies, "获取成功", c)
+}
+
+// PreviewAuthorityTree 预览按角色树同步继承关系
+// @Tags Casbin
+// @Summary 预览按角色树同步继承关系
+// @Description 列出同步后新增和删除的父子角色继承关系, 以及子角色因此获得或失去的权限, 不做修改
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Success 200 {object} response.Response{data=systemRes.AuthorityTreeSyncResponse,msg=string} "获取成功"
+// @Failure 401 {object} response.Response "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器错误"
+// @Router /casbin/previewAuthorityTree [get]
+func (cas *CasbinApi) PreviewAuthorityTree(c fiber.Ctx) error {
+	result, err := casbinService.PreviewAuthorityTree()
+	if err != nil {
+		return response.FailWithMessage("获取失败", 3, err, c)
+	}
+	return response.OkWithDetailed(result, "获取成功", c)
+}
+
+// SyncAuthorityTree 按角色树同步继承关系
+// @Tags Casbin
+// @Summary 按角色树同步继承关系
+// @Description 按角色的父角色校正 casbin 继承规则, 子角色会获得父角色的全部权限, 执行前先调用预览接口确认
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Success 200 {object} response.Response{data=systemRes.AuthorityTreeSyncResponse,msg=string} "同步成功"
+// @Failure 401 {object} response.Response "未授权"
+// @Failure 500 {object} response.Response{msg=string} "服务器错误"
+// @Router /casbin/syncAuthorityTree [post]
+func (cas *CasbinApi) SyncAuthorityTree(c fiber.Ctx) error {
+	result, err := casbinService.PreviewAuthorityTree()
+	if err != nil {
+		return response.FailWithMessage("同步失败", 3, err, c)
+	}
+	if err = casbinService.SyncAuthorityTree(); err != nil {
+		return response.FailWithMessage("同步失败", 3, err, c)
+	}
+	return response.OkWithDetailed(result, "同步成功", c)
+}
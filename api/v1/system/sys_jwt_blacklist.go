@@ -3,6 +3,8 @@ package system
 import (
 	"server/model/common/response"
 	"server/model/system"
+	systemReq "server/model/system/request"
+	"server/utils"
 	"strings"
 
 	"github.com/gofiber/fiber/v3"
@@ -15,6 +17,7 @@ type JwtApi struct{}
 // @Security ApiKeyAuth
 // @accept application/json
 // @Produce application/json
+// @Param data body systemReq.Logout false "需要一起作废的刷新令牌, all 为 true 时退出后台全部设备"
 // @Success 200 {object} response.Response{msg=string} "jwt加入黑名单"
 // @Failure 400 {object} response.Response{msg=string} "参数错误"
 // @Failure 401 {object} response.Response{msg=string} "未授权"
@@ -26,6 +29,18 @@ func (j *JwtApi) JsonInBlacklist(c fiber.Ctx) error {
 	if token == "" {
 		return response.FailWithMessage401("token 失效， 请重新登录", 3, nil, c)
 	}
+	// 退出登录同时作废当前登录的刷新令牌和会话, 其他设备不受影响
+	var req systemReq.Logout
+	_ = c.Bind().Body(&req)
+	var err error
+	if claims, claimsErr := utils.GetClaims(c); claimsErr == nil {
+		err = refreshTokenService.Logout(system.RefreshChannelBackend, claims.BaseClaims.ID, claims.RegisteredClaims.ID, req)
+	} else if req.RefreshToken != "" {
+		err = refreshTokenService.Revoke(system.RefreshChannelBackend, req.RefreshToken)
+	}
+	if err != nil {
+		return response.FailWithMessage("刷新令牌作废失败", 3, err, c)
+	}
 	jwt := system.JwtBlacklist{Jwt: token}
 	if err := jwtService.JsonInBlacklist(jwt); err != nil {
 		return response.FailWithMessage("jwt作废失败", 3, err, c)
@@ -33,3 +48,14 @@ func (j *JwtApi) JsonInBlacklist(c fiber.Ctx) error {
 		return response.OkWithMessage("jwt作废成功", c)
 	}
 }
+
+// Jwks
+// @Tags Jwt
+// @Summary 获取jwt验签公钥(JWKS)
+// @Produce application/json
+// @Success 200 {object} utils.JSONWebKeySet "当前所有可用于验签的公钥"
+// @Router /.well-known/jwks.json [get]
+func (j *JwtApi) Jwks(c fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(utils.JWKS())
+}
@@ -0,0 +1,143 @@
+package system
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"server/model/common/request"
+	"server/model/common/response"
+	systemReq "server/model/system/request"
+	systemServer "server/service/system"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type LoginGuardApi struct{}
+
+var errCaptchaWrong = errors.New("验证码错误")
+
+// NewLoginAttempt 根据请求生成登录记录需要的信息
+func NewLoginAttempt(c fiber.Ctx, channel, username string) systemServer.LoginAttempt {
+	return systemServer.LoginAttempt{
+		Channel:   channel,
+		Username:  username,
+		Ip:        c.IP(),
+		UserAgent: c.Get("User-Agent"),
+	}
+}
+
+// LoginGuardCheck 登录前检查锁定, 失败次数达到阈值后校验验证码
+// checked 为 true 表示调用方已经校验过验证码(后台登录始终需要验证码)
+func LoginGuardCheck(attempt systemServer.LoginAttempt, captchaId, captcha string, checked bool) (systemServer.LoginGuardState, error) {
+	state, err := loginGuardService.Check(attempt)
+	if err != nil || !state.NeedCaptcha || checked {
+		return state, err
+	}
+	if captchaId == "" || captcha == "" {
+		return state, systemServer.ErrLoginCaptchaRequired
+	}
+	if !store.Verify(captchaId, captcha, true) {
+		return state, errCaptchaWrong
+	}
+	return state, nil
+}
+
+// LoginGuardFail 登录前检查未通过时的响应
+func LoginGuardFail(c fiber.Ctx, attempt systemServer.LoginAttempt, state systemServer.LoginGuardState, err error) error {
+	if errors.Is(err, systemServer.ErrLoginLocked) {
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(time.Until(state.LockedUntil).Seconds())+1))
+		return response.Result(fiber.StatusTooManyRequests, fiber.Map{"lockedUntil": state.LockedUntil.Unix()}, err.Error(), c)
+	}
+	if errors.Is(err, systemServer.ErrLoginCaptchaRequired) || errors.Is(err, errCaptchaWrong) {
+		if errors.Is(err, errCaptchaWrong) {
+			_, _ = loginGuardService.Failed(attempt, 0, "captcha")
+		}
+		return response.FailWithDetailed(fiber.Map{"needCaptcha": true}, err.Error(), 3, err, c)
+	}
+	return response.FailWithMessage("登录失败", 3, err, c)
+}
+
+// LoginFailed 记录登录失败并按失败次数延迟响应, 返回的 needCaptcha 提示下次登录需要验证码
+func LoginFailed(c fiber.Ctx, attempt systemServer.LoginAttempt, userId uint, reason, msg string, err error) error {
+	state, guardErr := loginGuardService.Failed(attempt, userId, reason)
+	if guardErr != nil {
+		return response.FailWithMessage(msg, 3, errors.Join(err, guardErr), c)
+	}
+	time.Sleep(state.Delay)
+	if err == nil {
+		err = errors.New(reason)
+	}
+	return response.FailWithDetailed(fiber.Map{"needCaptcha": state.NeedCaptcha}, msg, 3, err, c)
+}
+
+// LoginSucceeded 记录登录成功, 清除账号的失败次数
+func LoginSucceeded(attempt systemServer.LoginAttempt, userId uint) {
+	_ = loginGuardService.Succeeded(attempt, userId)
+}
+
+// GetLoginLockList 获取锁定列表
+// @Tags LoginGuard
+// @Summary 分页获取当前被锁定的账号和ip
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Param data query systemReq.SysLoginLockSearch true "页码, 每页大小, 搜索条件"
+// @Success 200 {object} response.Response{data=response.PageResult{list=[]system.SysLoginLock,total=int64,page=int,pageSize=int},msg=string} "获取成功"
+// @Router /loginGuard/getLockList [get]
+func (l *LoginGuardApi) GetLoginLockList(c fiber.Ctx) error {
+	var pageInfo systemReq.SysLoginLockSearch
+	_ = c.Bind().Query(&pageInfo)
+	list, total, err := loginGuardService.GetLockList(pageInfo)
+	if err != nil {
+		return response.FailWithMessage("获取失败", 3, err, c)
+	}
+	return response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
+
+// UnlockLogin 解除锁定
+// @Tags LoginGuard
+// @Summary 解除账号或ip的登录锁定
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "锁定记录ID"
+// @Success 200 {object} response.Response{msg=string} "解除成功"
+// @Router /loginGuard/unlock [post]
+func (l *LoginGuardApi) UnlockLogin(c fiber.Ctx) error {
+	var req request.GetById
+	if err := c.Bind().Body(&req); err != nil || req.ID == 0 {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	if err := loginGuardService.Unlock(req.Uint()); err != nil {
+		return response.FailWithMessage("解除失败", 3, err, c)
+	}
+	return response.OkWithMessage("解除成功", c)
+}
+
+// GetLoginHistoryList 获取登录记录
+// @Tags LoginGuard
+// @Summary 分页获取登录记录
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Param data query systemReq.SysLoginHistorySearch true "页码, 每页大小, 搜索条件"
+// @Success 200 {object} response.Response{data=response.PageResult{list=[]system.SysLoginHistory,total=int64,page=int,pageSize=int},msg=string} "获取成功"
+// @Router /loginGuard/getHistoryList [get]
+func (l *LoginGuardApi) GetLoginHistoryList(c fiber.Ctx) error {
+	var pageInfo systemReq.SysLoginHistorySearch
+	_ = c.Bind().Query(&pageInfo)
+	list, total, err := loginGuardService.GetHistoryList(pageInfo)
+	if err != nil {
+		return response.FailWithMessage("获取失败", 3, err, c)
+	}
+	return response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
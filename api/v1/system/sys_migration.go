@@ -0,0 +1,64 @@
+package system
+
+import (
+	"server/model/common/response"
+	systemReq "server/model/system/request"
+	systemRes "server/model/system/response"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type MigrationApi struct{}
+
+// GetMigrationStatus
+// @Tags      Migration
+// @Summary   获取sql迁移执行状态
+// @Security  ApiKeyAuth
+// @Produce   application/json
+// @Success   200  {object}  response.Response{data=[]systemRes.MigrationStatus,msg=string}  "获取sql迁移执行状态"
+// @Router    /migration/status [get]
+func (m *MigrationApi) GetMigrationStatus(c fiber.Ctx) error {
+	list, err := migrationService.Status()
+	if err != nil {
+		return response.FailWithMessage("获取失败", 3, err, c)
+	}
+	return response.OkWithDetailed(list, "获取成功", c)
+}
+
+// MigrateUp
+// @Tags      Migration
+// @Summary   执行未执行的sql迁移
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.MigrationSteps                                    true  "执行步数, 0为全部"
+// @Success   200   {object}  response.Response{data=systemRes.MigrationResult,msg=string}  "执行sql迁移"
+// @Router    /migration/up [post]
+func (m *MigrationApi) MigrateUp(c fiber.Ctx) error {
+	var req systemReq.MigrationSteps
+	_ = c.Bind().Body(&req)
+	versions, err := migrationService.Up(req.Steps)
+	if err != nil {
+		return response.FailWithDetailed(systemRes.MigrationResult{Versions: versions}, "迁移失败", 3, err, c)
+	}
+	return response.OkWithDetailed(systemRes.MigrationResult{Versions: versions}, "迁移成功", c)
+}
+
+// MigrateDown
+// @Tags      Migration
+// @Summary   回滚最近执行的sql迁移
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      systemReq.MigrationSteps                                    true  "回滚步数, 默认1"
+// @Success   200   {object}  response.Response{data=systemRes.MigrationResult,msg=string}  "回滚sql迁移"
+// @Router    /migration/down [post]
+func (m *MigrationApi) MigrateDown(c fiber.Ctx) error {
+	var req systemReq.MigrationSteps
+	_ = c.Bind().Body(&req)
+	versions, err := migrationService.Down(req.Steps)
+	if err != nil {
+		return response.FailWithDetailed(systemRes.MigrationResult{Versions: versions}, "回滚失败", 3, err, c)
+	}
+	return response.OkWithDetailed(systemRes.MigrationResult{Versions: versions}, "回滚成功", c)
+}
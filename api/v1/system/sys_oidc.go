@@ -0,0 +1,168 @@
+package system
+
+import (
+	"errors"
+
+	"server/model/common/request"
+	"server/model/common/response"
+	"server/model/system"
+	systemReq "server/model/system/request"
+	systemRes "server/model/system/response"
+	systemServer "server/service/system"
+	"server/utils"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type OidcApi struct{}
+
+// OidcProviders 获取可用的身份提供方
+// @Tags Base
+// @Summary 获取可用的 OIDC 身份提供方
+// @Produce application/json
+// @Success 200 {object} response.Response{data=[]string,msg=string} "获取成功"
+// @Router /base/oidc/providers [get]
+func (b *BaseApi) OidcProviders(c fiber.Ctx) error {
+	return OidcProviders(c)
+}
+
+// OidcAuthorize 发起 OIDC 登录
+// @Tags Base
+// @Summary 发起 OIDC 登录
+// @Description 返回身份提供方的授权地址, 前端跳转后由回调页面把 code 和 state 提交到 base/oidc/callback
+// @Produce application/json
+// @Param provider path string true "身份提供方"
+// @Success 200 {object} response.Response{data=systemRes.OidcAuthorizeResponse,msg=string} "获取成功"
+// @Router /base/oidc/{provider}/authorize [get]
+func (b *BaseApi) OidcAuthorize(c fiber.Ctx) error {
+	return OidcAuthorize(c, system.RefreshChannelBackend, 0)
+}
+
+// OidcCallback OIDC 登录回调
+// @Tags Base
+// @Summary OIDC 登录回调
+// @Description 用授权码换取身份后登录, 与账号密码登录返回相同的结构(包括两步验证)
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.OidcCallback true "code, state"
+// @Success 200 {object} response.Response{data=systemRes.LoginResponse,msg=string} "登录成功"
+// @Router /base/oidc/callback [post]
+func (b *BaseApi) OidcCallback(c fiber.Ctx) error {
+	return OidcCallback(c, system.RefreshChannelBackend, func(user *system.SysUser) error {
+		return b.loginNext(c, user)
+	})
+}
+
+// LinkOidc 关联外部账号
+// @Tags Oidc
+// @Summary 当前用户关联外部账号
+// @Description 返回身份提供方的授权地址, 回调后关联到当前用户
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Param provider path string true "身份提供方"
+// @Success 200 {object} response.Response{data=systemRes.OidcAuthorizeResponse,msg=string} "获取成功"
+// @Router /oidc/{provider}/link [post]
+func (o *OidcApi) LinkOidc(c fiber.Ctx) error {
+	return OidcLink(c, system.RefreshChannelBackend)
+}
+
+// GetOidcIdentities 获取已关联的外部账号
+// @Tags Oidc
+// @Summary 获取当前用户已关联的外部账号
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Success 200 {object} response.Response{data=[]system.SysUserIdentity,msg=string} "获取成功"
+// @Router /oidc/identities [get]
+func (o *OidcApi) GetOidcIdentities(c fiber.Ctx) error {
+	return OidcIdentities(c)
+}
+
+// UnlinkOidc 解除外部账号关联
+// @Tags Oidc
+// @Summary 解除当前用户的外部账号关联
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "关联记录ID"
+// @Success 200 {object} response.Response{msg=string} "解除成功"
+// @Router /oidc/unlink [post]
+func (o *OidcApi) UnlinkOidc(c fiber.Ctx) error {
+	return OidcUnlink(c)
+}
+
+// OidcProviders 后台和前台共用, 返回已配置的身份提供方
+func OidcProviders(c fiber.Ctx) error {
+	return response.OkWithDetailed(oidcService.Providers(), "获取成功", c)
+}
+
+// OidcAuthorize 后台和前台共用, channel 决定回调页面和登录后签发的 token
+func OidcAuthorize(c fiber.Ctx, channel string, linkUserId uint) error {
+	url, err := oidcService.AuthURL(c.Context(), c.Params("provider"), channel, linkUserId)
+	if err != nil {
+		if errors.Is(err, systemServer.ErrOidcProvider) {
+			return response.FailWithMessage(systemServer.ErrOidcProvider.Error(), 3, err, c)
+		}
+		return response.FailWithMessage("连接身份提供方失败", 3, err, c)
+	}
+	return response.OkWithDetailed(systemRes.OidcAuthorizeResponse{URL: url}, "获取成功", c)
+}
+
+// OidcLink 后台和前台共用, 为当前登录的用户发起关联
+func OidcLink(c fiber.Ctx, channel string) error {
+	userId, err := utils.GetUserID(c)
+	if err != nil || userId == 0 {
+		return response.FailWithMessage401("未登录", 3, errors.Join(errors.New("未登录"), err), c)
+	}
+	return OidcAuthorize(c, channel, userId)
+}
+
+// OidcCallback 后台和前台共用, 登录成功后由 login 签发各端的 token
+func OidcCallback(c fiber.Ctx, channel string, login func(user *system.SysUser) error) error {
+	var req systemReq.OidcCallback
+	if err := c.Bind().Body(&req); err != nil || req.Code == "" || req.State == "" {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	user, linked, err := oidcService.Callback(c.Context(), channel, req.Code, req.State)
+	if err != nil {
+		for _, known := range []error{systemServer.ErrOidcState, systemServer.ErrOidcNotLinked, systemServer.ErrOidcLinked, systemServer.ErrOidcProvider} {
+			if errors.Is(err, known) {
+				return response.FailWithMessage(known.Error(), 3, err, c)
+			}
+		}
+		return response.FailWithMessage("外部账号登录失败", 3, err, c)
+	}
+	if linked {
+		return response.OkWithMessage("关联成功", c)
+	}
+	LoginSucceeded(NewLoginAttempt(c, channel, user.Username), user.ID)
+	return login(user)
+}
+
+// OidcIdentities 后台和前台共用, 当前用户已关联的外部账号
+func OidcIdentities(c fiber.Ctx) error {
+	userId, err := utils.GetUserID(c)
+	if err != nil {
+		return response.FailWithMessage401("未登录", 3, err, c)
+	}
+	list, err := oidcService.GetIdentities(userId)
+	if err != nil {
+		return response.FailWithMessage("获取失败", 3, err, c)
+	}
+	return response.OkWithDetailed(list, "获取成功", c)
+}
+
+// OidcUnlink 后台和前台共用, 解除当前用户的外部账号关联
+func OidcUnlink(c fiber.Ctx) error {
+	var req request.GetById
+	if err := c.Bind().Body(&req); err != nil || req.ID == 0 {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	userId, err := utils.GetUserID(c)
+	if err != nil {
+		return response.FailWithMessage401("未登录", 3, err, c)
+	}
+	if err = oidcService.Unlink(userId, req.Uint()); err != nil {
+		return response.FailWithMessage("解除失败", 3, err, c)
+	}
+	return response.OkWithMessage("解除成功", c)
+}
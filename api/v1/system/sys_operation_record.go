@@ -7,6 +7,7 @@ import (
 	"server/model/common/response"
 	"server/model/system"
 	systemReq "server/model/system/request"
+	"server/utils"
 
 	"github.com/gofiber/fiber/v3"
 )
@@ -110,7 +111,7 @@ func (s *OperationRecordApi) GetSysOperationRecordList(c fiber.Ctx) error {
 	var pageInfo systemReq.SysOperationRecordSearch
 	_ = c.Bind().Query(&pageInfo)
 	if pageInfo.TypePort == system.Backend {
-		if list, total, err := operationRecordService.GetSysOperationRecordInfoList(&pageInfo); err != nil {
+		if list, total, err := operationRecordService.GetSysOperationRecordInfoList(&pageInfo, authorityService.DataScope(utils.GetUserInfo(c), "user_id")); err != nil {
 			return response.FailWithMessage("获取失败", 3, err, c)
 		} else {
 			return response.OkWithDetailed(response.PageResult{
@@ -133,3 +134,14 @@ func (s *OperationRecordApi) GetSysOperationRecordList(c fiber.Ctx) error {
 		}
 	}
 }
+
+// @Tags SysOperationRecord
+// @Summary 获取操作记录异步写入的计数
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Success 200 {object} response.Response{data=systemRes.SysOperationRecordStats,msg=string} "队列长度和已写入/丢弃/失败的条数"
+// @Failure 401 {object} response.Response{msg=string} "未授权"
+// @Router /sysOperationRecord/getSysOperationRecordStats [get]
+func (s *OperationRecordApi) GetSysOperationRecordStats(c fiber.Ctx) error {
+	return response.OkWithDetailed(operationRecordWriter.Stats(), "获取成功", c)
+}
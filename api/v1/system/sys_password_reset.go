@@ -0,0 +1,74 @@
+package system
+
+import (
+	"errors"
+
+	"server/model/common/response"
+	"server/model/system"
+	systemReq "server/model/system/request"
+	systemServer "server/service/system"
+	"server/utils"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// forgotPasswordMessage 无论邮箱是否注册都返回同样的提示
+const forgotPasswordMessage = "如果该邮箱已注册, 您将收到一封重置密码的邮件"
+
+// ForgotPassword 找回密码
+// @Tags Base
+// @Summary 通过邮箱找回密码
+// @Description 向注册邮箱发送重置链接, 按邮箱和 ip 限流, 不会提示邮箱是否注册
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.ForgotPassword true "邮箱"
+// @Success 200 {object} response.Response{msg=string} "已受理"
+// @Router /base/forgotPassword [post]
+func (b *BaseApi) ForgotPassword(c fiber.Ctx) error {
+	return ForgotPassword(c, system.RefreshChannelBackend)
+}
+
+// ConfirmPasswordReset 重置密码
+// @Tags Base
+// @Summary 使用邮件中的令牌设置新密码
+// @Description 令牌只能使用一次, 重置后该用户已有的登录全部失效
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.ConfirmPasswordReset true "令牌, 新密码"
+// @Success 200 {object} response.Response{msg=string} "重置成功"
+// @Router /base/confirmPasswordReset [post]
+func (b *BaseApi) ConfirmPasswordReset(c fiber.Ctx) error {
+	return ConfirmPasswordReset(c)
+}
+
+// ForgotPassword 后台和前台共用的找回密码处理, channel 决定邮件中的链接地址
+func ForgotPassword(c fiber.Ctx, channel string) error {
+	var req systemReq.ForgotPassword
+	_ = c.Bind().Body(&req)
+	if err := utils.Verify(req, utils.ForgotPasswordVerify); err != nil {
+		return response.FailWithMessage(err.Error(), 3, err, c)
+	}
+	if err := passwordResetService.RequestReset(channel, req.Email, c.IP()); err != nil {
+		if errors.Is(err, systemServer.ErrPasswordResetLimited) {
+			return response.FailWithMessage(systemServer.ErrPasswordResetLimited.Error(), 3, err, c)
+		}
+		return response.FailWithMessage("请求失败, 请稍后再试", 3, err, c)
+	}
+	return response.OkWithMessage(forgotPasswordMessage, c)
+}
+
+// ConfirmPasswordReset 后台和前台共用的重置密码处理
+func ConfirmPasswordReset(c fiber.Ctx) error {
+	var req systemReq.ConfirmPasswordReset
+	_ = c.Bind().Body(&req)
+	if err := utils.Verify(req, utils.ConfirmPasswordResetVerify); err != nil {
+		return response.FailWithMessage(err.Error(), 3, err, c)
+	}
+	if err := passwordResetService.ConfirmReset(req.Token, req.Password); err != nil {
+		if errors.Is(err, systemServer.ErrPasswordResetInvalid) {
+			return response.FailWithMessage(err.Error(), 3, err, c)
+		}
+		return response.FailWithMessage("重置失败", 3, err, c)
+	}
+	return response.OkWithMessage("重置成功, 请使用新密码登录", c)
+}
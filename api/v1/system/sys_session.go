@@ -0,0 +1,157 @@
+package system
+
+import (
+	"server/model/common/request"
+	"server/model/common/response"
+	"server/model/system"
+	systemReq "server/model/system/request"
+	systemServer "server/service/system"
+	"server/utils"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type SessionApi struct{}
+
+// SysUserChannels 后台和前台都是 sys_users 中的用户, 查看和作废会话时一起处理
+var SysUserChannels = []string{system.RefreshChannelBackend, system.RefreshChannelFrontend}
+
+// NewSessionClient 当前请求的客户端信息
+func NewSessionClient(c fiber.Ctx) systemServer.SessionClient {
+	return systemServer.SessionClient{Ip: c.IP(), UserAgent: c.Get("User-Agent")}
+}
+
+// GetSessionList 获取当前用户的会话
+// @Tags Session
+// @Summary 获取当前用户已登录的设备
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Success 200 {object} response.Response{data=[]system.SysSession,msg=string} "获取成功"
+// @Router /session/getList [get]
+func (s *SessionApi) GetSessionList(c fiber.Ctx) error {
+	return SessionList(c, SysUserChannels)
+}
+
+// RevokeSession 作废当前用户的一个会话
+// @Tags Session
+// @Summary 退出指定设备
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "会话ID"
+// @Success 200 {object} response.Response{msg=string} "退出成功"
+// @Router /session/revoke [post]
+func (s *SessionApi) RevokeSession(c fiber.Ctx) error {
+	return SessionRevoke(c, SysUserChannels)
+}
+
+// RevokeAllSessions 作废当前用户的全部会话
+// @Tags Session
+// @Summary 退出全部设备
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.RevokeSessions true "是否保留当前会话"
+// @Success 200 {object} response.Response{msg=string} "退出成功"
+// @Router /session/revokeAll [post]
+func (s *SessionApi) RevokeAllSessions(c fiber.Ctx) error {
+	return SessionRevokeAll(c, SysUserChannels)
+}
+
+// GetUserSessionList 管理员查看用户的会话
+// @Tags Session
+// @Summary 管理员查看用户已登录的设备
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Param data query request.GetById true "用户ID"
+// @Success 200 {object} response.Response{data=[]system.SysSession,msg=string} "获取成功"
+// @Router /session/getUserList [get]
+func (s *SessionApi) GetUserSessionList(c fiber.Ctx) error {
+	var req request.GetById
+	if err := c.Bind().Query(&req); err != nil || req.ID == 0 {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	list, err := sessionService.GetSessions(req.Uint(), SysUserChannels, "")
+	if err != nil {
+		return response.FailWithMessage("获取失败", 3, err, c)
+	}
+	return response.OkWithDetailed(list, "获取成功", c)
+}
+
+// RevokeUserSessions 管理员强制用户下线
+// @Tags Session
+// @Summary 管理员作废用户的全部会话
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "用户ID"
+// @Success 200 {object} response.Response{msg=string} "已强制下线"
+// @Router /session/revokeUser [post]
+func (s *SessionApi) RevokeUserSessions(c fiber.Ctx) error {
+	var req request.GetById
+	if err := c.Bind().Body(&req); err != nil || req.ID == 0 {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	if err := sessionService.RevokeAll(req.Uint(), SysUserChannels, ""); err != nil {
+		return response.FailWithMessage("操作失败", 3, err, c)
+	}
+	return response.OkWithMessage("已强制下线", c)
+}
+
+// SessionList 各端共用, 当前用户在 channels 中的会话
+func SessionList(c fiber.Ctx, channels []string) error {
+	userId, jti, err := sessionOwner(c)
+	if err != nil {
+		return response.FailWithMessage401("未登录", 3, err, c)
+	}
+	list, err := sessionService.GetSessions(userId, channels, jti)
+	if err != nil {
+		return response.FailWithMessage("获取失败", 3, err, c)
+	}
+	return response.OkWithDetailed(list, "获取成功", c)
+}
+
+// SessionRevoke 各端共用, 作废当前用户的一个会话
+func SessionRevoke(c fiber.Ctx, channels []string) error {
+	var req request.GetById
+	if err := c.Bind().Body(&req); err != nil || req.ID == 0 {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	userId, _, err := sessionOwner(c)
+	if err != nil {
+		return response.FailWithMessage401("未登录", 3, err, c)
+	}
+	if err = sessionService.Revoke(userId, channels, req.Uint()); err != nil {
+		return response.FailWithMessage("退出失败", 3, err, c)
+	}
+	return response.OkWithMessage("退出成功", c)
+}
+
+// SessionRevokeAll 各端共用, 作废当前用户的全部会话, 可以保留当前会话
+func SessionRevokeAll(c fiber.Ctx, channels []string) error {
+	var req systemReq.RevokeSessions
+	_ = c.Bind().Body(&req)
+	userId, jti, err := sessionOwner(c)
+	if err != nil {
+		return response.FailWithMessage401("未登录", 3, err, c)
+	}
+	if !req.ExceptCurrent {
+		jti = ""
+	}
+	if err = sessionService.RevokeAll(userId, channels, jti); err != nil {
+		return response.FailWithMessage("退出失败", 3, err, c)
+	}
+	return response.OkWithMessage("退出成功", c)
+}
+
+// sessionOwner 从 JWTAuth 或移动端中间件写入的 claims 中取出用户和会话
+func sessionOwner(c fiber.Ctx) (uint, string, error) {
+	if claims, ok := c.Locals("mobile_claims").(*utils.MobileClaims); ok {
+		return claims.ID, claims.RegisteredClaims.ID, nil
+	}
+	claims, err := utils.GetClaims(c)
+	if err != nil {
+		return 0, "", err
+	}
+	return claims.BaseClaims.ID, claims.RegisteredClaims.ID, nil
+}
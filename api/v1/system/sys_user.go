@@ -7,11 +7,14 @@ import (
 	"server/model/system"
 	"server/utils"
 	"strconv"
+	"time"
 
 	systemReq "server/model/system/request"
 	systemRes "server/model/system/response"
 
 	"github.com/gofiber/fiber/v3"
+	jwt "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
@@ -36,19 +39,22 @@ func (b *BaseApi) Login(c fiber.Ctx) error {
 	if err := utils.Verify(l, utils.LoginVerify); err != nil {
 		return response.FailWithMessage(err.Error(), 3, err, c)
 	}
-	if store.Verify(l.CaptchaId, l.Captcha, true) {
-		if user, err := userService.Login(l.Username, l.Password); err != nil {
-			errorMessage := err.Error()
-			if err == gorm.ErrRecordNotFound {
-				errorMessage = "账户或密码错误"
-			}
-			return response.FailWithMessage("登录失败："+errorMessage, 3, err, c)
-		} else {
-			return b.tokenNext(c, user)
-		}
-	} else {
-		return response.FailWithMessage("验证码错误", 3, nil, c)
+	attempt := NewLoginAttempt(c, system.RefreshChannelBackend, l.Username)
+	if state, err := LoginGuardCheck(attempt, "", "", true); err != nil {
+		return LoginGuardFail(c, attempt, state, err)
+	}
+	if !store.Verify(l.CaptchaId, l.Captcha, true) {
+		return LoginFailed(c, attempt, 0, "captcha", "验证码错误", nil)
+	}
+	user, err := userService.Login(l.Username, l.Password)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return LoginFailed(c, attempt, 0, "password", "登录失败：账户或密码错误", err)
+	}
+	if err != nil {
+		return response.FailWithMessage("登录失败："+err.Error(), 3, err, c)
 	}
+	LoginSucceeded(attempt, user.ID)
+	return b.loginNext(c, user)
 }
 
 // LoginToken 用户登录获取token
@@ -69,16 +75,37 @@ func (b *BaseApi) LoginToken(c fiber.Ctx) error {
 	if err := utils.Verify(l, utils.TokenLoginVerify); err != nil {
 		return response.FailWithMessage(err.Error(), 3, err, c)
 	}
+	attempt := NewLoginAttempt(c, system.RefreshChannelBackend, l.Username)
+	if state, err := LoginGuardCheck(attempt, l.CaptchaId, l.Captcha, false); err != nil {
+		return LoginGuardFail(c, attempt, state, err)
+	}
 	u := &system.SysUser{Username: l.Username, Password: l.Password}
-	if user, err := userService.LoginToken(u); err != nil {
+	user, err := userService.LoginToken(u)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return LoginFailed(c, attempt, 0, "password", "账户或密码错误", err)
+	}
+	if err != nil {
 		return response.FailWithMessage(err.Error(), 3, err, c)
-	} else {
-		return b.tokenNext(c, user)
 	}
+	LoginSucceeded(attempt, user.ID)
+	return b.loginNext(c, user)
 }
 
-// 登录以后签发jwt
-func (b *BaseApi) tokenNext(c fiber.Ctx, user *system.SysUser) error {
+// 登录以后签发jwt, 同时开启新的刷新令牌族
+func (b *BaseApi) tokenNext(c fiber.Ctx, user *system.SysUser, recoveryCodes []string) error {
+	jti := uuid.NewString()
+	refreshToken, refreshExpiresAt, err := refreshTokenService.Issue(system.RefreshChannelBackend, user.ID, jti)
+	if err != nil {
+		return response.FailWithMessage("签发刷新令牌失败", 3, err, c)
+	}
+	return b.accessTokenNext(c, user, jti, refreshToken, refreshExpiresAt, recoveryCodes)
+}
+
+// 签发访问令牌并记录会话, 多点登录拦截时作废该用户上一次的令牌
+func (b *BaseApi) accessTokenNext(c fiber.Ctx, user *system.SysUser, jti, refreshToken string, refreshExpiresAt time.Time, recoveryCodes []string) error {
+	if err := sessionService.Record(system.RefreshChannelBackend, user.ID, jti, NewSessionClient(c), refreshExpiresAt); err != nil {
+		return response.FailWithMessage("记录登录会话失败", 3, err, c)
+	}
 	j := utils.NewJWT() // 唯一签名
 	claims := j.CreateClaims(systemReq.BaseClaims{
 		UUID:        user.UUID,
@@ -87,27 +114,29 @@ func (b *BaseApi) tokenNext(c fiber.Ctx, user *system.SysUser) error {
 		Username:    user.Username,
 		AuthorityId: user.AuthorityId,
 	})
+	claims.RegisteredClaims.ID = jti
+	claims.RegisteredClaims.Audience = jwt.ClaimStrings{system.RefreshChannelBackend} // 区分签发渠道, 后台接口只接受后台令牌
 	token, err := j.CreateToken(claims)
 	if err != nil {
 		return response.FailWithMessage(err.Error(), 3, err, c)
 	}
+	loginResponse := systemRes.LoginResponse{
+		User:             *user,
+		Token:            token,
+		ExpiresAt:        claims.RegisteredClaims.ExpiresAt.Unix(),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refreshExpiresAt.Unix(),
+		RecoveryCodes:    recoveryCodes,
+	}
 	if !global.CONFIG.System.UseMultipoint {
-		return response.OkWithDetailed(systemRes.LoginResponse{
-			User:      *user,
-			Token:     token,
-			ExpiresAt: claims.RegisteredClaims.ExpiresAt.Unix(),
-		}, "登录成功", c)
+		return response.OkWithDetailed(loginResponse, "登录成功", c)
 	}
 
 	if jwtStr, err := jwtService.GetRedisJWT(user.Username); errors.Is(err, redis.Nil) {
 		if err := jwtService.SetRedisJWT(token, user.Username); err != nil {
 			return response.FailWithMessage("设置登录状态失败", 3, err, c)
 		}
-		return response.OkWithDetailed(systemRes.LoginResponse{
-			User:      *user,
-			Token:     token,
-			ExpiresAt: claims.RegisteredClaims.ExpiresAt.Unix(),
-		}, "登录成功", c)
+		return response.OkWithDetailed(loginResponse, "登录成功", c)
 	} else if err != nil {
 		return response.FailWithMessage("设置登录状态失败", 3, err, c)
 	} else {
@@ -119,12 +148,34 @@ func (b *BaseApi) tokenNext(c fiber.Ctx, user *system.SysUser) error {
 		if err := jwtService.SetRedisJWT(token, user.Username); err != nil {
 			return response.FailWithMessage("设置登录状态失败", 3, err, c)
 		}
-		return response.OkWithDetailed(systemRes.LoginResponse{
-			User:      *user,
-			Token:     token,
-			ExpiresAt: claims.RegisteredClaims.ExpiresAt.Unix(),
-		}, "登录成功", c)
+		return response.OkWithDetailed(loginResponse, "登录成功", c)
+	}
+}
+
+// RefreshToken 使用刷新令牌换新
+// @Tags Base
+// @Summary 使用刷新令牌换取新的token
+// @Description 旧的刷新令牌立即失效, 重复使用已失效的刷新令牌会作废该次登录的全部令牌
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.RefreshToken true "刷新令牌"
+// @Success 200 {object} response.Response{data=systemRes.LoginResponse,msg=string} "换新成功"
+// @Failure 401 {object} response.Response{msg=string} "刷新令牌无效"
+// @Router /base/refresh [post]
+func (b *BaseApi) RefreshToken(c fiber.Ctx) error {
+	var req systemReq.RefreshToken
+	if err := c.Bind().Body(&req); err != nil || req.RefreshToken == "" {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	record, refreshToken, refreshExpiresAt, err := refreshTokenService.Rotate(system.RefreshChannelBackend, req.RefreshToken)
+	if err != nil {
+		return response.FailWithDetailed401(fiber.Map{"reload": true}, err.Error(), err, c)
+	}
+	user, err := userService.FindUserById(int(record.UserId))
+	if err != nil {
+		return response.FailWithDetailed401(fiber.Map{"reload": true}, "用户不存在", err, c)
 	}
+	return b.accessTokenNext(c, user, record.FamilyId, refreshToken, refreshExpiresAt, nil)
 }
 
 // Register
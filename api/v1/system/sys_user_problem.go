@@ -1,11 +1,14 @@
 package system
 
 import (
+	"errors"
 	"strconv"
 
 	global "server/model"
 	"server/model/common/response"
 	problemReq "server/model/system"
+	systemServer "server/service/system"
+	"server/utils"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v3"
@@ -100,7 +103,7 @@ type VerifyProblemSettingData struct {
 
 // VerifyAnswer 验证问题答案
 // @Summary 验证问题答案
-// @Description 验证用户提供的安全问题答案是否正确
+// @Description 验证当前用户的安全问题答案是否正确
 // @Tags 用户问题管理
 // @Accept json
 // @Produce json
@@ -119,7 +122,15 @@ func (*UserProblem) VerifyAnswer(c fiber.Ctx) error {
 		}
 		return response.FailWithDetailed(errs.Translate(global.Validate), "传错参数", 3, err, c)
 	}
-	ispassed, err := userProblem.VerifyAnswer(&dataProblem.Data)
+	userId, err := utils.GetUserID(c)
+	if err != nil {
+		return response.FailWithMessage(err.Error(), 3, err, c)
+	}
+	// 只能校验自己的问题
+	ispassed, err := userProblem.VerifyAnswer(userId, &dataProblem.Data)
+	if errors.Is(err, systemServer.ErrRecoveryLocked) {
+		return response.FailWithMessage(systemServer.ErrRecoveryLocked.Error(), 3, err, c)
+	}
 	if err != nil {
 		return response.FailWithDetailed(err, "未查到此问题", 3, err, c)
 	}
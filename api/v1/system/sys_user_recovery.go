@@ -0,0 +1,68 @@
+package system
+
+import (
+	"errors"
+
+	"server/model/common/response"
+	systemReq "server/model/system/request"
+	systemServer "server/service/system"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// RecoveryQuestions 获取找回账号的安全问题
+// @Tags Base
+// @Summary 通过安全问题找回账号, 获取问题
+// @Description 随机抽取用户设置的部分安全问题, 答错次数过多时锁定
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.RecoveryQuestions true "用户名"
+// @Success 200 {object} response.Response{data=systemRes.RecoveryQuestionsResponse,msg=string} "获取成功"
+// @Router /base/recovery/questions [post]
+func (b *BaseApi) RecoveryQuestions(c fiber.Ctx) error {
+	var req systemReq.RecoveryQuestions
+	if err := c.Bind().Body(&req); err != nil || req.Username == "" {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	res, err := userProblem.RecoveryQuestions(req.Username, c.IP())
+	if err != nil {
+		return recoveryFail(c, err)
+	}
+	return response.OkWithDetailed(res, "获取成功", c)
+}
+
+// VerifyRecovery 回答安全问题
+// @Tags Base
+// @Summary 通过安全问题找回账号, 回答问题
+// @Description 每次获取的问题只能作答一次, 全部答对后返回重置令牌, 使用 base/confirmPasswordReset 设置新密码
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.RecoveryAnswers true "凭证, 答案"
+// @Success 200 {object} response.Response{data=systemRes.RecoveryTokenResponse,msg=string} "验证成功"
+// @Router /base/recovery/verify [post]
+func (b *BaseApi) VerifyRecovery(c fiber.Ctx) error {
+	var req systemReq.RecoveryAnswers
+	if err := c.Bind().Body(&req); err != nil || req.Challenge == "" || len(req.Answers) == 0 {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	res, err := userProblem.VerifyRecovery(req, c.IP())
+	if err != nil {
+		return recoveryFail(c, err)
+	}
+	return response.OkWithDetailed(res, "验证成功, 请设置新密码", c)
+}
+
+func recoveryFail(c fiber.Ctx, err error) error {
+	for _, known := range []error{
+		systemServer.ErrPasswordResetLimited,
+		systemServer.ErrRecoveryLocked,
+		systemServer.ErrRecoveryUnavailable,
+		systemServer.ErrRecoveryChallengeInvalid,
+		systemServer.ErrRecoveryAnswerWrong,
+	} {
+		if errors.Is(err, known) {
+			return response.FailWithMessage(known.Error(), 3, err, c)
+		}
+	}
+	return response.FailWithMessage("请求失败, 请稍后再试", 3, err, c)
+}
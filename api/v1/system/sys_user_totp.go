@@ -0,0 +1,274 @@
+package system
+
+import (
+	"errors"
+
+	"server/model/common/request"
+	"server/model/common/response"
+	"server/model/system"
+	systemReq "server/model/system/request"
+	systemRes "server/model/system/response"
+	systemServer "server/service/system"
+	"server/utils"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type TotpApi struct{}
+
+// 密码校验通过后, 已开启两步验证或角色要求两步验证的用户先返回第二步登录凭证
+func (b *BaseApi) loginNext(c fiber.Ctx, user *system.SysUser) error {
+	enabled, err := totpService.Enabled(user.ID)
+	if err != nil {
+		return response.FailWithMessage("登录失败", 3, err, c)
+	}
+	if !enabled && !totpService.Required(user) {
+		return b.tokenNext(c, user, nil)
+	}
+	challenge, expiresAt, err := totpService.CreateChallenge(user.ID)
+	if err != nil {
+		return response.FailWithMessage("登录失败", 3, err, c)
+	}
+	msg := "请输入两步验证码"
+	if !enabled {
+		msg = "当前角色必须开启两步验证, 请先绑定验证器"
+	}
+	return response.OkWithDetailed(systemRes.TotpChallengeResponse{
+		NeedTotp:  true,
+		Enrolled:  enabled,
+		Challenge: challenge,
+		ExpiresAt: expiresAt.Unix(),
+	}, msg, c)
+}
+
+// TotpLogin 两步验证登录
+// @Tags Base
+// @Summary 两步验证登录
+// @Description 使用登录接口返回的 challenge 和验证器中的验证码(或恢复码)完成登录
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.TotpChallenge true "登录凭证, 验证码"
+// @Success 200 {object} response.Response{data=systemRes.LoginResponse,msg=string} "登录成功"
+// @Failure 401 {object} response.Response{msg=string} "验证码错误或凭证失效"
+// @Router /base/totp/login [post]
+func (b *BaseApi) TotpLogin(c fiber.Ctx) error {
+	var req systemReq.TotpChallenge
+	if err := c.Bind().Body(&req); err != nil || req.Challenge == "" || req.Code == "" {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	userId, err := totpService.CompleteChallenge(req.Challenge, req.Code)
+	if err != nil {
+		return totpFail(c, err)
+	}
+	user, err := userService.FindUserById(int(userId))
+	if err != nil {
+		return response.FailWithDetailed401(fiber.Map{"reload": true}, "用户不存在", err, c)
+	}
+	return b.tokenNext(c, user, nil)
+}
+
+// TotpEnroll 登录时绑定验证器
+// @Tags Base
+// @Summary 登录时绑定验证器
+// @Description 角色要求两步验证但尚未绑定时, 使用登录凭证生成验证器密钥
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.TotpChallenge true "登录凭证"
+// @Success 200 {object} response.Response{data=systemRes.TotpSetupResponse,msg=string} "生成成功"
+// @Failure 401 {object} response.Response{msg=string} "凭证失效"
+// @Router /base/totp/enroll [post]
+func (b *BaseApi) TotpEnroll(c fiber.Ctx) error {
+	var req systemReq.TotpChallenge
+	if err := c.Bind().Body(&req); err != nil || req.Challenge == "" {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	userId, err := totpService.ChallengeUser(req.Challenge)
+	if err != nil {
+		return totpFail(c, err)
+	}
+	user, err := userService.FindUserById(int(userId))
+	if err != nil {
+		return response.FailWithDetailed401(fiber.Map{"reload": true}, "用户不存在", err, c)
+	}
+	return totpSetup(c, user)
+}
+
+// TotpActivate 登录时确认绑定并登录
+// @Tags Base
+// @Summary 登录时确认绑定验证器并登录
+// @Description 校验验证码后开启两步验证并签发 token, 同时返回只显示一次的恢复码
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.TotpChallenge true "登录凭证, 验证码"
+// @Success 200 {object} response.Response{data=systemRes.LoginResponse,msg=string} "登录成功"
+// @Failure 401 {object} response.Response{msg=string} "验证码错误或凭证失效"
+// @Router /base/totp/activate [post]
+func (b *BaseApi) TotpActivate(c fiber.Ctx) error {
+	var req systemReq.TotpChallenge
+	if err := c.Bind().Body(&req); err != nil || req.Challenge == "" || req.Code == "" {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	userId, recoveryCodes, err := totpService.ActivateChallenge(req.Challenge, req.Code)
+	if err != nil {
+		return totpFail(c, err)
+	}
+	user, err := userService.FindUserById(int(userId))
+	if err != nil {
+		return response.FailWithDetailed401(fiber.Map{"reload": true}, "用户不存在", err, c)
+	}
+	return b.tokenNext(c, user, recoveryCodes)
+}
+
+// GetTotpStatus 获取两步验证状态
+// @Tags Totp
+// @Summary 获取当前用户的两步验证状态
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Success 200 {object} response.Response{data=systemRes.TotpStatusResponse,msg=string} "获取成功"
+// @Router /totp/status [get]
+func (t *TotpApi) GetTotpStatus(c fiber.Ctx) error {
+	user, err := userService.GetUserInfo(utils.GetUserUuid(c))
+	if err != nil {
+		return response.FailWithMessage("获取失败", 3, err, c)
+	}
+	enabled, err := totpService.Enabled(user.ID)
+	if err != nil {
+		return response.FailWithMessage("获取失败", 3, err, c)
+	}
+	left, err := totpService.RecoveryCodesLeft(user.ID)
+	if err != nil {
+		return response.FailWithMessage("获取失败", 3, err, c)
+	}
+	return response.OkWithDetailed(systemRes.TotpStatusResponse{
+		Enabled:       enabled,
+		Required:      totpService.Required(&user),
+		RecoveryCodes: left,
+	}, "获取成功", c)
+}
+
+// SetupTotp 生成验证器密钥
+// @Tags Totp
+// @Summary 生成验证器密钥
+// @Description 返回密钥和 otpauth 地址, 调用 enable 校验验证码后才生效
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Success 200 {object} response.Response{data=systemRes.TotpSetupResponse,msg=string} "生成成功"
+// @Router /totp/setup [post]
+func (t *TotpApi) SetupTotp(c fiber.Ctx) error {
+	user, err := userService.GetUserInfo(utils.GetUserUuid(c))
+	if err != nil {
+		return response.FailWithMessage("获取用户失败", 3, err, c)
+	}
+	return totpSetup(c, &user)
+}
+
+// EnableTotp 开启两步验证
+// @Tags Totp
+// @Summary 校验验证码并开启两步验证
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.TotpCode true "验证码"
+// @Success 200 {object} response.Response{data=[]string,msg=string} "开启成功, 返回只显示一次的恢复码"
+// @Router /totp/enable [post]
+func (t *TotpApi) EnableTotp(c fiber.Ctx) error {
+	var req systemReq.TotpCode
+	if err := c.Bind().Body(&req); err != nil || req.Code == "" {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	userId, err := utils.GetUserID(c)
+	if err != nil {
+		return response.FailWithMessage401("未登录", 3, err, c)
+	}
+	recoveryCodes, err := totpService.Enable(userId, req.Code)
+	if err != nil {
+		return response.FailWithMessage(err.Error(), 3, err, c)
+	}
+	return response.OkWithDetailed(recoveryCodes, "开启成功, 请妥善保存恢复码", c)
+}
+
+// DisableTotp 关闭两步验证
+// @Tags Totp
+// @Summary 关闭两步验证
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.TotpCode true "验证码或恢复码"
+// @Success 200 {object} response.Response{msg=string} "关闭成功"
+// @Router /totp/disable [post]
+func (t *TotpApi) DisableTotp(c fiber.Ctx) error {
+	var req systemReq.TotpCode
+	if err := c.Bind().Body(&req); err != nil || req.Code == "" {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	user, err := userService.GetUserInfo(utils.GetUserUuid(c))
+	if err != nil {
+		return response.FailWithMessage("获取用户失败", 3, err, c)
+	}
+	if err = totpService.Disable(&user, req.Code); err != nil {
+		return response.FailWithMessage(err.Error(), 3, err, c)
+	}
+	return response.OkWithMessage("关闭成功", c)
+}
+
+// RegenerateRecoveryCodes 重新生成恢复码
+// @Tags Totp
+// @Summary 重新生成恢复码
+// @Description 旧的恢复码全部作废
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body systemReq.TotpCode true "验证码或恢复码"
+// @Success 200 {object} response.Response{data=[]string,msg=string} "生成成功"
+// @Router /totp/recoveryCodes [post]
+func (t *TotpApi) RegenerateRecoveryCodes(c fiber.Ctx) error {
+	var req systemReq.TotpCode
+	if err := c.Bind().Body(&req); err != nil || req.Code == "" {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	userId, err := utils.GetUserID(c)
+	if err != nil {
+		return response.FailWithMessage401("未登录", 3, err, c)
+	}
+	recoveryCodes, err := totpService.RegenerateRecoveryCodes(userId, req.Code)
+	if err != nil {
+		return response.FailWithMessage(err.Error(), 3, err, c)
+	}
+	return response.OkWithDetailed(recoveryCodes, "生成成功, 请妥善保存恢复码", c)
+}
+
+// ResetTotp 重置用户的两步验证
+// @Tags Totp
+// @Summary 管理员重置用户的两步验证
+// @Description 用于丢失验证器的用户, 角色要求两步验证时用户下次登录需要重新绑定
+// @Security ApiKeyAuth
+// @Accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "用户ID"
+// @Success 200 {object} response.Response{msg=string} "重置成功"
+// @Router /totp/reset [post]
+func (t *TotpApi) ResetTotp(c fiber.Ctx) error {
+	var req request.GetById
+	if err := c.Bind().Body(&req); err != nil || req.ID == 0 {
+		return response.FailWithMessage400("参数错误", 3, err, c)
+	}
+	if err := totpService.Reset(uint(req.ID)); err != nil {
+		return response.FailWithMessage("重置失败", 3, err, c)
+	}
+	return response.OkWithMessage("重置成功", c)
+}
+
+func totpSetup(c fiber.Ctx, user *system.SysUser) error {
+	secret, uri, err := totpService.Setup(user)
+	if err != nil {
+		return response.FailWithMessage(err.Error(), 3, err, c)
+	}
+	return response.OkWithDetailed(systemRes.TotpSetupResponse{Secret: secret, URI: uri}, "生成成功", c)
+}
+
+func totpFail(c fiber.Ctx, err error) error {
+	if errors.Is(err, systemServer.ErrTotpChallengeInvalid) {
+		return response.FailWithDetailed401(fiber.Map{"reload": true}, err.Error(), err, c)
+	}
+	return response.FailWithMessage(err.Error(), 3, err, c)
+}
@@ -44,6 +44,13 @@ var SystemApiSet = wire.NewSet(
 	ProvideProblemApi,
 	ProvideSystemUserApi,
 	ProvideInitDBApi,
+	ProvideMigrationApi,
+	ProvideTotpApi,
+	ProvideLoginGuardApi,
+	ProvideOidcApi,
+	ProvideApiKeyApi,
+	ProvideSessionApi,
+	ProvideAuditLogApi,
 )
 
 // FrontendApiSet Frontend API 集合
@@ -175,6 +182,34 @@ func ProvideInitDBApi(initDBService *systemService.InitDBService) *system.DBApi
 	return &system.DBApi{}
 }
 
+func ProvideMigrationApi(migrationService *systemService.MigrationService) *system.MigrationApi {
+	return &system.MigrationApi{}
+}
+
+func ProvideTotpApi(totpService *systemService.TotpService) *system.TotpApi {
+	return &system.TotpApi{}
+}
+
+func ProvideLoginGuardApi(loginGuardService *systemService.LoginGuardService) *system.LoginGuardApi {
+	return &system.LoginGuardApi{}
+}
+
+func ProvideOidcApi(oidcService *systemService.OidcService) *system.OidcApi {
+	return &system.OidcApi{}
+}
+
+func ProvideApiKeyApi(apiKeyService *systemService.ApiKeyService) *system.ApiKeyApi {
+	return &system.ApiKeyApi{}
+}
+
+func ProvideSessionApi(sessionService *systemService.SessionService) *system.SessionApi {
+	return &system.SessionApi{}
+}
+
+func ProvideAuditLogApi(auditLogService *systemService.AuditLogService) *system.AuditLogApi {
+	return &system.AuditLogApi{}
+}
+
 // ========== Frontend APIs ==========
 
 func ProvideFrontendArticleApi(articleService *frontendService.Article) *frontend.ArticleApi {
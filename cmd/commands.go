@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"server/core"
+	"server/model/system/request"
+)
+
+var commands = map[string]func(args []string) error{
+	"serve":        serve,
+	"initdb":       initDB,
+	"migrate":      migrate,
+	"create-admin": createAdmin,
+	"check-config": checkConfig,
+	"encrypt-data": encryptData,
+}
+
+// newFlagSet 创建子命令参数, 所有子命令都支持 -c 指定配置目录
+func newFlagSet(name string) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	dir := fs.String("c", "", "配置目录, 未指定时读取环境变量 SERVER_CONFIG, 默认 ./conf/")
+	return fs, dir
+}
+
+func serve(args []string) error {
+	fs, dir := newFlagSet("serve")
+	_ = fs.Parse(args)
+	core.SetConfigDir(*dir)
+	core.RunServer()
+	return nil
+}
+
+func initDB(args []string) error {
+	var conf request.InitDB
+	fs, dir := newFlagSet("initdb")
+	fs.StringVar(&conf.DBType, "db-type", "mysql", "数据库类型 mysql | pgsql | sqlite")
+	fs.StringVar(&conf.Host, "host", "127.0.0.1", "数据库地址")
+	fs.StringVar(&conf.Port, "port", "", "数据库端口")
+	fs.StringVar(&conf.UserName, "username", "", "数据库用户名")
+	fs.StringVar(&conf.Password, "password", os.Getenv("SERVER_DB_PASSWORD"), "数据库密码, 默认读取环境变量 SERVER_DB_PASSWORD")
+	fs.StringVar(&conf.DBName, "db-name", "", "数据库名")
+	fs.StringVar(&conf.DBPath, "db-path", "", "sqlite 数据库文件目录")
+	_ = fs.Parse(args)
+	if conf.DBName == "" {
+		return fmt.Errorf("-db-name 不能为空")
+	}
+	core.SetConfigDir(*dir)
+	if err := core.RunInitDB(conf); err != nil {
+		return err
+	}
+	fmt.Println("数据库初始化成功")
+	return nil
+}
+
+// migrate 支持 server migrate up -steps 1 与 server migrate -steps 1 up 两种写法
+func migrate(args []string) error {
+	fs, dir := newFlagSet("migrate")
+	steps := fs.Int("steps", 0, "执行步数, up 时 0 为全部, down 时默认 1")
+	version := fs.Int64("version", 0, "baseline 时标记到该版本为止, 0 为全部")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "用法: server migrate [up|down|status|baseline] [参数]")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+	action := fs.Arg(0)
+	if fs.NArg() > 1 {
+		_ = fs.Parse(fs.Args()[1:])
+	}
+	core.SetConfigDir(*dir)
+	return core.RunMigrate(action, *steps, *version, os.Stdout)
+}
+
+func createAdmin(args []string) error {
+	fs, dir := newFlagSet("create-admin")
+	username := fs.String("username", "", "登录名")
+	nickName := fs.String("nickname", "超级管理员", "昵称")
+	authorityId := fs.String("authority", "888", "角色ID")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "用法: server create-admin -username 登录名 [参数]")
+		fmt.Fprintln(fs.Output(), "登录密码读取环境变量 SERVER_ADMIN_PASSWORD, 未设置时从标准输入读取一行")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+	core.SetConfigDir(*dir)
+	password, err := readAdminPassword(os.Stdin)
+	if err != nil {
+		return err
+	}
+	if err = core.CreateAdmin(*username, password, *nickName, *authorityId); err != nil {
+		return err
+	}
+	fmt.Printf("用户 %s 创建成功\n", *username)
+	return nil
+}
+
+// readAdminPassword 密码不通过命令行参数传入, 避免留在 shell 历史和进程列表中
+func readAdminPassword(in io.Reader) (string, error) {
+	if password := os.Getenv("SERVER_ADMIN_PASSWORD"); password != "" {
+		return password, nil
+	}
+	fmt.Fprint(os.Stderr, "请输入登录密码: ")
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func checkConfig(args []string) error {
+	fs, dir := newFlagSet("check-config")
+	_ = fs.Parse(args)
+	core.SetConfigDir(*dir)
+	return core.CheckConfig(os.Stdout)
+}
+
+func encryptData(args []string) error {
+	fs, dir := newFlagSet("encrypt-data")
+	batch := fs.Int("batch", 500, "每批处理的行数")
+	_ = fs.Parse(args)
+	core.SetConfigDir(*dir)
+	return core.RunEncryptData(*batch, os.Stdout)
+}
@@ -1,14 +1,49 @@
 package main
 
 import (
-	"server/core"
+	"fmt"
+	"os"
+	"strings"
+
 	_ "server/docs"
 )
 
+const usage = `用法: server <命令> [参数]
+
+命令:
+  serve          启动服务(默认)
+  initdb         初始化数据库
+  migrate        执行sql迁移 up | down | status | baseline
+  create-admin   创建后台用户
+  check-config   校验配置文件
+  encrypt-data   按加密配置重写已有的手机号/邮箱/真实姓名并重建盲索引
+
+通用参数:
+  -c string      配置目录, 未指定时读取环境变量 SERVER_CONFIG, 默认 ./conf/
+配置项可以用 SERVER_ 前缀的环境变量覆盖, 如 SERVER_SYSTEM_ADDR=8888
+
+使用 server <命令> -h 查看命令参数
+`
+
 //go:generate go env -w GO111MODULE=on
 //go:generate go env -w GOPROXY=https://goproxy.cn,direct
 //go:generate go mod tidy
 //go:generate go mod download
 func main() {
-	core.RunServer()
+	name, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		if name != "help" {
+			fmt.Fprintf(os.Stderr, "未知命令: %s\n\n", name)
+		}
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err := cmd(args); err != nil {
+		fmt.Fprintf(os.Stderr, "%s 执行失败: %v\n", name, err)
+		os.Exit(1)
+	}
 }
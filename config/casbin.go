@@ -1,5 +1,7 @@
 package config
 
 type Casbin struct {
-	ModelPath string `mapstructure:"model-path" json:"model-path" yaml:"model-path"` // 存放casbin模型的相对路径
+	ModelPath   string `mapstructure:"model-path" json:"model-path" yaml:"model-path"`       // 存放casbin模型的相对路径
+	Cache       bool   `mapstructure:"cache" json:"cache" yaml:"cache"`                      // 缓存鉴权结果, 策略变更时清空
+	CacheExpire int    `mapstructure:"cache-expire" json:"cache-expire" yaml:"cache-expire"` // 鉴权结果缓存时间(秒), 0 表示直到策略变更
 }
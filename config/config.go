@@ -12,6 +12,26 @@ type Server struct {
 	Casbin  Casbin     `mapstructure:"casbin" json:"casbin" yaml:"casbin"`
 	System  System     `mapstructure:"system" json:"system" yaml:"system"`
 	Captcha Captcha    `mapstructure:"captcha" json:"captcha" yaml:"captcha"` // 验证码配置
+	// 密码哈希
+	Password Password `mapstructure:"password" json:"password" yaml:"password"`
+	// 两步验证
+	Totp Totp `mapstructure:"totp" json:"totp" yaml:"totp"`
+	// 邮件找回密码
+	PasswordReset PasswordReset `mapstructure:"password-reset" json:"password-reset" yaml:"password-reset"`
+	// 安全问题找回账号
+	SecurityQuestion SecurityQuestion `mapstructure:"security-question" json:"security-question" yaml:"security-question"`
+	// 登录失败限制
+	LoginGuard LoginGuard `mapstructure:"login-guard" json:"login-guard" yaml:"login-guard"`
+	// OpenID Connect 登录
+	Oidc Oidc `mapstructure:"oidc" json:"oidc" yaml:"oidc"`
+	// 敏感字段脱敏
+	Masking Masking `mapstructure:"masking" json:"masking" yaml:"masking"`
+	// 敏感字段加密存储
+	Encryption Encryption `mapstructure:"encryption" json:"encryption" yaml:"encryption"`
+	// 接口限流
+	RateLimit RateLimit `mapstructure:"rate-limit" json:"rate-limit" yaml:"rate-limit"`
+	// 操作记录
+	OperationRecord OperationRecord `mapstructure:"operation-record" json:"operation-record" yaml:"operation-record"`
 	// auto
 	AutoCode Autocode `mapstructure:"autocode" json:"autocode" yaml:"autocode"`
 	// gorm
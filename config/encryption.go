@@ -0,0 +1,14 @@
+package config
+
+// Encryption 敏感字段加密存储配置, current 为空时按明文存储
+type Encryption struct {
+	Current  string          `mapstructure:"current" json:"current" yaml:"current"`       // 加密使用的密钥版本
+	Keys     []EncryptionKey `mapstructure:"keys" json:"keys" yaml:"keys"`                // 全部密钥, 轮换后旧版本需要保留到数据重新加密完成
+	IndexKey string          `mapstructure:"index-key" json:"index-key" yaml:"index-key"` // 盲索引的 HMAC 密钥, 修改后需要执行 encrypt-data 重建索引
+}
+
+// EncryptionKey 带版本的 AES 密钥
+type EncryptionKey struct {
+	Version string `mapstructure:"version" json:"version" yaml:"version"` // 版本号, 写入密文前缀, 不能包含 :
+	Key     string `mapstructure:"key" json:"key" yaml:"key"`             // base64 编码的 16/24/32 字节密钥
+}
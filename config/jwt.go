@@ -1,6 +1,10 @@
 package config
 
-import "crypto/rsa"
+import (
+	"crypto/rsa"
+	"errors"
+	"time"
+)
 
 type JWT struct {
 	JWTConfig
@@ -8,13 +12,56 @@ type JWT struct {
 }
 
 type PrivacyJWT struct {
-	ExpiresTime int64  `mapstructure:"expires-time" json:"expires-time" yaml:"expires-time"` // 过期时间
-	BufferTime  int64  `mapstructure:"buffer-time" json:"buffer-time" yaml:"buffer-time"`    // 缓冲时间
-	Issuer      string `mapstructure:"issuer" json:"issuer" yaml:"issuer"`                   // 签发者
-	SigningKey  string `mapstructure:"signing-key" json:"signing-key" yaml:"signing-key"`    // 签名密钥 HS256使用
+	ExpiresTime          int64    `mapstructure:"expires-time" json:"expires-time" yaml:"expires-time"`                               // 已停用, 原为令牌过期时间(小时), 配置后启动失败
+	AccessExpiresMinutes int64    `mapstructure:"access-expires-minutes" json:"access-expires-minutes" yaml:"access-expires-minutes"` // 访问令牌过期时间(分钟), 默认 15
+	RefreshTime          int64    `mapstructure:"refresh-time" json:"refresh-time" yaml:"refresh-time"`                               // 刷新令牌过期时间(小时), 默认 168
+	BufferTime           int64    `mapstructure:"buffer-time" json:"buffer-time" yaml:"buffer-time"`                                  // 缓冲时间
+	Issuer               string   `mapstructure:"issuer" json:"issuer" yaml:"issuer"`                                                 // 签发者
+	SigningKey           string   `mapstructure:"signing-key" json:"signing-key" yaml:"signing-key"`                                  // 签名密钥 HS256使用
+	ActiveKid            string   `mapstructure:"active-kid" json:"active-kid" yaml:"active-kid"`                                     // 当前签名使用的 kid, 为空时取第一个带私钥且未退役的 key
+	Keys                 []JWTKey `mapstructure:"keys" json:"keys" yaml:"keys"`                                                       // RS256 密钥列表, 轮换时新增 key 并切换 active-kid
+}
+
+// ErrExpiresTimeRemoved expires-time 原来的单位是小时, 继续沿用会被当成分钟, 改为报错要求换成新配置项
+var ErrExpiresTimeRemoved = errors.New("jwt.expires-time 已停用(原单位为小时), 请删除并改用 jwt.access-expires-minutes 配置访问令牌有效期(分钟)")
+
+// CheckRemoved 检查是否还在使用已停用的配置项
+func (j PrivacyJWT) CheckRemoved() error {
+	if j.ExpiresTime != 0 {
+		return ErrExpiresTimeRemoved
+	}
+	return nil
+}
+
+// AccessExpires 访问令牌有效期, 后台、前台和移动端签发的访问令牌共用
+func (j PrivacyJWT) AccessExpires() time.Duration {
+	if j.AccessExpiresMinutes <= 0 {
+		return 15 * time.Minute
+	}
+	return time.Duration(j.AccessExpiresMinutes) * time.Minute
+}
+
+// RefreshExpires 刷新令牌有效期
+func (j PrivacyJWT) RefreshExpires() time.Duration {
+	if j.RefreshTime <= 0 {
+		return 168 * time.Hour
+	}
+	return time.Duration(j.RefreshTime) * time.Hour
+}
+
+// JWTKey RS256 密钥, PEM 内容优先于文件
+type JWTKey struct {
+	Kid            string `mapstructure:"kid" json:"kid" yaml:"kid"`                                        // 为空时使用公钥指纹
+	PrivateKeyFile string `mapstructure:"private-key-file" json:"private-key-file" yaml:"private-key-file"` // 私钥 PEM 文件, 只用于验签的旧 key 可不配置
+	PublicKeyFile  string `mapstructure:"public-key-file" json:"public-key-file" yaml:"public-key-file"`    // 公钥 PEM 文件, 配置了私钥时可不配置
+	PrivateKey     string `mapstructure:"private-key" json:"private-key" yaml:"private-key"`                // 私钥 PEM 内容
+	PublicKey      string `mapstructure:"public-key" json:"public-key" yaml:"public-key"`                   // 公钥 PEM 内容
+	Retired        bool   `mapstructure:"retired" json:"retired" yaml:"retired"`                            // 已退役, 不再签发也不再验签
 }
 
 type JWTConfig struct {
-	PrivateKey *rsa.PrivateKey
-	PublicKey  *rsa.PublicKey
+	PrivateKey *rsa.PrivateKey           // 当前签名私钥
+	PublicKey  *rsa.PublicKey            // 当前签名公钥
+	Kid        string                    // 当前签名 kid
+	PublicKeys map[string]*rsa.PublicKey // 所有未退役的验签公钥, key 为 kid
 }
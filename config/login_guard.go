@@ -0,0 +1,12 @@
+package config
+
+// LoginGuard 登录失败限制配置, 后台、前台和移动端登录共用
+type LoginGuard struct {
+	AccountLimit int   `mapstructure:"account-limit" json:"account-limit" yaml:"account-limit"` // 同一账号在同一 ip 连续失败多少次后锁定该账号在该 ip 的登录
+	IpLimit      int   `mapstructure:"ip-limit" json:"ip-limit" yaml:"ip-limit"`                // 同一ip失败多少次后锁定
+	CaptchaAfter int   `mapstructure:"captcha-after" json:"captcha-after" yaml:"captcha-after"` // 账号或ip失败多少次后要求验证码
+	Window       int64 `mapstructure:"window" json:"window" yaml:"window"`                      // 失败次数统计窗口(秒)
+	LockTime     int64 `mapstructure:"lock-time" json:"lock-time" yaml:"lock-time"`             // 锁定时间(秒)
+	DelayBase    int64 `mapstructure:"delay-base" json:"delay-base" yaml:"delay-base"`          // 失败后的响应延迟(毫秒), 每多失败一次翻倍
+	DelayMax     int64 `mapstructure:"delay-max" json:"delay-max" yaml:"delay-max"`             // 响应延迟上限(毫秒)
+}
@@ -0,0 +1,18 @@
+package config
+
+// Masking 敏感字段脱敏配置, 只对配置了规则的角色生效, 未列出的字段原样显示
+type Masking struct {
+	Authorities []MaskingAuthority `mapstructure:"authorities" json:"authorities" yaml:"authorities"`
+}
+
+// MaskingAuthority 单个角色的脱敏规则
+type MaskingAuthority struct {
+	AuthorityId string         `mapstructure:"authority-id" json:"authority-id" yaml:"authority-id"` // 角色ID
+	Fields      []MaskingField `mapstructure:"fields" json:"fields" yaml:"fields"`
+}
+
+// MaskingField 字段的显示方式
+type MaskingField struct {
+	Field string `mapstructure:"field" json:"field" yaml:"field"` // 模型名.字段名, 如 SysUser.Phone, 模型名.* 或 * 匹配全部敏感字段
+	Mode  string `mapstructure:"mode" json:"mode" yaml:"mode"`    // full 完整显示 / partial 部分显示 / hidden 不显示
+}
@@ -0,0 +1,26 @@
+package config
+
+// Oidc OpenID Connect 登录配置, 可配置多个身份提供方
+type Oidc struct {
+	StateTime int64          `mapstructure:"state-time" json:"state-time" yaml:"state-time"` // 发起登录到回调的有效期(秒)
+	Providers []OidcProvider `mapstructure:"providers" json:"providers" yaml:"providers"`
+}
+
+// OidcProvider 单个身份提供方, 使用授权码 + PKCE 流程
+type OidcProvider struct {
+	Name                  string   `mapstructure:"name" json:"name" yaml:"name"`                                                       // 路由中使用的名称
+	Issuer                string   `mapstructure:"issuer" json:"issuer" yaml:"issuer"`                                                 // issuer, 未配置各端点时从 /.well-known/openid-configuration 获取
+	ClientId              string   `mapstructure:"client-id" json:"client-id" yaml:"client-id"`                                        // 客户端ID
+	ClientSecret          string   `mapstructure:"client-secret" json:"-" yaml:"client-secret"`                                        // 客户端密钥, 公开客户端可留空
+	Scopes                []string `mapstructure:"scopes" json:"scopes" yaml:"scopes"`                                                 // 默认 openid profile email
+	BackendRedirectURL    string   `mapstructure:"backend-redirect-url" json:"backend-redirect-url" yaml:"backend-redirect-url"`       // 后台回调页面, 页面把 code 和 state 提交到 base/oidc/callback
+	FrontendRedirectURL   string   `mapstructure:"frontend-redirect-url" json:"frontend-redirect-url" yaml:"frontend-redirect-url"`    // 前台回调页面, 页面把 code 和 state 提交到 oidc/callback
+	AuthorizationEndpoint string   `mapstructure:"authorization-endpoint" json:"authorization-endpoint" yaml:"authorization-endpoint"` // 可选, 覆盖发现文档
+	TokenEndpoint         string   `mapstructure:"token-endpoint" json:"token-endpoint" yaml:"token-endpoint"`                         // 可选, 覆盖发现文档
+	JwksURI               string   `mapstructure:"jwks-uri" json:"jwks-uri" yaml:"jwks-uri"`                                           // 可选, 覆盖发现文档
+	UsernameClaim         string   `mapstructure:"username-claim" json:"username-claim" yaml:"username-claim"`                         // 自动创建用户时作为用户名的 claim, 默认 preferred_username
+	AutoCreate            bool     `mapstructure:"auto-create" json:"auto-create" yaml:"auto-create"`                                  // 没有关联账号时自动创建用户
+	AuthorityId           string   `mapstructure:"authority-id" json:"authority-id" yaml:"authority-id"`                               // 后台登录自动创建用户的角色
+	FrontendAuthorityId   string   `mapstructure:"frontend-authority-id" json:"frontend-authority-id" yaml:"frontend-authority-id"`    // 前台登录自动创建用户的角色
+	LinkByEmail           bool     `mapstructure:"link-by-email" json:"link-by-email" yaml:"link-by-email"`                            // 按已验证的邮箱自动关联已有用户, 只应对可信的企业 SSO 开启
+}
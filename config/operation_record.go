@@ -0,0 +1,8 @@
+package config
+
+// OperationRecord 操作记录异步批量写入配置
+type OperationRecord struct {
+	BufferSize    int `mapstructure:"buffer-size" json:"buffer-size" yaml:"buffer-size"`          // 队列长度, 写满后丢弃新记录
+	BatchSize     int `mapstructure:"batch-size" json:"batch-size" yaml:"batch-size"`             // 每批写入的条数
+	FlushInterval int `mapstructure:"flush-interval" json:"flush-interval" yaml:"flush-interval"` // 未满一批时的写入间隔, 毫秒
+}
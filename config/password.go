@@ -0,0 +1,10 @@
+package config
+
+// Password 用户密码哈希配置
+type Password struct {
+	Algorithm     string `mapstructure:"algorithm" json:"algorithm" yaml:"algorithm"`                // 新密码使用的算法 argon2id 或 bcrypt
+	BcryptCost    int    `mapstructure:"bcrypt-cost" json:"bcrypt-cost" yaml:"bcrypt-cost"`          // bcrypt 计算成本
+	Argon2Memory  uint32 `mapstructure:"argon2-memory" json:"argon2-memory" yaml:"argon2-memory"`    // argon2id 内存, 单位 KiB
+	Argon2Time    uint32 `mapstructure:"argon2-time" json:"argon2-time" yaml:"argon2-time"`          // argon2id 迭代次数
+	Argon2Threads uint8  `mapstructure:"argon2-threads" json:"argon2-threads" yaml:"argon2-threads"` // argon2id 并行度
+}
@@ -0,0 +1,11 @@
+package config
+
+// PasswordReset 邮件找回密码配置
+type PasswordReset struct {
+	TokenTime   int64  `mapstructure:"token-time" json:"token-time" yaml:"token-time"`       // 重置链接有效期(分钟)
+	BackendURL  string `mapstructure:"backend-url" json:"backend-url" yaml:"backend-url"`    // 后台重置页面地址, {token} 会替换为重置令牌
+	FrontendURL string `mapstructure:"frontend-url" json:"frontend-url" yaml:"frontend-url"` // 前台重置页面地址, {token} 会替换为重置令牌
+	EmailLimit  int    `mapstructure:"email-limit" json:"email-limit" yaml:"email-limit"`    // 每个邮箱在窗口内允许的请求次数
+	IpLimit     int    `mapstructure:"ip-limit" json:"ip-limit" yaml:"ip-limit"`             // 每个ip在窗口内允许的请求次数
+	LimitWindow int64  `mapstructure:"limit-window" json:"limit-window" yaml:"limit-window"` // 限流窗口(秒)
+}
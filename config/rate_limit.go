@@ -0,0 +1,17 @@
+package config
+
+// RateLimit 接口限流配置, 开启 redis 时多实例共享计数, 否则在进程内计数
+type RateLimit struct {
+	Policies []RateLimitPolicy `mapstructure:"policies" json:"policies" yaml:"policies"` // 为空时使用 system.iplimit-count/iplimit-time 按 ip 限流
+}
+
+// RateLimitPolicy 单条限流策略, 请求匹配的全部策略都会计数
+type RateLimitPolicy struct {
+	Name   string `mapstructure:"name" json:"name" yaml:"name"`       // 策略名, 作为计数 key 的一部分
+	Path   string `mapstructure:"path" json:"path" yaml:"path"`       // 请求路径, 支持 :param 和结尾的 *, 为空匹配全部
+	Method string `mapstructure:"method" json:"method" yaml:"method"` // 请求方法, 为空匹配全部
+	By     string `mapstructure:"by" json:"by" yaml:"by"`             // 计数维度 ip | user | global, user 未登录时按 ip
+	Limit  int    `mapstructure:"limit" json:"limit" yaml:"limit"`    // 周期内允许的请求数
+	Period int    `mapstructure:"period" json:"period" yaml:"period"` // 周期, 秒
+	Burst  int    `mapstructure:"burst" json:"burst" yaml:"burst"`    // 允许的突发请求数, 为 0 时等于 limit
+}
@@ -0,0 +1,11 @@
+package config
+
+// SecurityQuestion 安全问题找回账号配置
+type SecurityQuestion struct {
+	Ask           int   `mapstructure:"ask" json:"ask" yaml:"ask"`                                  // 每次随机提问的数量, 超过用户设置的数量时全部提问
+	MaxFailures   int   `mapstructure:"max-failures" json:"max-failures" yaml:"max-failures"`       // 连续答错多少次后锁定
+	LockTime      int64 `mapstructure:"lock-time" json:"lock-time" yaml:"lock-time"`                // 锁定时间(秒), 同时也是失败次数的统计窗口
+	ChallengeTime int64 `mapstructure:"challenge-time" json:"challenge-time" yaml:"challenge-time"` // 作答时间(秒)
+	TokenTime     int64 `mapstructure:"token-time" json:"token-time" yaml:"token-time"`             // 答对后签发的重置令牌有效期(秒)
+	IpLimit       int   `mapstructure:"ip-limit" json:"ip-limit" yaml:"ip-limit"`                   // 每个ip每小时允许发起找回的次数
+}
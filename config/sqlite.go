@@ -1,8 +1,29 @@
 package config
 
+import (
+	"path/filepath"
+)
+
 type Sqlite struct {
-	Path    string `mapstructure:"path" json:"path" yaml:"path"`             // 文件路径
-	LogMode string `mapstructure:"log-mode" json:"log-mode" yaml:"log-mode"` // 是否开启Gorm全局日志
+	Path         string `mapstructure:"path" json:"path" yaml:"path"`                               // 文件路径
+	Config       string `mapstructure:"config" json:"config" yaml:"config"`                         // 高级配置
+	Dbname       string `mapstructure:"db-name" json:"db-name" yaml:"db-name"`                      // 数据库名
+	MaxIdleConns int    `mapstructure:"max-idle-conns" json:"max-idle-conns" yaml:"max-idle-conns"` // 空闲中的最大连接数
+	MaxOpenConns int    `mapstructure:"max-open-conns" json:"max-open-conns" yaml:"max-open-conns"` // 打开到数据库的最大连接数
+	LogMode      string `mapstructure:"log-mode" json:"log-mode" yaml:"log-mode"`                   // 是否开启Gorm全局日志
+	LogZap       bool   `mapstructure:"log-zap" json:"log-zap" yaml:"log-zap"`                      // 是否通过zap写入日志文件
+}
+
+// Dsn 基于配置文件获取 dsn, Dbname 为 ":memory:" 时使用内存数据库
+func (m *Sqlite) Dsn() string {
+	dsn := m.Dbname
+	if m.Dbname != ":memory:" {
+		dsn = filepath.Join(m.Path, m.Dbname+".db")
+	}
+	if m.Config != "" {
+		dsn += "?" + m.Config
+	}
+	return dsn
 }
 
 func (m *Sqlite) GetLogMode() string {
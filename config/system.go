@@ -10,4 +10,6 @@ type System struct {
 	UseRedis      bool   `mapstructure:"use-redis" json:"use-redis" yaml:"use-redis"`                // 使用redis
 	LimitCountIP  int    `mapstructure:"iplimit-count" json:"iplimit-count" yaml:"iplimit-count"`    // 访问次数限制
 	LimitTimeIP   int    `mapstructure:"iplimit-time" json:"iplimit-time" yaml:"iplimit-time"`       // 访问时间限制
+	AutoMigrate   bool   `mapstructure:"auto-migrate" json:"auto-migrate" yaml:"auto-migrate"`       // 启动时执行未执行的sql迁移
+	MigrationDir  string `mapstructure:"migration-dir" json:"migration-dir" yaml:"migration-dir"`    // sql迁移文件目录
 }
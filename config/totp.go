@@ -0,0 +1,11 @@
+package config
+
+// Totp 后台用户两步验证配置
+type Totp struct {
+	Issuer              string   `mapstructure:"issuer" json:"issuer" yaml:"issuer"`                                           // 验证器中显示的签发者
+	RequiredAuthorities []string `mapstructure:"required-authorities" json:"required-authorities" yaml:"required-authorities"` // 必须开启两步验证的角色id
+	ChallengeTime       int64    `mapstructure:"challenge-time" json:"challenge-time" yaml:"challenge-time"`                   // 第二步登录凭证有效期(秒)
+	MaxAttempts         int      `mapstructure:"max-attempts" json:"max-attempts" yaml:"max-attempts"`                         // 每个凭证允许输错验证码的次数
+	Skew                int      `mapstructure:"skew" json:"skew" yaml:"skew"`                                                 // 允许前后偏差的时间步数
+	RecoveryCodes       int      `mapstructure:"recovery-codes" json:"recovery-codes" yaml:"recovery-codes"`                   // 生成的恢复码数量
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var ossTypes = map[string]bool{"local": true, "qiniu": true, "tencent-cos": true, "aliyun-oss": true, "huawei-obs": true, "aws-s3": true}
+
+// Validate 校验合并后的配置, 返回所有发现的问题
+func (s *Server) Validate() error {
+	var errs []error
+	if s.System.Addr <= 0 || s.System.Addr > 65535 {
+		errs = append(errs, fmt.Errorf("system.addr 端口无效: %d", s.System.Addr))
+	}
+	switch s.System.DbType {
+	case "", "mysql":
+		if s.Mysql.Dbname == "" {
+			errs = append(errs, errors.New("mysql.db-name 未配置"))
+		}
+	case "pgsql":
+		if s.Pgsql.Dbname == "" {
+			errs = append(errs, errors.New("pgsql.db-name 未配置"))
+		}
+	case "sqlite":
+		if s.Sqlite.Dbname == "" {
+			errs = append(errs, errors.New("sqlite.db-name 未配置"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("system.db-type 不支持: %s", s.System.DbType))
+	}
+	if s.System.OssType != "" && !ossTypes[s.System.OssType] {
+		errs = append(errs, fmt.Errorf("system.oss-type 不支持: %s", s.System.OssType))
+	}
+	if (s.System.UseRedis || s.System.UseMultipoint) && s.Redis.Addr == "" {
+		errs = append(errs, errors.New("已开启 redis 但 redis.addr 未配置"))
+	}
+	if err := s.JWT.CheckRemoved(); err != nil {
+		errs = append(errs, err)
+	}
+	if s.JWT.AccessExpiresMinutes <= 0 {
+		errs = append(errs, errors.New("jwt.access-expires-minutes 必须大于0"))
+	} else if s.JWT.AccessExpires() >= s.JWT.RefreshExpires() {
+		errs = append(errs, fmt.Errorf("访问令牌有效期 jwt.access-expires-minutes(%s) 必须短于刷新令牌 jwt.refresh-time(%s)", s.JWT.AccessExpires(), s.JWT.RefreshExpires()))
+	}
+	if len(s.JWT.Keys) == 0 && s.System.Env != "develop" {
+		errs = append(errs, errors.New("jwt.keys 未配置, 只有 system.env 为 develop 时允许使用临时密钥"))
+	}
+	if s.JWT.BufferTime < 0 {
+		errs = append(errs, errors.New("jwt.buffer-time 不能小于0"))
+	}
+	switch s.Password.Algorithm {
+	case "", "argon2id", "bcrypt":
+	default:
+		errs = append(errs, fmt.Errorf("password.algorithm 不支持: %s", s.Password.Algorithm))
+	}
+	names := map[string]bool{}
+	for i, p := range s.Oidc.Providers {
+		switch {
+		case p.Name == "" || names[p.Name]:
+			errs = append(errs, fmt.Errorf("oidc.providers[%d].name 为空或重复", i))
+		case p.Issuer == "" || p.ClientId == "":
+			errs = append(errs, fmt.Errorf("oidc.providers[%d] issuer 和 client-id 必须配置", i))
+		}
+		names[p.Name] = true
+	}
+	names = map[string]bool{}
+	for i, p := range s.RateLimit.Policies {
+		switch {
+		case p.Name == "" || names[p.Name]:
+			errs = append(errs, fmt.Errorf("rate-limit.policies[%d].name 为空或重复", i))
+		case p.By != "ip" && p.By != "user" && p.By != "global":
+			errs = append(errs, fmt.Errorf("rate-limit.policies[%d].by 不支持: %s", i, p.By))
+		case p.Limit <= 0 || p.Period <= 0 || p.Burst < 0:
+			errs = append(errs, fmt.Errorf("rate-limit.policies[%d] limit 和 period 必须大于0", i))
+		}
+		names[p.Name] = true
+	}
+	if err := s.Encryption.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if s.Casbin.ModelPath == "" {
+		errs = append(errs, errors.New("casbin.model-path 未配置"))
+	} else if _, err := os.Stat(s.Casbin.ModelPath); err != nil {
+		errs = append(errs, fmt.Errorf("casbin.model-path 不可用: %w", err))
+	}
+	if s.System.AutoMigrate && s.System.MigrationDir != "" {
+		if _, err := os.Stat(s.System.MigrationDir); err != nil {
+			errs = append(errs, fmt.Errorf("system.migration-dir 不可用: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validate 校验加密密钥, encrypt-data 命令执行前单独校验
+func (e Encryption) Validate() error {
+	var errs []error
+	versions := map[string]bool{}
+	for i, k := range e.Keys {
+		if k.Version == "" || versions[k.Version] || strings.Contains(k.Version, ":") {
+			errs = append(errs, fmt.Errorf("encryption.keys[%d].version 为空、重复或包含 :", i))
+		}
+		versions[k.Version] = true
+		if key, err := base64.StdEncoding.DecodeString(k.Key); err != nil || (len(key) != 16 && len(key) != 24 && len(key) != 32) {
+			errs = append(errs, fmt.Errorf("encryption.keys[%d].key 必须是 base64 编码的 16/24/32 字节密钥", i))
+		}
+	}
+	if e.Current != "" {
+		if !versions[e.Current] {
+			errs = append(errs, fmt.Errorf("encryption.current 对应的密钥 %s 未配置", e.Current))
+		}
+		if e.IndexKey == "" {
+			errs = append(errs, errors.New("已开启加密但 encryption.index-key 未配置"))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validServer() Server {
+	return Server{
+		System: System{Addr: 3100, DbType: "sqlite", OssType: "local", Env: "develop"},
+		Sqlite: Sqlite{Dbname: "server"},
+		JWT:    PrivacyJWT{AccessExpiresMinutes: 15, RefreshTime: 168, BufferTime: 86400},
+		Casbin: Casbin{ModelPath: "validate.go"},
+	}
+}
+
+func TestServerValidate(t *testing.T) {
+	t.Run("合法配置", func(t *testing.T) {
+		s := validServer()
+		assert.NoError(t, s.Validate())
+	})
+
+	t.Run("汇总所有错误", func(t *testing.T) {
+		s := validServer()
+		s.System.Addr = 0
+		s.System.DbType = "oracle"
+		s.System.UseRedis = true
+		s.Casbin.ModelPath = "not-exist.conf"
+		s.Password.Algorithm = "md5"
+		s.Oidc.Providers = []OidcProvider{{Name: "sso", Issuer: "https://sso"}}
+		s.RateLimit.Policies = []RateLimitPolicy{{Name: "ip", By: "ip", Limit: 10, Period: 60}, {Name: "ip", By: "ip"}}
+		s.Encryption = Encryption{Current: "v2", Keys: []EncryptionKey{{Version: "v1", Key: "c2hvcnQ="}}}
+		err := s.Validate()
+		assert.ErrorContains(t, err, "system.addr")
+		assert.ErrorContains(t, err, "system.db-type")
+		assert.ErrorContains(t, err, "redis.addr")
+		assert.ErrorContains(t, err, "casbin.model-path")
+		assert.ErrorContains(t, err, "password.algorithm")
+		assert.ErrorContains(t, err, "oidc.providers[0]")
+		assert.ErrorContains(t, err, "rate-limit.policies[1]")
+		assert.NotContains(t, err.Error(), "rate-limit.policies[0]")
+		assert.ErrorContains(t, err, "encryption.keys[0].key")
+		assert.ErrorContains(t, err, "encryption.current")
+		assert.ErrorContains(t, err, "encryption.index-key")
+	})
+
+	t.Run("缺少数据库名", func(t *testing.T) {
+		s := validServer()
+		s.System.DbType = "mysql"
+		assert.ErrorContains(t, s.Validate(), "mysql.db-name")
+	})
+
+	t.Run("非开发环境必须配置jwt密钥", func(t *testing.T) {
+		s := validServer()
+		s.System.Env = "public"
+		assert.ErrorContains(t, s.Validate(), "jwt.keys")
+		s.JWT.Keys = []JWTKey{{Kid: "2026-10", PrivateKeyFile: "jwt.pem"}}
+		assert.NoError(t, s.Validate())
+	})
+
+	t.Run("访问令牌有效期不短于刷新令牌", func(t *testing.T) {
+		s := validServer()
+		s.JWT.AccessExpiresMinutes = 24 * 60
+		s.JWT.RefreshTime = 24
+		assert.ErrorContains(t, s.Validate(), "jwt.access-expires-minutes")
+		s.JWT.AccessExpiresMinutes = 24*60 - 1
+		assert.NoError(t, s.Validate())
+	})
+
+	t.Run("仍配置了已停用的 expires-time", func(t *testing.T) {
+		s := validServer()
+		s.JWT.ExpiresTime = 168
+		assert.ErrorIs(t, s.Validate(), ErrExpiresTimeRemoved)
+	})
+}
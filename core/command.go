@@ -0,0 +1,153 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"server/init_load"
+	global "server/model"
+	sysModel "server/model/system"
+	"server/model/system/request"
+	"server/service/system"
+
+	"go.uber.org/zap"
+)
+
+// bootstrap 命令行子命令使用, 只加载配置和日志, withDB 时连接数据库
+func bootstrap(withDB bool) error {
+	vip, err := ViperInit()
+	if err != nil {
+		return err
+	}
+	if _, err = ZapInit(vip); err != nil {
+		return err
+	}
+	zap.ReplaceGlobals(global.LOG)
+	if withDB {
+		if _, err = init_load.ProvideGorm(global.LOG); err != nil {
+			return fmt.Errorf("连接数据库失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// CheckConfig 加载并校验合并后的配置
+func CheckConfig(out io.Writer) error {
+	if _, err := viperInit(); err != nil {
+		return err
+	}
+	if err := global.CONFIG.Validate(); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(out, "配置目录 %s 校验通过, db-type: %s, addr: %d\n", ConfigDir(), global.CONFIG.System.DbType, global.CONFIG.System.Addr)
+	return nil
+}
+
+// RunInitDB 非交互式初始化数据库, 与 /backend/init/initdb 接口逻辑一致
+func RunInitDB(conf request.InitDB) error {
+	if err := bootstrap(false); err != nil {
+		return err
+	}
+	defer closeDatabase()
+
+	if db, err := init_load.Gorm(); err == nil {
+		initialized := db.Migrator().HasTable(&sysModel.SysUser{})
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+		if initialized {
+			return errors.New("已存在数据库配置且已初始化")
+		}
+	}
+	return system.InitDBServiceApp.InitDB(conf)
+}
+
+// RunMigrate 执行 sql 迁移, action: up | down | status | baseline
+// baseline 用于导入 sql/server-fiber.sql 建好的库, 把 version 及之前的迁移记为已执行
+func RunMigrate(action string, steps int, version int64, out io.Writer) error {
+	if err := bootstrap(true); err != nil {
+		return err
+	}
+	defer closeDatabase()
+
+	var (
+		versions []int64
+		err      error
+	)
+	switch action {
+	case "up":
+		versions, err = system.MigrationServiceApp.Up(steps)
+	case "down":
+		versions, err = system.MigrationServiceApp.Down(steps)
+	case "baseline":
+		versions, err = system.MigrationServiceApp.Baseline(version)
+	case "status", "":
+		list, err := system.MigrationServiceApp.Status()
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED\tAPPLIED AT")
+		for _, s := range list {
+			appliedAt := "-"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			_, _ = fmt.Fprintf(w, "%d\t%s\t%t\t%s\n", s.Version, s.Name, s.Applied, appliedAt)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("未知的迁移操作: %s", action)
+	}
+	for _, v := range versions {
+		_, _ = fmt.Fprintf(out, "%s %d\n", action, v)
+	}
+	return err
+}
+
+// RunEncryptData 按当前加密配置重写已有的敏感字段并重建盲索引
+func RunEncryptData(batch int, out io.Writer) error {
+	if err := bootstrap(true); err != nil {
+		return err
+	}
+	defer closeDatabase()
+
+	if err := global.CONFIG.Encryption.Validate(); err != nil {
+		return err
+	}
+	results, err := system.EncryptionServiceApp.EncryptData(batch)
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "TABLE\tSCANNED\tUPDATED")
+	for _, r := range results {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%d\n", r.Table, r.Scanned, r.Updated)
+	}
+	_ = w.Flush()
+	return err
+}
+
+// CreateAdmin 创建指定角色的后台用户
+func CreateAdmin(username, password, nickName, authorityId string) error {
+	if username == "" || password == "" {
+		return errors.New("用户名和密码不能为空")
+	}
+	if err := bootstrap(true); err != nil {
+		return err
+	}
+	defer closeDatabase()
+
+	var authority sysModel.SysAuthority
+	if err := global.DB.Where("authority_id = ?", authorityId).First(&authority).Error; err != nil {
+		return fmt.Errorf("角色 %s 不存在: %w", authorityId, err)
+	}
+	user := &sysModel.SysUser{
+		Username:    username,
+		NickName:    nickName,
+		Password:    password,
+		AuthorityId: authorityId,
+		Authorities: []sysModel.SysAuthority{authority},
+	}
+	_, err := system.UserServiceApp.Register(user)
+	return err
+}
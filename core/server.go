@@ -28,8 +28,41 @@ func RunServer() {
 	zap.ReplaceGlobals(global.LOG)
 
 	if global.DB != nil {
+		if global.CONFIG.System.AutoMigrate {
+			if _, err = system.MigrationServiceApp.Up(0); err != nil {
+				log.Fatalf("run sql migration failed: %v", err)
+			}
+		}
 		system.LoadAll()
+		// 策略加载失败时所有接口都会被拒绝, 直接终止启动
+		if err = system.CasbinServiceApp.Init(); err != nil {
+			log.Fatalf("load casbin policy failed: %v", err)
+		}
+		if err = system.ApiServiceApp.MigrateRenamedApis(); err != nil {
+			global.LOG.Error("迁移改名的api失败", zap.Error(err))
+		}
+		if err = system.OwnershipServiceApp.EnsureOverrideApis(); err != nil {
+			global.LOG.Error("补充越权权限api失败", zap.Error(err))
+		}
+		if err = system.MaskingServiceApp.EnsureRevealApi(); err != nil {
+			global.LOG.Error("补充查看完整数据权限api失败", zap.Error(err))
+		}
+		// 升级前写入的记录没有盲索引, 按手机号/邮箱查询前先补全
+		if results, err := system.EncryptionServiceApp.BackfillIndexes(0); err != nil {
+			global.LOG.Error("补全盲索引失败", zap.Error(err))
+		} else {
+			for _, r := range results {
+				if r.Updated > 0 {
+					global.LOG.Info("补全盲索引", zap.String("table", r.Table), zap.Int("updated", r.Updated))
+				}
+			}
+		}
+		system.OperationRecordWriterApp.Start(global.CONFIG.OperationRecord)
 	}
+	// 接收其他实例的 jwt 拉黑通知
+	subscribeCtx, stopSubscribe := context.WithCancel(context.Background())
+	defer stopSubscribe()
+	go system.JwtServiceApp.SubscribeBlacklist(subscribeCtx)
 
 	go listen(app)
 
@@ -40,8 +73,17 @@ func RunServer() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := app.ShutdownWithContext(ctx); err != nil {
-		global.LOG.Error("shutdown Fiber app failed", zap.Error(err))
+	shutdownErr := app.ShutdownWithContext(ctx)
+	if shutdownErr != nil {
+		global.LOG.Error("shutdown Fiber app failed", zap.Error(shutdownErr))
+	}
+	// 请求处理完后写入队列中剩余的操作记录, 需要在关闭数据库之前
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelFlush()
+	if err := system.OperationRecordWriterApp.Close(flushCtx); err != nil {
+		global.LOG.Error("写入剩余操作记录超时", zap.Error(err))
+	}
+	if shutdownErr != nil {
 		os.Exit(1)
 	}
 }
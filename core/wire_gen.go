@@ -62,7 +62,14 @@ func InitializeApp() (*init_load.RouterApp, func(), error) {
 	problemRouter := router.ProvideProblemRouter()
 	sysRouter := router.ProvideSysRouter()
 	systemUserRouter := router.ProvideSystemUserRouter()
-	systemRouter := router.ProvideSystemGroup(apiRouter, githubRouter, authorityBtnRouter, authorityRouter, autoCodeHistoryRouter, autoCodeRouter, baseRouter, casbinRouter, dictionaryDetailRouter, dictionaryRouter, initRouter, jwtRouter, menuRouter, operationRecordRouter, problemRouter, sysRouter, systemUserRouter)
+	migrationRouter := router.ProvideMigrationRouter()
+	totpRouter := router.ProvideTotpRouter()
+	loginGuardRouter := router.ProvideLoginGuardRouter()
+	oidcRouter := router.ProvideOidcRouter()
+	apiKeyRouter := router.ProvideApiKeyRouter()
+	sessionRouter := router.ProvideSessionRouter()
+	auditLogRouter := router.ProvideAuditLogRouter()
+	systemRouter := router.ProvideSystemGroup(apiRouter, githubRouter, authorityBtnRouter, authorityRouter, autoCodeHistoryRouter, autoCodeRouter, baseRouter, casbinRouter, dictionaryDetailRouter, dictionaryRouter, initRouter, jwtRouter, menuRouter, operationRecordRouter, problemRouter, sysRouter, systemUserRouter, migrationRouter, totpRouter, loginGuardRouter, oidcRouter, apiKeyRouter, sessionRouter, auditLogRouter)
 	customerRouter := router.ProvideCustomerRouter()
 	excelRouter := router.ProvideExcelRouter()
 	fileUploadAndDownloadRouter := router.ProvideFileUploadAndDownloadRouter()
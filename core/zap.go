@@ -13,13 +13,9 @@ import (
 // Zap 获取 zap.Logger
 // Author wuhao
 func zapInit() (logger *zap.Logger, err error) {
-	ok, err := utils.PathExists(global.CONFIG.Zap.Director)
-	if err != nil {
-		return
-	}
-	if !ok { // 判断是否有Director文件夹
+	if ok, _ := utils.PathExists(global.CONFIG.Zap.Director); !ok { // 判断是否有Director文件夹
 		// log.Printf("create %v directory\n", global.CONFIG.Zap.Director)
-		err = os.Mkdir(global.CONFIG.Zap.Director, os.ModePerm)
+		err = os.MkdirAll(global.CONFIG.Zap.Director, os.ModePerm)
 		if err != nil {
 			return
 		}
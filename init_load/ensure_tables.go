@@ -6,6 +6,7 @@ import (
 	global "server/model"
 	"server/model/app"
 	"server/model/example"
+	"server/model/mobile"
 	sysModel "server/model/system"
 	"server/service/system"
 
@@ -36,39 +37,21 @@ func (e *ensureTables) DataInserted(ctx context.Context) bool {
 }
 
 // 迁移数据
+// mysql 按 migration 下的 sql 建表, pgsql/sqlite 没有初始化脚本, 按模型建表后把已有的迁移记为已执行
 func (e *ensureTables) MigrateTable(ctx context.Context) (context.Context, error) {
 	db, ok := ctx.Value("db").(*gorm.DB)
 	if !ok {
 		return ctx, system.ErrMissingDBContext
 	}
-	tables := []any{
-		// sysModel.SysApi{},
-		// sysModel.SysUser{},
-		// sysModel.SysBaseMenu{},
-		// sysModel.SysAuthority{},
-		// sysModel.JwtBlacklist{},
-		// sysModel.SysDictionary{},
-		// sysModel.SysAutoCodeHistory{},
-		// sysModel.SysOperationRecord{},
-		// sysModel.SysDictionaryDetail{},
-		// sysModel.SysBaseMenuParameter{},
-		// sysModel.SysBaseMenuBtn{},
-		// sysModel.SysAuthorityBtn{},
-		// sysModel.SysAutoCode{},
-
-		// adapter.CasbinRule{},
-
-		// example.ExaFile{},
-		// example.ExaCustomer{},
-		// example.ExaFileChunk{},
-		// example.ExaFileUploadAndDownload{},
+	if db.Dialector.Name() == "mysql" {
+		_, err := system.MigrationServiceApp.Up(0)
+		return ctx, err
 	}
-	for _, t := range tables {
-		_ = db.AutoMigrate(&t)
-		// 视图 authority_menu 会被当成表来创建，引发冲突错误（更新版本的gorm似乎不会）
-		// 由于 AutoMigrate() 基本无需考虑错误，因此显式忽略
+	if err := migrateModels(db); err != nil {
+		return ctx, err
 	}
-	return ctx, nil
+	_, err := system.MigrationServiceApp.Baseline(0)
+	return ctx, err
 }
 
 // 判断是否已经创建了表
@@ -77,40 +60,103 @@ func (e *ensureTables) TableCreated(ctx context.Context) bool {
 	if !ok {
 		return false
 	}
-	tables := []any{
-		sysModel.SysApi{},
-		sysModel.SysUser{},
-		sysModel.SysBaseMenu{},
-		sysModel.SysAuthority{},
-		sysModel.JwtBlacklist{},
-		sysModel.SysDictionary{},
-		sysModel.SysAutoCodeHistory{},
-		sysModel.SysOperationRecord{},
-		sysModel.SysDictionaryDetail{},
-		sysModel.SysBaseMenuParameter{},
-		sysModel.SysBaseMenuBtn{},
-		sysModel.SysAuthorityBtn{},
-		sysModel.SysAutoCode{},
-
-		adapter.CasbinRule{},
-
-		example.ExaFile{},
-		example.ExaCustomer{},
-		example.ExaFileChunk{},
-		example.ExaFileUploadAndDownload{},
-
-		app.Article{},
-		app.ArticleTag{},
-		app.Tag{},
-		app.BaseMessage{},
-		app.Comment{},
-		app.Ip{},
-		global.Praise{},
-		app.User{},
-	}
 	yes := true
-	for _, t := range tables {
+	for _, t := range registeredTables() {
 		yes = yes && db.Migrator().HasTable(t)
 	}
 	return yes
 }
+
+// registeredTables 服务运行需要的全部表
+func registeredTables() []any {
+	return []any{
+		&sysModel.SysApi{},
+		&sysModel.SysUser{},
+		&sysModel.SysBaseMenu{},
+		&sysModel.SysAuthority{},
+		&sysModel.JwtBlacklist{},
+		&sysModel.SysDictionary{},
+		&sysModel.SysAutoCodeHistory{},
+		&sysModel.SysOperationRecord{},
+		&sysModel.SysDictionaryDetail{},
+		&sysModel.SysBaseMenuParameter{},
+		&sysModel.SysBaseMenuBtn{},
+		&sysModel.SysAuthorityBtn{},
+		&sysModel.SysAutoCode{},
+
+		&adapter.CasbinRule{},
+
+		&example.ExaFile{},
+		&example.ExaCustomer{},
+		&example.ExaFileChunk{},
+		&example.ExaFileUploadAndDownload{},
+
+		&app.Article{},
+		&app.ArticleTag{},
+		&app.Tag{},
+		&app.BaseMessage{},
+		&app.Comment{},
+		&app.Ip{},
+		&global.Praise{},
+		&app.User{},
+	}
+}
+
+// serviceTables 后续功能新增的表和字段, mysql/pgsql 由 migration/1792281600_add_service_tables 迁移
+func serviceTables() []any {
+	return []any{
+		&sysModel.SysRefreshToken{},
+		&sysModel.SysUserTotp{},
+		&sysModel.SysUserRecoveryCode{},
+		&sysModel.SysTotpChallenge{},
+		&sysModel.SysPasswordReset{},
+		&sysModel.SysRecoveryChallenge{},
+		&sysModel.SysLoginLock{},
+		&sysModel.SysLoginHistory{},
+		&sysModel.SysUserIdentity{},
+		&sysModel.SysOidcState{},
+		&sysModel.SysApiKey{},
+		&sysModel.SysSession{},
+		&sysModel.SysAuditLog{},
+		// 按 jti 拉黑新增 jti/expires_at 字段
+		&sysModel.JwtBlacklist{},
+		// 按钮绑定接口新增 sys_menu_btn_apis 关联表
+		&sysModel.SysBaseMenuBtn{},
+		// 资源权限过滤新增 user_id 字段
+		&example.ExaFileUploadAndDownload{},
+		// 敏感字段加密新增盲索引字段
+		&sysModel.SysUser{},
+		&example.ExaCustomer{},
+		&mobile.MobileUser{},
+	}
+}
+
+// migrateModels 按模型建表并创建 authority_menu 视图
+func migrateModels(db *gorm.DB) error {
+	tables := append(registeredTables(), serviceTables()...)
+	tables = append(tables,
+		&sysModel.SysGithub{},
+		&sysModel.SysStatistics{},
+		&sysModel.SysUserProblem{},
+		&app.Like{},
+		&app.FileUploadAndDownload{},
+		&mobile.MobileUser{},
+		&mobile.Register{},
+	)
+	if err := db.AutoMigrate(tables...); err != nil {
+		return err
+	}
+	create := "CREATE VIEW IF NOT EXISTS"
+	if db.Dialector.Name() == "postgres" {
+		create = "CREATE OR REPLACE VIEW"
+	}
+	return db.Exec(create + ` authority_menu AS
+SELECT sys_base_menus.id AS id, sys_base_menus.path AS path, sys_base_menus.icon AS icon, sys_base_menus.name AS name,
+       sys_base_menus.sort AS sort, sys_base_menus.title AS title, sys_base_menus.hidden AS hidden,
+       sys_base_menus.component AS component, sys_base_menus.parent_id AS parent_id,
+       sys_base_menus.created_at AS created_at, sys_base_menus.updated_at AS updated_at, sys_base_menus.deleted_at AS deleted_at,
+       sys_base_menus.keep_alive AS keep_alive, sys_base_menus.menu_level AS menu_level, sys_base_menus.default_menu AS default_menu,
+       sys_base_menus.close_tab AS close_tab,
+       sys_authority_menus.sys_base_menu_id AS menu_id, sys_authority_menus.sys_authority_authority_id AS authority_id
+FROM sys_authority_menus JOIN sys_base_menus ON sys_authority_menus.sys_base_menu_id = sys_base_menus.id`).Error
+}
@@ -16,6 +16,8 @@ func Gorm() (*gorm.DB, error) {
 		return GormMysql()
 	case "pgsql":
 		return GormPgSql()
+	case "sqlite":
+		return GormSqlite()
 	default:
 		return GormMysql()
 	}
@@ -27,6 +27,8 @@ func (w *Writer) Printf(message string, data ...any) {
 		logZap = global.CONFIG.Mysql.LogZap
 	case "pgsql":
 		logZap = global.CONFIG.Pgsql.LogZap
+	case "sqlite":
+		logZap = global.CONFIG.Sqlite.LogZap
 	}
 	if logZap {
 		global.LOG.Info(fmt.Sprintf(message+"\n", data...))
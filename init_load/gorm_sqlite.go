@@ -0,0 +1,44 @@
+package init_load
+
+import (
+	"errors"
+	"os"
+	"server/config"
+	"server/init_load/gorm_log"
+	global "server/model"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+// GormSqlite 初始化 Sqlite 数据库
+func GormSqlite() (*gorm.DB, error) {
+	return GormSqliteByConfig(global.CONFIG.Sqlite)
+}
+
+// GormSqliteByConfig 初始化 Sqlite 数据库 通过参数
+func GormSqliteByConfig(s config.Sqlite) (*gorm.DB, error) {
+	if s.Dbname == "" {
+		return nil, errors.New("没设置数据库名")
+	}
+	if s.Path != "" {
+		if err := os.MkdirAll(s.Path, os.ModePerm); err != nil {
+			return nil, err
+		}
+	}
+	db, err := gorm.Open(sqlite.Open(s.Dsn()), gorm_log.Gorm.Config())
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	// sqlite 同一时间只允许一个写连接, 未配置时限制为单连接避免 database is locked
+	if s.MaxOpenConns <= 0 {
+		s.MaxOpenConns = 1
+	}
+	sqlDB.SetMaxIdleConns(s.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(s.MaxOpenConns)
+	return db, nil
+}
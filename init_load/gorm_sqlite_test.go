@@ -0,0 +1,132 @@
+package init_load
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"server/config"
+	global "server/model"
+	sysModel "server/model/system"
+	"server/service/system"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// openSqlite 打开临时 sqlite 库并设置为 global.DB, 迁移目录指向 dir
+func openSqlite(t *testing.T, dir string) *gorm.DB {
+	global.CONFIG.System.DbType = "sqlite"
+	global.CONFIG.Sqlite.LogMode = "silent"
+	global.CONFIG.System.MigrationDir = dir
+	global.LOG = zap.NewNop()
+	db, err := GormSqliteByConfig(config.Sqlite{Path: t.TempDir(), Dbname: "test"})
+	require.NoError(t, err)
+	global.DB = db
+	t.Cleanup(func() {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+		global.DB = nil
+		global.CONFIG.System.DbType, global.CONFIG.Sqlite.LogMode, global.CONFIG.System.MigrationDir = "", "", ""
+	})
+	return db
+}
+
+func TestGormSqliteByConfig(t *testing.T) {
+	t.Run("未配置数据库名", func(t *testing.T) {
+		_, err := GormSqliteByConfig(config.Sqlite{Path: t.TempDir()})
+		assert.Error(t, err)
+	})
+
+	t.Run("建表后 ensure_tables 判断已创建", func(t *testing.T) {
+		db := openSqlite(t, "../migration")
+
+		ctx := context.WithValue(context.Background(), "db", db)
+		e := &ensureTables{}
+		assert.False(t, e.TableCreated(ctx))
+
+		_, err := e.MigrateTable(ctx)
+		require.NoError(t, err)
+		assert.True(t, e.TableCreated(ctx))
+		var views int64
+		db.Raw("SELECT count(*) FROM sqlite_master WHERE type = 'view' AND name = ?", sysModel.SysMenu{}.TableName()).Scan(&views)
+		assert.Equal(t, int64(1), views)
+
+		// 模型已经建好了全部表, 已有的迁移只记为已执行
+		list, err := system.MigrationServiceApp.Status()
+		require.NoError(t, err)
+		for _, s := range list {
+			assert.True(t, s.Applied, s.Name)
+		}
+	})
+}
+
+// 在 sql 脚本之后新增的表和字段必须登记, 并写入 migration 下的迁移文件
+func TestServiceTables(t *testing.T) {
+	tables := map[string]bool{}
+	for _, m := range serviceTables() {
+		tables[reflect.TypeOf(m).Elem().Name()] = true
+	}
+	for _, name := range []string{"SysBaseMenuBtn", "JwtBlacklist"} {
+		assert.True(t, tables[name], name)
+	}
+}
+
+// sqlite 迁移文件建出的新表要包含模型的全部字段, 防止模型和迁移文件不一致
+func TestServiceTablesMigration(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"1792281600_add_service_tables.sqlite.up.sql", "1792281600_add_service_tables.sqlite.down.sql"} {
+		content, err := os.ReadFile(filepath.Join("..", "migration", name))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), content, 0o644))
+	}
+	db := openSqlite(t, dir)
+
+	// 升级前已有的表, 只关心迁移新增的字段
+	added := map[string][]string{
+		"jwt_blacklists":                {"jti", "expires_at"},
+		"exa_file_upload_and_downloads": {"user_id"},
+		"sys_users":                     {"phone_bidx", "email_bidx"},
+		"exa_customers":                 {"customer_phone_bidx"},
+		"mobile_users":                  {"phone_bidx", "realname_bidx"},
+		"sys_base_menu_btns":            nil,
+	}
+	for table := range added {
+		require.NoError(t, db.Exec("CREATE TABLE "+table+" (id integer PRIMARY KEY)").Error)
+	}
+	_, err := system.MigrationServiceApp.Up(0)
+	require.NoError(t, err)
+
+	for _, m := range serviceTables() {
+		stmt := &gorm.Statement{DB: db}
+		require.NoError(t, stmt.Parse(m))
+		columns, ok := added[stmt.Schema.Table]
+		if len(columns) == 0 {
+			// 新表和 sys_base_menu_btns 的关联表都由迁移创建
+			for _, rel := range stmt.Schema.Relationships.Many2Many {
+				assert.True(t, db.Migrator().HasTable(rel.JoinTable.Table), rel.JoinTable.Table)
+			}
+		}
+		if ok {
+			for _, column := range columns {
+				assert.True(t, db.Migrator().HasColumn(stmt.Schema.Table, column), stmt.Schema.Table+"."+column)
+			}
+			continue
+		}
+		assert.True(t, db.Migrator().HasTable(stmt.Schema.Table), stmt.Schema.Table)
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName != "" {
+				assert.True(t, db.Migrator().HasColumn(stmt.Schema.Table, field.DBName), stmt.Schema.Table+"."+field.DBName)
+			}
+		}
+	}
+
+	_, err = system.MigrationServiceApp.Down(1)
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasTable("sys_sessions"))
+	assert.False(t, db.Migrator().HasColumn("sys_users", "phone_bidx"))
+}
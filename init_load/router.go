@@ -57,11 +57,13 @@ func Routers(
 	routers := app.Use(middleware.AppMiddlewares()...)
 	routers.Get("/backend/form-generator/*", static.New("resource/page"))
 
+	systemRouter.InitJwksRouter(routers)
+
 	backendRouterNotLogin := routers.Group("/backend")
 	systemRouter.InitBaseRouter(backendRouterNotLogin)
 	systemRouter.InitInitRouter(backendRouterNotLogin)
 
-	backendRouter := backendRouterNotLogin.Use(middleware.JWTAuth, middleware.CasbinHandler)
+	backendRouter := backendRouterNotLogin.Use(middleware.JWTAuth, middleware.CasbinHandler, middleware.BtnAuthHandler, middleware.MaskingHandler)
 	{
 		systemRouter.InitApiRouter(backendRouter)
 		systemRouter.InitJwtRouter(backendRouter)
@@ -78,6 +80,13 @@ func Routers(
 		systemRouter.InitAuthorityBtnRouterRouter(backendRouter)
 		systemRouter.InitProblemRouter(backendRouter)
 		systemRouter.InitGithubRouter(backendRouter)
+		systemRouter.InitMigrationRouter(backendRouter)
+		systemRouter.InitTotpRouter(backendRouter)
+		systemRouter.InitLoginGuardRouter(backendRouter)
+		systemRouter.InitAuditLogRouter(backendRouter)
+		systemRouter.InitOidcRouter(backendRouter)
+		systemRouter.InitApiKeyRouter(backendRouter)
+		systemRouter.InitSessionRouter(backendRouter)
 
 		exampleRouter.InitExcelRouter(backendRouter)
 		exampleRouter.InitCustomerRouter(backendRouter)
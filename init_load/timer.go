@@ -3,6 +3,7 @@ package init_load
 import (
 	"server/config"
 	global "server/model"
+	"server/service/system"
 	"server/utils"
 
 	"go.uber.org/zap"
@@ -23,5 +24,14 @@ func Timer() {
 				}
 			}(global.CONFIG.Timer.Detail[i])
 		}
+		// 黑名单按令牌过期时间清理
+		_, err := global.Timer.AddTaskByFunc("ClearJwtBlacklist", global.CONFIG.Timer.Spec, func() {
+			if _, err := system.JwtServiceApp.ClearExpiredBlacklist(); err != nil {
+				global.LOG.Error("清理jwt黑名单错误：", zap.Error(err))
+			}
+		})
+		if err != nil {
+			global.LOG.Error("添加清理jwt黑名单任务失败：", zap.Error(err))
+		}
 	}
 }
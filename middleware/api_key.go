@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"errors"
+	"strings"
+
+	"server/model/common/response"
+	"server/model/system"
+	systemReq "server/model/system/request"
+	systemService "server/service/system"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+var apiKeyService = systemService.ApiKeyServiceApp
+
+// apiKeyFromRequest 从 X-Api-Key 或 Authorization: Bearer sk_xxx 中取出 API Key
+func apiKeyFromRequest(c fiber.Ctx) (string, bool) {
+	if key := strings.TrimSpace(c.Get("X-Api-Key")); key != "" {
+		return key, true
+	}
+	token := strings.TrimPrefix(strings.TrimSpace(c.Get("Authorization")), "Bearer ")
+	return token, strings.HasPrefix(token, system.ApiKeyPrefix)
+}
+
+// apiKeyAuth 使用 API Key 代替 JWT 登录, 以所属用户的身份继续后续的 casbin 鉴权
+func apiKeyAuth(c fiber.Ctx, key string) error {
+	if !strings.HasPrefix(c.Path(), "/backend/") {
+		return response.FailWithMessage401("API Key 只能访问后台接口", 3, nil, c)
+	}
+	record, user, err := apiKeyService.Authenticate(key, c.IP())
+	if err != nil {
+		if errors.Is(err, systemService.ErrApiKeyInvalid) || errors.Is(err, systemService.ErrApiKeyExpired) {
+			return response.FailWithMessage401(err.Error(), 3, err, c)
+		}
+		return response.FailWithMessage401("API Key 校验失败", 3, err, c)
+	}
+	c.Locals("claims", &systemReq.CustomClaims{BaseClaims: systemReq.BaseClaims{
+		UUID:        user.UUID,
+		ID:          user.ID,
+		Username:    user.Username,
+		NickName:    user.NickName,
+		AuthorityId: user.AuthorityId,
+	}})
+	c.Locals("apiKey", record)
+	return c.Next()
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"server/model/common/response"
+	service "server/service/system"
+	"server/utils"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+var authorityBtnService = service.AuthorityBtnServiceApp
+
+// BtnAuthHandler 接口绑定了按钮时, 角色还必须拥有对应的按钮, 需放在 CasbinHandler 之后
+func BtnAuthHandler(c fiber.Ctx) error {
+	if isPublicPath(c.Path()) {
+		return c.Next()
+	}
+	claims, err := utils.GetClaims(c)
+	if err != nil {
+		return response.FailWithMessage401("token 错误", 3, err, c)
+	}
+	ok, err := authorityBtnService.CheckBtnAuth(claims.AuthorityId, c.Path(), c.Method())
+	if err != nil {
+		// 按钮绑定关系读取失败时无法判断是否需要按钮, 拒绝访问
+		return response.FailWithMessage403("按钮权限校验失败", 3, err, c)
+	}
+	if !ok {
+		return response.FailWithMessage403("没有该按钮的权限", 3, nil, c)
+	}
+	return c.Next()
+}
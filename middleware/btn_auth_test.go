@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	global "server/model"
+	systemReq "server/model/system/request"
+	service "server/service/system"
+
+	"github.com/glebarez/sqlite"
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// 读取按钮绑定关系失败时拒绝访问, 不能跳过按钮校验
+func TestBtnAuthHandler_StoreError(t *testing.T) {
+	// 空库里没有 sys_menu_btn_apis 等表, 查询必然失败
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	global.DB, global.LOG = db, zap.NewNop()
+	service.ClearBtnAuthCache()
+	t.Cleanup(func() {
+		global.DB = nil
+		service.ClearBtnAuthCache()
+	})
+
+	app := fiber.New()
+	app.Use(func(c fiber.Ctx) error {
+		c.Locals("claims", &systemReq.CustomClaims{BaseClaims: systemReq.BaseClaims{ID: 1, AuthorityId: "888"}})
+		return c.Next()
+	})
+	app.Get("/backend/user/getUserList", BtnAuthHandler, func(c fiber.Ctx) error { return c.SendString("ok") })
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/backend/user/getUserList", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+}
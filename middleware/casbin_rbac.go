@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"server/model/common/response"
+	"server/model/system"
 	service "server/service/system"
 	"server/utils"
 
@@ -26,6 +27,9 @@ func CasbinHandler(c fiber.Ctx) error {
 	// 获取用户的角色
 	sub := waitUse.AuthorityId
 	e := casbinService.Casbin()
+	if e == nil {
+		return response.FailWithMessage403("权限策略加载失败", 3, nil, c)
+	}
 	// 判断策略中是否存在
 	success, err := e.Enforce(sub, obj, act)
 	// log.Println("error is ", err, success, obj, act, sub)
@@ -34,6 +38,10 @@ func CasbinHandler(c fiber.Ctx) error {
 		return response.FailWithMessage403("验证失败", 3, err, c)
 	}
 	if success {
+		// API Key 限定了接口范围时, 在角色权限之外再做一次限制
+		if key, ok := c.Locals("apiKey").(*system.SysApiKey); ok && !apiKeyService.Allowed(key, obj, act) {
+			return response.FailWithMessage403("API Key 无权访问该接口", 3, nil, c)
+		}
 		return c.Next()
 	} else {
 		// 上传文件 由于是ajxs 必须返回403 错误 才能展示错误信息
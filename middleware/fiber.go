@@ -19,8 +19,8 @@ func AppMiddlewares() []any {
 		RequestID(),
 		AccessLogger(),
 		ResponseCompressor(),
-		DefaultLimit,
 		CorsByRules,
+		RateLimit,
 	}
 }
 
@@ -1,7 +1,12 @@
 package middleware
 
-import "github.com/gofiber/fiber/v3"
+import (
+	"server/model/system"
 
-func JWTAuthMiddleware(c fiber.Ctx) error {
-	return JWTAuth(c)
+	"github.com/gofiber/fiber/v3"
+)
+
+// JWTAuthFrontend 前台接口鉴权, 只接受前台登录签发的令牌
+func JWTAuthFrontend(c fiber.Ctx) error {
+	return frontendJWTMiddleware.get(system.RefreshChannelFrontend)(c)
 }
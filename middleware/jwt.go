@@ -1,78 +1,102 @@
 package middleware
 
 import (
+	"slices"
 	"strings"
 	"sync"
 
 	global "server/model"
 	"server/model/common/response"
+	"server/model/system"
 	systemReq "server/model/system/request"
 	systemService "server/service/system"
+	"server/utils"
 
 	jwtware "github.com/gofiber/contrib/v3/jwt"
 	"github.com/gofiber/fiber/v3"
 )
 
 var jwtService = new(systemService.JwtService)
+var sessionService = systemService.SessionServiceApp
 
 // JWTAuth 使用 Fiber 内置 JWT 中间件（contrib/v3/jwt），RS256 + 自定义 Claims + 黑名单
+// 后台接口只接受后台签发的令牌, 也接受 API Key, 见 apiKeyAuth
 func JWTAuth(c fiber.Ctx) error {
+	if key, ok := apiKeyFromRequest(c); ok {
+		return apiKeyAuth(c, key)
+	}
 	// fmt.Println(global.RunCONFIG.JWT.PublicKey, "global.RunCONFIG.JWT.PublicKey")
-	return getJWTMiddleware()(c)
+	return backendJWTMiddleware.get(system.RefreshChannelBackend)(c)
+}
+
+// lazyJWTMiddleware 每个签发渠道一个中间件, 第一次请求时创建
+type lazyJWTMiddleware struct {
+	once    sync.Once
+	handler fiber.Handler
 }
 
 var (
-	jwtMiddlewareOnce sync.Once
-	jwtMiddleware     fiber.Handler
+	backendJWTMiddleware  lazyJWTMiddleware
+	frontendJWTMiddleware lazyJWTMiddleware
 )
 
-func getJWTMiddleware() fiber.Handler {
-	jwtMiddlewareOnce.Do(func() {
+func (m *lazyJWTMiddleware) get(audience string) fiber.Handler {
+	m.once.Do(func() {
 		// 关键：不要在包初始化阶段捕获 Key（那时 RunCONFIG 还没被 viperInit 赋值）
-		if global.RunCONFIG.JWT.PublicKey == nil {
-			jwtMiddleware = func(c fiber.Ctx) error {
-				return response.FailWithMessage403("JWT 公钥未初始化", 3, nil, c)
-			}
-			return
+		m.handler = newJWTMiddleware(audience)
+	})
+	return m.handler
+}
+
+// newJWTMiddleware 令牌的 aud 必须包含 audience, 各端签发的令牌不能互相使用
+func newJWTMiddleware(audience string) fiber.Handler {
+	if global.RunCONFIG.JWT.PublicKey == nil {
+		return func(c fiber.Ctx) error {
+			return response.FailWithMessage403("JWT 公钥未初始化", 3, nil, c)
 		}
+	}
 
-		jwtMiddleware = jwtware.New(jwtware.Config{
-			// 跳过静态文件路径，避免 401
-			Next: func(c fiber.Ctx) bool {
-				return isPublicPath(c.Path())
-			},
-			SigningKey: jwtware.SigningKey{
-				JWTAlg: jwtware.RS256,
-				Key:    global.RunCONFIG.JWT.PublicKey,
-			},
-			Claims: &systemReq.CustomClaims{},
-			ErrorHandler: func(c fiber.Ctx, err error) error {
-				msg := "未登录或非法访问"
-				if err != nil && strings.Contains(strings.ToLower(err.Error()), "expired") {
-					msg = "授权已过期"
-				} else if err != nil && err.Error() != "" {
-					msg = err.Error()
-				}
-				return response.FailWithDetailed401(fiber.Map{"reload": true}, msg, err, c)
-			},
-			SuccessHandler: func(c fiber.Ctx) error {
-				rawToken := c.Get("Authorization", "")
-				tokenStr := strings.TrimPrefix(strings.TrimSpace(rawToken), "Bearer ")
-				if tokenStr == "" {
-					return response.FailWithDetailed401(fiber.Map{"reload": true}, "未登录或非法访问", nil, c)
-				}
-				if jwtService.IsBlacklist(tokenStr) {
-					return response.FailWithDetailed401(fiber.Map{"reload": true}, "您的帐户异地登陆或令牌失效", nil, c)
-				}
-				token := jwtware.FromContext(c)
-				if token != nil && token.Valid {
-					if claims, ok := token.Claims.(*systemReq.CustomClaims); ok {
-						c.Locals("claims", claims)
-					}
-				}
-				return c.Next()
-			},
-		})
+	return jwtware.New(jwtware.Config{
+		// 跳过静态文件路径，避免 401
+		Next: func(c fiber.Ctx) bool {
+			return isPublicPath(c.Path())
+		},
+		KeyFunc: utils.NewJWT().KeyFunc, // 按 kid 选择公钥, 支持密钥轮换
+		Claims:  &systemReq.CustomClaims{},
+		ErrorHandler: func(c fiber.Ctx, err error) error {
+			msg := "未登录或非法访问"
+			if err != nil && strings.Contains(strings.ToLower(err.Error()), "expired") {
+				msg = "授权已过期"
+			} else if err != nil && err.Error() != "" {
+				msg = err.Error()
+			}
+			return response.FailWithDetailed401(fiber.Map{"reload": true}, msg, err, c)
+		},
+		SuccessHandler: func(c fiber.Ctx) error {
+			rawToken := c.Get("Authorization", "")
+			tokenStr := strings.TrimPrefix(strings.TrimSpace(rawToken), "Bearer ")
+			if tokenStr == "" {
+				return response.FailWithDetailed401(fiber.Map{"reload": true}, "未登录或非法访问", nil, c)
+			}
+			// 按 jti 拉黑, 会话被作废(退出设备、强制下线)后该会话的全部 token 一起失效
+			if jwtService.IsBlacklist(tokenStr) {
+				return response.FailWithDetailed401(fiber.Map{"reload": true}, "您的帐户异地登陆或令牌失效", nil, c)
+			}
+			token := jwtware.FromContext(c)
+			if token == nil || !token.Valid {
+				return response.FailWithDetailed401(fiber.Map{"reload": true}, "未登录或非法访问", nil, c)
+			}
+			claims, ok := token.Claims.(*systemReq.CustomClaims)
+			if !ok {
+				return response.FailWithDetailed401(fiber.Map{"reload": true}, "未登录或非法访问", nil, c)
+			}
+			// 前台登录不经过二次验证, 用户 id 在各端也不是同一张表, 只接受本端签发的令牌
+			if !slices.Contains(claims.Audience, audience) {
+				return response.FailWithDetailed401(fiber.Map{"reload": true}, "令牌不能访问该接口", nil, c)
+			}
+			sessionService.Touch(claims.RegisteredClaims.ID, c.IP())
+			c.Locals("claims", claims)
+			return c.Next()
+		},
 	})
-	return jwtMiddleware
 }
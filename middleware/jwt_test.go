@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http/httptest"
+	"testing"
+
+	"server/config"
+	global "server/model"
+	"server/model/mobile"
+	"server/model/system"
+	systemReq "server/model/system/request"
+	"server/utils"
+
+	"github.com/gofiber/fiber/v3"
+	jwt "github.com/golang-jwt/jwt/v5"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// setupJWTKeys 中间件第一次请求时才读取公钥, 需要在发请求之前调用
+func setupJWTKeys(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keys, err := utils.LoadJWTKeys(config.PrivacyJWT{Keys: []config.JWTKey{
+		{Kid: "test", PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))},
+	}})
+	require.NoError(t, err)
+	global.RunCONFIG.JWT = keys
+	global.CONFIG.JWT.AccessExpiresMinutes = 15
+	global.LOG, global.BlackCache = zap.NewNop(), local_cache.NewCache()
+}
+
+func TestJWTAudience(t *testing.T) {
+	setupJWTKeys(t)
+	j := utils.NewJWT()
+	signed := func(audience string) string {
+		claims := j.CreateClaims(systemReq.BaseClaims{ID: 1, Username: "admin", AuthorityId: "888"})
+		claims.Audience = jwt.ClaimStrings{audience}
+		token, err := j.CreateToken(claims)
+		require.NoError(t, err)
+		return token
+	}
+	backendToken := signed(system.RefreshChannelBackend)
+	frontendToken := signed(system.RefreshChannelFrontend)
+	mobileToken, _, err := j.MakeToken(mobile.Login{Username: "reader"}, 1, "")
+	require.NoError(t, err)
+
+	app := fiber.New()
+	ok := func(c fiber.Ctx) error { return c.SendString("ok") }
+	app.Get("/backend/ping", JWTAuth, ok)
+	app.Get("/api/frontend/ping", JWTAuthFrontend, ok)
+	app.Get("/mobile/ping", JWTAuthMobileMiddleware(), ok)
+
+	cases := []struct {
+		name  string
+		path  string
+		token string
+		code  int
+	}{
+		{"后台令牌访问后台", "/backend/ping", backendToken, 200},
+		{"前台令牌访问后台", "/backend/ping", frontendToken, 401},
+		{"移动端令牌访问后台", "/backend/ping", mobileToken, 401},
+		{"前台令牌访问前台", "/api/frontend/ping", frontendToken, 200},
+		{"后台令牌访问前台", "/api/frontend/ping", backendToken, 401},
+		{"移动端令牌访问前台", "/api/frontend/ping", mobileToken, 401},
+		{"移动端令牌访问移动端", "/mobile/ping", mobileToken, 200},
+		{"后台令牌访问移动端", "/mobile/ping", backendToken, 401},
+		{"前台令牌访问移动端", "/mobile/ping", frontendToken, 401},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tc.path, nil)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tc.code, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"strconv"
+
+	global "server/model"
+	"server/model/common/response"
+	"server/model/system"
+	service "server/service/system"
+	"server/utils"
+	"server/utils/mask"
+
+	"github.com/gofiber/fiber/v3"
+	"go.uber.org/zap"
+)
+
+var maskingService = service.MaskingServiceApp
+
+// MaskingHandler 按角色的脱敏规则处理列表接口返回的敏感字段, 需放在 CasbinHandler 之后
+// 请求带 reveal=true 时不脱敏, 要求角色拥有查看完整数据的权限, 每次查看都会写入审计记录
+func MaskingHandler(c fiber.Ctx) error {
+	if isPublicPath(c.Path()) {
+		return c.Next()
+	}
+	claims, err := utils.GetClaims(c)
+	if err != nil {
+		return response.FailWithMessage401("token 错误", 3, err, c)
+	}
+	policy := maskingService.Policy(claims.AuthorityId)
+	if policy == nil {
+		return c.Next()
+	}
+	if reveal, _ := strconv.ParseBool(c.Query("reveal")); !reveal {
+		c.Locals(mask.LocalsKey, policy)
+		return c.Next()
+	}
+	ok, err := maskingService.CanReveal(claims.AuthorityId)
+	if err != nil {
+		return response.FailWithMessage403("验证失败", 3, err, c)
+	}
+	if !ok {
+		return response.FailWithMessage403("没有查看完整数据的权限", 3, nil, c)
+	}
+	err = c.Next()
+	audit := system.SysAuditLog{
+		Action:      system.AuditFieldReveal,
+		UserId:      claims.BaseClaims.ID,
+		AuthorityId: claims.AuthorityId,
+		Resource:    c.Route().Path,
+		Detail:      string(c.Request().URI().QueryString()),
+		Method:      c.Method(),
+		Path:        c.Path(),
+		Ip:          c.IP(),
+	}
+	if auditErr := auditLogService.CreateAuditLog(&audit); auditErr != nil {
+		global.LOG.Error("写入查看完整数据审计记录失败", zap.Error(auditErr))
+	}
+	return err
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"slices"
 	"strings"
 
 	jwtware "github.com/gofiber/contrib/v3/jwt"
@@ -8,6 +9,7 @@ import (
 
 	global "server/model"
 	"server/model/common/response"
+	"server/model/system"
 	"server/utils"
 )
 
@@ -19,11 +21,8 @@ func JWTAuthMobileMiddleware() fiber.Handler {
 	}
 
 	jwtMiddleware := jwtware.New(jwtware.Config{
-		SigningKey: jwtware.SigningKey{
-			JWTAlg: jwtware.RS256,
-			Key:    global.RunCONFIG.JWT.PublicKey,
-		},
-		Claims: &utils.MobileClaims{},
+		KeyFunc: utils.NewJWT().KeyFunc,
+		Claims:  &utils.MobileClaims{},
 		ErrorHandler: func(c fiber.Ctx, err error) error {
 			return response.FailWithMessage401("token 失效，请重新登录", 3, err, c)
 		},
@@ -37,6 +36,14 @@ func JWTAuthMobileMiddleware() fiber.Handler {
 			if !ok || claims.ID == 0 {
 				return response.FailWithMessage401("token 失效，请重新登录", 3, nil, c)
 			}
+			// 只接受移动端签发的令牌, 后台和前台的用户 id 不是 mobile 用户表的 id
+			if !slices.Contains(claims.Audience, system.RefreshChannelMobile) {
+				return response.FailWithMessage401("token 失效，请重新登录", 3, nil, c)
+			}
+			if jwtService.IsJtiBlacklist(claims.RegisteredClaims.ID) {
+				return response.FailWithMessage401("登录已失效，请重新登录", 3, nil, c)
+			}
+			sessionService.Touch(claims.RegisteredClaims.ID, c.IP())
 
 			c.Locals("mobile_claims", claims)
 			c.Locals("user_id", claims.ID)
@@ -2,9 +2,9 @@ package middleware
 
 import (
 	"errors"
-	"strings"
 
 	global "server/model"
+	"server/model/system"
 
 	"github.com/gofiber/fiber/v3"
 )
@@ -12,9 +12,8 @@ import (
 // 处理跨域请求,支持options访问
 
 func NeedInit(c fiber.Ctx) error {
-	var tables []string
-	global.DB.Raw("show tables").Scan(&tables)
-	if strings.Contains(strings.Join(tables, ""), "sys_users") {
+	// 使用 Migrator 判断, 兼容 mysql/pgsql/sqlite
+	if global.DB != nil && global.DB.Migrator().HasTable(&system.SysUser{}) {
 		return c.Next()
 	}
 	return errors.New("没有初始化数据库")
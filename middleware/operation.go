@@ -3,7 +3,6 @@ package middleware
 
 import (
 	"encoding/json"
-	global "server/model"
 	"server/model/system"
 	"server/utils"
 	"strconv"
@@ -14,18 +13,17 @@ import (
 	"github.com/gofiber/fiber/v3"
 
 	systemService "server/service/system"
-
-	"go.uber.org/zap"
 )
 
-// operationRecordService 操作记录服务实例
-var operationRecordService = new(systemService.OperationRecordService)
+// operationRecordWriter 操作记录异步写入
+var operationRecordWriter = systemService.OperationRecordWriterApp
 
 // OperationRecord 操作记录中间件
 // 用于记录用户的API操作历史，包括请求信息、响应状态、执行时间等
 // 支持GET请求的查询参数记录和POST/PUT等请求的请求体记录
 // 自动识别请求来源（后端管理、前端API、移动端）并记录相应的端口类型
 func OperationRecord(c fiber.Ctx) error {
+	start := time.Now()
 	var body []byte
 	var userId int
 	// var err error
@@ -89,15 +87,15 @@ func OperationRecord(c fiber.Ctx) error {
 		isBackend = system.Backend
 	}
 
-	// 创建操作记录对象
+	// 创建操作记录对象, 记录在请求结束后异步写入, fiber 会复用请求的内存, 字符串需要复制
 	record := system.SysOperationRecord{
-		Ip:       c.IP(),              // 客户端IP地址
-		Method:   c.Method(),          // HTTP请求方法
-		Path:     pathURL,             // 请求路径
-		Agent:    c.Get("User-Agent"), // 用户代理信息
-		Body:     string(body),        // 请求体内容
-		UserID:   userId,              // 用户ID
-		TypePort: isBackend,           // 请求来源类型
+		Ip:       strings.Clone(c.IP()),              // 客户端IP地址
+		Method:   strings.Clone(c.Method()),          // HTTP请求方法
+		Path:     strings.Clone(pathURL),             // 请求路径
+		Agent:    strings.Clone(c.Get("User-Agent")), // 用户代理信息
+		Body:     string(body),                       // 请求体内容
+		UserID:   userId,                             // 用户ID
+		TypePort: isBackend,                          // 请求来源类型
 	}
 
 	// 处理文件上传请求，对请求体进行长度限制
@@ -119,15 +117,13 @@ func OperationRecord(c fiber.Ctx) error {
 		}
 
 		// 计算请求处理延迟时间
-		record.Latency = time.Since(time.Now())
+		record.Latency = time.Since(start)
 
 		// 记录响应内容
 		record.Resp = string(c.Response().Body())
 
-		// 保存操作记录到数据库
-		if err := operationRecordService.CreateSysOperationRecord(&record); err != nil {
-			global.LOG.Error("create operation record error:", zap.Error(err))
-		}
+		// 提交到后台队列批量写入, 不占用请求时间
+		operationRecordWriter.Write(record)
 	}()
 
 	// 继续执行下一个中间件或处理器
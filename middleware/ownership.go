@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	global "server/model"
+	"server/model/common/request"
+	"server/model/common/response"
+	"server/model/system"
+	service "server/service/system"
+	"server/utils"
+
+	"github.com/gofiber/fiber/v3"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var (
+	ownershipService = service.OwnershipServiceApp
+	auditLogService  = service.AuditLogServiceApp
+)
+
+// Ownership 声明路由操作的记录及其创建人列, 只有创建人或拥有越权权限的角色可以继续, 需放在 JWTAuth 之后
+// 记录 id 取自路径参数 :id, 没有时取自请求体的 ids; 使用越权权限的操作会写入审计记录
+func Ownership(model schema.Tabler, column string) fiber.Handler {
+	rule := ownershipService.Register(model, column)
+	return func(c fiber.Ctx) error {
+		claims, err := utils.GetClaims(c)
+		if err != nil {
+			return response.FailWithMessage401("token 错误", 3, err, c)
+		}
+		ids, err := ownershipIds(c)
+		if err != nil {
+			return response.FailWithMessage400("获取id失败", 3, err, c)
+		}
+		override, err := ownershipService.CheckOwnership(rule, claims.BaseClaims.ID, claims.AuthorityId, ids)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return response.FailWithMessage404("记录不存在", 3, err, c)
+		case errors.Is(err, service.ErrNotOwner):
+			return response.FailWithMessage403(err.Error(), 3, nil, c)
+		case err != nil:
+			return response.FailWithMessage403("验证失败", 3, err, c)
+		}
+		if !override {
+			return c.Next()
+		}
+		err = c.Next()
+		strIds := make([]string, len(ids))
+		for i, id := range ids {
+			strIds[i] = strconv.FormatUint(uint64(id), 10)
+		}
+		audit := system.SysAuditLog{
+			Action:      system.AuditOwnershipOverride,
+			UserId:      claims.BaseClaims.ID,
+			AuthorityId: claims.AuthorityId,
+			Resource:    model.TableName(),
+			RecordIds:   strings.Join(strIds, ","),
+			Detail:      fmt.Sprintf("status %d", c.Response().StatusCode()),
+			Method:      c.Method(),
+			Path:        c.Path(),
+			Ip:          c.IP(),
+		}
+		if auditErr := auditLogService.CreateAuditLog(&audit); auditErr != nil {
+			global.LOG.Error("写入越权操作审计记录失败", zap.Error(auditErr))
+		}
+		return err
+	}
+}
+
+// ownershipIds 获取请求操作的记录 id
+func ownershipIds(c fiber.Ctx) ([]uint, error) {
+	if param := c.Params("id"); param != "" {
+		id, err := strconv.ParseUint(param, 10, 64)
+		if err != nil || id == 0 {
+			return nil, errors.New("id 格式错误")
+		}
+		return []uint{uint(id)}, nil
+	}
+	var req request.IdsReq
+	if err := c.Bind().Body(&req); err != nil {
+		return nil, err
+	}
+	if len(req.Ids) == 0 {
+		return nil, errors.New("未传入id")
+	}
+	ids := make([]uint, 0, len(req.Ids))
+	for _, id := range req.Ids {
+		if id <= 0 {
+			return nil, errors.New("id 格式错误")
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"server/config"
+	global "server/model"
+	"server/model/common/response"
+	"server/utils"
+	"server/utils/ratelimit"
+
+	"github.com/gofiber/fiber/v3"
+	"go.uber.org/zap"
+)
+
+const rateLimitPrefix = "ratelimit:"
+
+// redis 未开启或出错时使用进程内计数
+var memoryLimiter = ratelimit.NewMemory()
+
+// RateLimit 按 rate-limit 配置限流, 请求匹配的全部策略都会计数, 任意一条超限即返回 429;
+// 响应头按剩余次数最少的策略设置
+func RateLimit(c fiber.Ctx) error {
+	var (
+		policy  config.RateLimitPolicy
+		result  ratelimit.Result
+		matched bool
+	)
+	for _, p := range rateLimitPolicies() {
+		if !p.match(c) {
+			continue
+		}
+		r := allowRate(c.Context(), p.Name+":"+p.key(c), ratelimit.Rate{
+			Limit:  p.Limit,
+			Period: time.Duration(p.Period) * time.Second,
+			Burst:  p.Burst,
+		})
+		if !matched || worse(r, result) {
+			policy, result, matched = p.RateLimitPolicy, r, true
+		}
+	}
+	if !matched {
+		return c.Next()
+	}
+	c.Set("RateLimit-Limit", strconv.Itoa(policy.Limit))
+	c.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+	c.Set("RateLimit-Policy", strconv.Itoa(policy.Limit)+";w="+strconv.Itoa(policy.Period))
+	if !result.Allowed {
+		retryAfter := ceilSeconds(result.RetryAfter)
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+		return response.Result(fiber.StatusTooManyRequests, fiber.Map{"retryAfter": retryAfter}, "请求太过频繁, 请 "+strconv.Itoa(retryAfter)+" 秒后重试", c)
+	}
+	return c.Next()
+}
+
+// worse 被拒绝的结果中等待时间最长的优先, 都放行时剩余次数少的优先
+func worse(a, b ratelimit.Result) bool {
+	if a.Allowed != b.Allowed {
+		return !a.Allowed
+	}
+	if !a.Allowed {
+		return a.RetryAfter > b.RetryAfter
+	}
+	return a.Remaining < b.Remaining
+}
+
+func allowRate(ctx context.Context, key string, rate ratelimit.Rate) ratelimit.Result {
+	if global.REDIS != nil {
+		r, err := ratelimit.NewRedis(global.REDIS, rateLimitPrefix).Allow(ctx, key, rate)
+		if err == nil {
+			return r
+		}
+		global.LOG.Warn("redis 限流失败, 使用进程内计数", zap.String("key", key), zap.Error(err))
+	}
+	r, _ := memoryLimiter.Allow(ctx, key, rate)
+	return r
+}
+
+type rateLimitPolicy struct {
+	config.RateLimitPolicy
+}
+
+// rateLimitPolicies 未配置策略时沿用 system.iplimit-count/iplimit-time 对每个 ip 限流
+func rateLimitPolicies() []rateLimitPolicy {
+	policies := global.CONFIG.RateLimit.Policies
+	if len(policies) == 0 {
+		system := global.CONFIG.System
+		if system.LimitCountIP <= 0 || system.LimitTimeIP <= 0 {
+			return nil
+		}
+		policies = []config.RateLimitPolicy{{Name: "ip", By: "ip", Limit: system.LimitCountIP, Period: system.LimitTimeIP}}
+	}
+	list := make([]rateLimitPolicy, 0, len(policies))
+	for _, p := range policies {
+		if p.Limit > 0 && p.Period > 0 {
+			list = append(list, rateLimitPolicy{p})
+		}
+	}
+	return list
+}
+
+func (p rateLimitPolicy) match(c fiber.Ctx) bool {
+	if p.Method != "" && !strings.EqualFold(p.Method, c.Method()) {
+		return false
+	}
+	return p.Path == "" || matchPath(p.Path, c.Path())
+}
+
+// key 计数维度, user 从 token 中解析用户, 解析失败时按 ip
+func (p rateLimitPolicy) key(c fiber.Ctx) string {
+	switch p.By {
+	case "global":
+		return "global"
+	case "user":
+		token := strings.TrimPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if token != "" {
+			if claims, err := utils.NewJWT().ParseToken(token); err == nil && claims.BaseClaims.ID != 0 {
+				return "user:" + strconv.FormatUint(uint64(claims.BaseClaims.ID), 10)
+			}
+		}
+	}
+	return "ip:" + c.IP()
+}
+
+// matchPath 按段匹配路径, :param 匹配单段, 结尾的 * 匹配剩余部分
+func matchPath(pattern, path string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(path, prefix)
+	}
+	ps, qs := strings.Split(strings.Trim(pattern, "/"), "/"), strings.Split(strings.Trim(path, "/"), "/")
+	if len(ps) != len(qs) {
+		return false
+	}
+	for i := range ps {
+		if !strings.HasPrefix(ps[i], ":") && ps[i] != qs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,41 @@
+-- 删除初始化迁移创建的表和视图, 会清空全部数据
+SET FOREIGN_KEY_CHECKS = 0;
+
+DROP VIEW IF EXISTS `authority_menu`;
+DROP TABLE IF EXISTS `articles`;
+DROP TABLE IF EXISTS `article_tag`;
+DROP TABLE IF EXISTS `base_messages`;
+DROP TABLE IF EXISTS `casbin_rule`;
+DROP TABLE IF EXISTS `comments`;
+DROP TABLE IF EXISTS `exa_customers`;
+DROP TABLE IF EXISTS `exa_files`;
+DROP TABLE IF EXISTS `exa_file_chunks`;
+DROP TABLE IF EXISTS `exa_file_upload_and_downloads`;
+DROP TABLE IF EXISTS `file_upload_import`;
+DROP TABLE IF EXISTS `githubs`;
+DROP TABLE IF EXISTS `ips`;
+DROP TABLE IF EXISTS `jwt_blacklists`;
+DROP TABLE IF EXISTS `meta`;
+DROP TABLE IF EXISTS `mobile_users`;
+DROP TABLE IF EXISTS `praise`;
+DROP TABLE IF EXISTS `reply`;
+DROP TABLE IF EXISTS `sys_apis`;
+DROP TABLE IF EXISTS `sys_authorities`;
+DROP TABLE IF EXISTS `sys_authority_btns`;
+DROP TABLE IF EXISTS `sys_auto_codes`;
+DROP TABLE IF EXISTS `sys_auto_code_histories`;
+DROP TABLE IF EXISTS `sys_base_menus`;
+DROP TABLE IF EXISTS `sys_base_menu_btns`;
+DROP TABLE IF EXISTS `sys_base_menu_parameters`;
+DROP TABLE IF EXISTS `sys_data_authority_id`;
+DROP TABLE IF EXISTS `sys_dictionaries`;
+DROP TABLE IF EXISTS `sys_dictionary_details`;
+DROP TABLE IF EXISTS `sys_operation_records`;
+DROP TABLE IF EXISTS `sys_statistics`;
+DROP TABLE IF EXISTS `sys_users`;
+DROP TABLE IF EXISTS `sys_user_authority`;
+DROP TABLE IF EXISTS `sys_user_problems`;
+DROP TABLE IF EXISTS `tags`;
+DROP TABLE IF EXISTS `users`;
+
+SET FOREIGN_KEY_CHECKS = 1;
@@ -0,0 +1,24 @@
+-- 删除新增的表和字段, mobile_users 的 realname/phone 加密后超出原长度, 不改回原类型
+DROP TABLE IF EXISTS `sys_refresh_tokens`;
+DROP TABLE IF EXISTS `sys_user_totps`;
+DROP TABLE IF EXISTS `sys_user_recovery_codes`;
+DROP TABLE IF EXISTS `sys_totp_challenges`;
+DROP TABLE IF EXISTS `sys_password_resets`;
+DROP TABLE IF EXISTS `sys_recovery_challenges`;
+DROP TABLE IF EXISTS `sys_login_locks`;
+DROP TABLE IF EXISTS `sys_login_histories`;
+DROP TABLE IF EXISTS `sys_user_identities`;
+DROP TABLE IF EXISTS `sys_oidc_states`;
+DROP TABLE IF EXISTS `sys_api_keys`;
+DROP TABLE IF EXISTS `sys_sessions`;
+DROP TABLE IF EXISTS `sys_audit_logs`;
+DROP TABLE IF EXISTS `sys_menu_btn_apis`;
+
+ALTER TABLE `jwt_blacklists` DROP COLUMN `jti`;
+ALTER TABLE `jwt_blacklists` DROP COLUMN `expires_at`;
+ALTER TABLE `exa_file_upload_and_downloads` DROP COLUMN `user_id`;
+ALTER TABLE `sys_users` DROP COLUMN `phone_bidx`;
+ALTER TABLE `sys_users` DROP COLUMN `email_bidx`;
+ALTER TABLE `exa_customers` DROP COLUMN `customer_phone_bidx`;
+ALTER TABLE `mobile_users` DROP COLUMN `phone_bidx`;
+ALTER TABLE `mobile_users` DROP COLUMN `realname_bidx`;
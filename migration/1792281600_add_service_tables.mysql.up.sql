@@ -0,0 +1,252 @@
+-- 登录安全、会话、api 密钥、审计等功能新增的表和字段
+
+CREATE TABLE IF NOT EXISTS `sys_refresh_tokens` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `token_hash` varchar(64) COMMENT '令牌摘要',
+  `family_id` varchar(36) COMMENT '令牌族',
+  `channel` varchar(20) COMMENT '所属端',
+  `user_id` bigint unsigned COMMENT '用户id',
+  `expires_at` datetime(3) NULL COMMENT '过期时间',
+  `used_at` datetime(3) NULL COMMENT '轮换时间',
+  `revoked_at` datetime(3) NULL COMMENT '作废时间',
+  PRIMARY KEY (`id`),
+  INDEX `idx_sys_refresh_tokens_deleted_at` (`deleted_at`),
+  UNIQUE INDEX `idx_sys_refresh_tokens_token_hash` (`token_hash`),
+  INDEX `idx_sys_refresh_tokens_family_id` (`family_id`),
+  INDEX `idx_sys_refresh_tokens_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+CREATE TABLE IF NOT EXISTS `sys_user_totps` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `user_id` bigint unsigned COMMENT '用户id',
+  `secret` varchar(64) COMMENT 'TOTP密钥',
+  `enabled` boolean COMMENT '是否已开启',
+  `enabled_at` datetime(3) NULL COMMENT '开启时间',
+  `last_step` bigint COMMENT '最后一次使用的时间步, 防止验证码重放',
+  PRIMARY KEY (`id`),
+  INDEX `idx_sys_user_totps_deleted_at` (`deleted_at`),
+  UNIQUE INDEX `idx_sys_user_totps_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+CREATE TABLE IF NOT EXISTS `sys_user_recovery_codes` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `user_id` bigint unsigned COMMENT '用户id',
+  `code_hash` varchar(64) COMMENT '恢复码摘要',
+  `used_at` datetime(3) NULL COMMENT '使用时间',
+  PRIMARY KEY (`id`),
+  INDEX `idx_sys_user_recovery_codes_deleted_at` (`deleted_at`),
+  INDEX `idx_sys_user_recovery_codes_user_id` (`user_id`),
+  INDEX `idx_sys_user_recovery_codes_code_hash` (`code_hash`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+CREATE TABLE IF NOT EXISTS `sys_totp_challenges` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `token_hash` varchar(64) COMMENT '凭证摘要',
+  `user_id` bigint unsigned COMMENT '用户id',
+  `expires_at` datetime(3) NULL COMMENT '过期时间',
+  `attempts` bigint COMMENT '验证失败次数',
+  `used_at` datetime(3) NULL COMMENT '使用时间',
+  PRIMARY KEY (`id`),
+  INDEX `idx_sys_totp_challenges_deleted_at` (`deleted_at`),
+  UNIQUE INDEX `idx_sys_totp_challenges_token_hash` (`token_hash`),
+  INDEX `idx_sys_totp_challenges_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+CREATE TABLE IF NOT EXISTS `sys_password_resets` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `token_hash` varchar(64) COMMENT '令牌摘要',
+  `user_id` bigint unsigned COMMENT '用户id',
+  `channel` varchar(20) COMMENT '发起端',
+  `request_ip` varchar(64) COMMENT '请求ip',
+  `expires_at` datetime(3) NULL COMMENT '过期时间',
+  `used_at` datetime(3) NULL COMMENT '使用时间',
+  PRIMARY KEY (`id`),
+  INDEX `idx_sys_password_resets_deleted_at` (`deleted_at`),
+  UNIQUE INDEX `idx_sys_password_resets_token_hash` (`token_hash`),
+  INDEX `idx_sys_password_resets_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+CREATE TABLE IF NOT EXISTS `sys_recovery_challenges` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `token_hash` varchar(64) COMMENT '凭证摘要',
+  `user_id` bigint unsigned COMMENT '用户id',
+  `problem_ids` varchar(255) COMMENT '提问的问题id, 逗号分隔',
+  `expires_at` datetime(3) NULL COMMENT '过期时间',
+  `used_at` datetime(3) NULL COMMENT '作答时间',
+  PRIMARY KEY (`id`),
+  INDEX `idx_sys_recovery_challenges_deleted_at` (`deleted_at`),
+  UNIQUE INDEX `idx_sys_recovery_challenges_token_hash` (`token_hash`),
+  INDEX `idx_sys_recovery_challenges_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+CREATE TABLE IF NOT EXISTS `sys_login_locks` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `kind` varchar(20) COMMENT '锁定对象 account/ip',
+  `channel` varchar(20) COMMENT '所属端, ip锁定为空',
+  `subject` varchar(191) COMMENT '用户名或ip',
+  `failures` bigint COMMENT '锁定时的失败次数',
+  `last_ip` varchar(64) COMMENT '最后一次失败的ip',
+  `locked_until` datetime(3) NULL COMMENT '锁定到期时间',
+  PRIMARY KEY (`id`),
+  INDEX `idx_sys_login_locks_deleted_at` (`deleted_at`),
+  UNIQUE INDEX `idx_login_lock` (`kind`,`channel`,`subject`),
+  INDEX `idx_sys_login_locks_locked_until` (`locked_until`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+CREATE TABLE IF NOT EXISTS `sys_login_histories` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `channel` varchar(20) COMMENT '所属端',
+  `user_id` bigint unsigned COMMENT '用户id, 账号不存在时为0',
+  `username` varchar(191) COMMENT '登录时填写的用户名',
+  `ip` varchar(64) COMMENT '请求ip',
+  `user_agent` varchar(255) COMMENT '客户端',
+  `success` boolean COMMENT '是否成功',
+  `reason` varchar(64) COMMENT '失败原因',
+  PRIMARY KEY (`id`),
+  INDEX `idx_sys_login_histories_deleted_at` (`deleted_at`),
+  INDEX `idx_sys_login_histories_channel` (`channel`),
+  INDEX `idx_sys_login_histories_user_id` (`user_id`),
+  INDEX `idx_sys_login_histories_username` (`username`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+CREATE TABLE IF NOT EXISTS `sys_user_identities` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `user_id` bigint unsigned COMMENT '用户id',
+  `provider` varchar(64) COMMENT '身份提供方',
+  `subject` varchar(191) COMMENT '外部用户标识 sub',
+  `email` varchar(191) COMMENT '关联时的邮箱',
+  `last_login_at` datetime(3) NULL COMMENT '最后一次登录时间',
+  PRIMARY KEY (`id`),
+  INDEX `idx_sys_user_identities_deleted_at` (`deleted_at`),
+  INDEX `idx_sys_user_identities_user_id` (`user_id`),
+  UNIQUE INDEX `idx_identity_subject` (`provider`,`subject`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+CREATE TABLE IF NOT EXISTS `sys_oidc_states` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `state_hash` varchar(64) COMMENT 'state 摘要',
+  `provider` varchar(64) COMMENT '身份提供方',
+  `channel` varchar(20) COMMENT '发起端 backend/frontend',
+  `nonce` varchar(64) COMMENT 'nonce',
+  `code_verifier` varchar(128) COMMENT 'PKCE code_verifier',
+  `link_user_id` bigint unsigned COMMENT '不为0时回调只关联到该用户, 不登录',
+  `expires_at` datetime(3) NULL COMMENT '过期时间',
+  `used_at` datetime(3) NULL COMMENT '使用时间',
+  PRIMARY KEY (`id`),
+  INDEX `idx_sys_oidc_states_deleted_at` (`deleted_at`),
+  UNIQUE INDEX `idx_sys_oidc_states_state_hash` (`state_hash`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+CREATE TABLE IF NOT EXISTS `sys_api_keys` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `user_id` bigint unsigned COMMENT '用户id',
+  `name` varchar(64) COMMENT '名称',
+  `key_hash` varchar(64) COMMENT '密钥摘要',
+  `hint` varchar(16) COMMENT '密钥前几位, 用于辨认',
+  `scopes` text COMMENT '允许访问的接口',
+  `expires_at` datetime(3) NULL COMMENT '过期时间, 为空不过期',
+  `last_used_at` datetime(3) NULL COMMENT '最后使用时间',
+  `last_used_ip` varchar(64) COMMENT '最后使用ip',
+  `revoked_at` datetime(3) NULL COMMENT '作废时间',
+  PRIMARY KEY (`id`),
+  INDEX `idx_sys_api_keys_deleted_at` (`deleted_at`),
+  INDEX `idx_sys_api_keys_user_id` (`user_id`),
+  UNIQUE INDEX `idx_sys_api_keys_key_hash` (`key_hash`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+CREATE TABLE IF NOT EXISTS `sys_sessions` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `jti` varchar(36) COMMENT '令牌id',
+  `channel` varchar(20) COMMENT '所属端',
+  `user_id` bigint unsigned COMMENT '用户id',
+  `ip` varchar(64) COMMENT '最后使用ip',
+  `user_agent` varchar(512) COMMENT '客户端',
+  `last_active_at` datetime(3) NULL COMMENT '最后活动时间',
+  `expires_at` datetime(3) NULL COMMENT '过期时间',
+  `revoked_at` datetime(3) NULL COMMENT '作废时间',
+  PRIMARY KEY (`id`),
+  INDEX `idx_sys_sessions_deleted_at` (`deleted_at`),
+  UNIQUE INDEX `idx_sys_sessions_jti` (`jti`),
+  INDEX `idx_session_user` (`channel`,`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+CREATE TABLE IF NOT EXISTS `sys_audit_logs` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `action` varchar(64) COMMENT '审计动作',
+  `user_id` bigint unsigned COMMENT '操作用户id',
+  `authority_id` varchar(90) COMMENT '操作用户角色',
+  `resource` varchar(191) COMMENT '操作对象, 一般为表名',
+  `record_ids` varchar(255) COMMENT '操作的记录id, 多个以逗号分隔',
+  `detail` text COMMENT '补充信息',
+  `method` varchar(16) COMMENT '请求方法',
+  `path` varchar(255) COMMENT '请求路径',
+  `ip` varchar(64) COMMENT '请求ip',
+  PRIMARY KEY (`id`),
+  INDEX `idx_sys_audit_logs_deleted_at` (`deleted_at`),
+  INDEX `idx_sys_audit_logs_action` (`action`),
+  INDEX `idx_sys_audit_logs_user_id` (`user_id`),
+  INDEX `idx_sys_audit_logs_resource` (`resource`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+CREATE TABLE IF NOT EXISTS `sys_menu_btn_apis` (
+  `sys_base_menu_btn_id` bigint unsigned,
+  `sys_api_id` bigint unsigned,
+  PRIMARY KEY (`sys_base_menu_btn_id`,`sys_api_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+ALTER TABLE `jwt_blacklists` ADD COLUMN `jti` varchar(64) COMMENT '令牌id, 旧令牌没有jti时为jwt的sha256';
+ALTER TABLE `jwt_blacklists` ADD COLUMN `expires_at` datetime(3) NULL COMMENT '令牌过期时间';
+ALTER TABLE `exa_file_upload_and_downloads` ADD COLUMN `user_id` bigint unsigned COMMENT '上传用户ID';
+ALTER TABLE `sys_users` ADD COLUMN `phone_bidx` varchar(64) COMMENT '手机号盲索引';
+ALTER TABLE `sys_users` ADD COLUMN `email_bidx` varchar(64) COMMENT '邮箱盲索引';
+ALTER TABLE `exa_customers` ADD COLUMN `customer_phone_bidx` varchar(64) COMMENT '客户手机号盲索引';
+ALTER TABLE `mobile_users` ADD COLUMN `phone_bidx` varchar(64) COMMENT '电话盲索引';
+ALTER TABLE `mobile_users` ADD COLUMN `realname_bidx` varchar(64) COMMENT '真实姓名盲索引';
+CREATE INDEX `idx_jwt_blacklists_jti` ON `jwt_blacklists`(`jti`);
+CREATE INDEX `idx_jwt_blacklists_expires_at` ON `jwt_blacklists`(`expires_at`);
+CREATE INDEX `idx_exa_file_upload_and_downloads_user_id` ON `exa_file_upload_and_downloads`(`user_id`);
+CREATE INDEX `idx_sys_users_phone_index` ON `sys_users`(`phone_bidx`);
+CREATE INDEX `idx_sys_users_email_index` ON `sys_users`(`email_bidx`);
+CREATE INDEX `idx_exa_customers_customer_phone_index` ON `exa_customers`(`customer_phone_bidx`);
+CREATE INDEX `idx_mobile_users_phone_index` ON `mobile_users`(`phone_bidx`);
+CREATE INDEX `idx_mobile_users_realname_index` ON `mobile_users`(`realname_bidx`);
+ALTER TABLE `mobile_users` MODIFY COLUMN `realname` varchar(255) COMMENT '真实姓名';
+ALTER TABLE `mobile_users` MODIFY COLUMN `phone` varchar(255) COMMENT '电话';
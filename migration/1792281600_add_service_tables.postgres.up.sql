@@ -0,0 +1,343 @@
+-- 登录安全、会话、api 密钥、审计等功能新增的表和字段
+
+CREATE TABLE IF NOT EXISTS sys_refresh_tokens (
+  id bigserial,
+  created_at timestamptz,
+  updated_at timestamptz,
+  deleted_at timestamptz,
+  token_hash varchar(64),
+  family_id varchar(36),
+  channel varchar(20),
+  user_id bigint,
+  expires_at timestamptz,
+  used_at timestamptz,
+  revoked_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_sys_refresh_tokens_user_id ON sys_refresh_tokens (user_id);
+CREATE INDEX IF NOT EXISTS idx_sys_refresh_tokens_family_id ON sys_refresh_tokens (family_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sys_refresh_tokens_token_hash ON sys_refresh_tokens (token_hash);
+CREATE INDEX IF NOT EXISTS idx_sys_refresh_tokens_deleted_at ON sys_refresh_tokens (deleted_at);
+COMMENT ON COLUMN sys_refresh_tokens.token_hash IS '令牌摘要';
+COMMENT ON COLUMN sys_refresh_tokens.family_id IS '令牌族';
+COMMENT ON COLUMN sys_refresh_tokens.channel IS '所属端';
+COMMENT ON COLUMN sys_refresh_tokens.user_id IS '用户id';
+COMMENT ON COLUMN sys_refresh_tokens.expires_at IS '过期时间';
+COMMENT ON COLUMN sys_refresh_tokens.used_at IS '轮换时间';
+COMMENT ON COLUMN sys_refresh_tokens.revoked_at IS '作废时间';
+
+CREATE TABLE IF NOT EXISTS sys_user_totps (
+  id bigserial,
+  created_at timestamptz,
+  updated_at timestamptz,
+  deleted_at timestamptz,
+  user_id bigint,
+  secret varchar(64),
+  enabled boolean,
+  enabled_at timestamptz,
+  last_step bigint,
+  PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sys_user_totps_user_id ON sys_user_totps (user_id);
+CREATE INDEX IF NOT EXISTS idx_sys_user_totps_deleted_at ON sys_user_totps (deleted_at);
+COMMENT ON COLUMN sys_user_totps.user_id IS '用户id';
+COMMENT ON COLUMN sys_user_totps.secret IS 'TOTP密钥';
+COMMENT ON COLUMN sys_user_totps.enabled IS '是否已开启';
+COMMENT ON COLUMN sys_user_totps.enabled_at IS '开启时间';
+COMMENT ON COLUMN sys_user_totps.last_step IS '最后一次使用的时间步, 防止验证码重放';
+
+CREATE TABLE IF NOT EXISTS sys_user_recovery_codes (
+  id bigserial,
+  created_at timestamptz,
+  updated_at timestamptz,
+  deleted_at timestamptz,
+  user_id bigint,
+  code_hash varchar(64),
+  used_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_sys_user_recovery_codes_code_hash ON sys_user_recovery_codes (code_hash);
+CREATE INDEX IF NOT EXISTS idx_sys_user_recovery_codes_user_id ON sys_user_recovery_codes (user_id);
+CREATE INDEX IF NOT EXISTS idx_sys_user_recovery_codes_deleted_at ON sys_user_recovery_codes (deleted_at);
+COMMENT ON COLUMN sys_user_recovery_codes.user_id IS '用户id';
+COMMENT ON COLUMN sys_user_recovery_codes.code_hash IS '恢复码摘要';
+COMMENT ON COLUMN sys_user_recovery_codes.used_at IS '使用时间';
+
+CREATE TABLE IF NOT EXISTS sys_totp_challenges (
+  id bigserial,
+  created_at timestamptz,
+  updated_at timestamptz,
+  deleted_at timestamptz,
+  token_hash varchar(64),
+  user_id bigint,
+  expires_at timestamptz,
+  attempts bigint,
+  used_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_sys_totp_challenges_user_id ON sys_totp_challenges (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sys_totp_challenges_token_hash ON sys_totp_challenges (token_hash);
+CREATE INDEX IF NOT EXISTS idx_sys_totp_challenges_deleted_at ON sys_totp_challenges (deleted_at);
+COMMENT ON COLUMN sys_totp_challenges.token_hash IS '凭证摘要';
+COMMENT ON COLUMN sys_totp_challenges.user_id IS '用户id';
+COMMENT ON COLUMN sys_totp_challenges.expires_at IS '过期时间';
+COMMENT ON COLUMN sys_totp_challenges.attempts IS '验证失败次数';
+COMMENT ON COLUMN sys_totp_challenges.used_at IS '使用时间';
+
+CREATE TABLE IF NOT EXISTS sys_password_resets (
+  id bigserial,
+  created_at timestamptz,
+  updated_at timestamptz,
+  deleted_at timestamptz,
+  token_hash varchar(64),
+  user_id bigint,
+  channel varchar(20),
+  request_ip varchar(64),
+  expires_at timestamptz,
+  used_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_sys_password_resets_user_id ON sys_password_resets (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sys_password_resets_token_hash ON sys_password_resets (token_hash);
+CREATE INDEX IF NOT EXISTS idx_sys_password_resets_deleted_at ON sys_password_resets (deleted_at);
+COMMENT ON COLUMN sys_password_resets.token_hash IS '令牌摘要';
+COMMENT ON COLUMN sys_password_resets.user_id IS '用户id';
+COMMENT ON COLUMN sys_password_resets.channel IS '发起端';
+COMMENT ON COLUMN sys_password_resets.request_ip IS '请求ip';
+COMMENT ON COLUMN sys_password_resets.expires_at IS '过期时间';
+COMMENT ON COLUMN sys_password_resets.used_at IS '使用时间';
+
+CREATE TABLE IF NOT EXISTS sys_recovery_challenges (
+  id bigserial,
+  created_at timestamptz,
+  updated_at timestamptz,
+  deleted_at timestamptz,
+  token_hash varchar(64),
+  user_id bigint,
+  problem_ids varchar(255),
+  expires_at timestamptz,
+  used_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_sys_recovery_challenges_user_id ON sys_recovery_challenges (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sys_recovery_challenges_token_hash ON sys_recovery_challenges (token_hash);
+CREATE INDEX IF NOT EXISTS idx_sys_recovery_challenges_deleted_at ON sys_recovery_challenges (deleted_at);
+COMMENT ON COLUMN sys_recovery_challenges.token_hash IS '凭证摘要';
+COMMENT ON COLUMN sys_recovery_challenges.user_id IS '用户id';
+COMMENT ON COLUMN sys_recovery_challenges.problem_ids IS '提问的问题id, 逗号分隔';
+COMMENT ON COLUMN sys_recovery_challenges.expires_at IS '过期时间';
+COMMENT ON COLUMN sys_recovery_challenges.used_at IS '作答时间';
+
+CREATE TABLE IF NOT EXISTS sys_login_locks (
+  id bigserial,
+  created_at timestamptz,
+  updated_at timestamptz,
+  deleted_at timestamptz,
+  kind varchar(20),
+  channel varchar(20),
+  subject varchar(191),
+  failures bigint,
+  last_ip varchar(64),
+  locked_until timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_sys_login_locks_locked_until ON sys_login_locks (locked_until);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_login_lock ON sys_login_locks (kind,channel,subject);
+CREATE INDEX IF NOT EXISTS idx_sys_login_locks_deleted_at ON sys_login_locks (deleted_at);
+COMMENT ON COLUMN sys_login_locks.kind IS '锁定对象 account/ip';
+COMMENT ON COLUMN sys_login_locks.channel IS '所属端, ip锁定为空';
+COMMENT ON COLUMN sys_login_locks.subject IS '用户名或ip';
+COMMENT ON COLUMN sys_login_locks.failures IS '锁定时的失败次数';
+COMMENT ON COLUMN sys_login_locks.last_ip IS '最后一次失败的ip';
+COMMENT ON COLUMN sys_login_locks.locked_until IS '锁定到期时间';
+
+CREATE TABLE IF NOT EXISTS sys_login_histories (
+  id bigserial,
+  created_at timestamptz,
+  updated_at timestamptz,
+  deleted_at timestamptz,
+  channel varchar(20),
+  user_id bigint,
+  username varchar(191),
+  ip varchar(64),
+  user_agent varchar(255),
+  success boolean,
+  reason varchar(64),
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_sys_login_histories_username ON sys_login_histories (username);
+CREATE INDEX IF NOT EXISTS idx_sys_login_histories_user_id ON sys_login_histories (user_id);
+CREATE INDEX IF NOT EXISTS idx_sys_login_histories_channel ON sys_login_histories (channel);
+CREATE INDEX IF NOT EXISTS idx_sys_login_histories_deleted_at ON sys_login_histories (deleted_at);
+COMMENT ON COLUMN sys_login_histories.channel IS '所属端';
+COMMENT ON COLUMN sys_login_histories.user_id IS '用户id, 账号不存在时为0';
+COMMENT ON COLUMN sys_login_histories.username IS '登录时填写的用户名';
+COMMENT ON COLUMN sys_login_histories.ip IS '请求ip';
+COMMENT ON COLUMN sys_login_histories.user_agent IS '客户端';
+COMMENT ON COLUMN sys_login_histories.success IS '是否成功';
+COMMENT ON COLUMN sys_login_histories.reason IS '失败原因';
+
+CREATE TABLE IF NOT EXISTS sys_user_identities (
+  id bigserial,
+  created_at timestamptz,
+  updated_at timestamptz,
+  deleted_at timestamptz,
+  user_id bigint,
+  provider varchar(64),
+  subject varchar(191),
+  email varchar(191),
+  last_login_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_identity_subject ON sys_user_identities (provider,subject);
+CREATE INDEX IF NOT EXISTS idx_sys_user_identities_user_id ON sys_user_identities (user_id);
+CREATE INDEX IF NOT EXISTS idx_sys_user_identities_deleted_at ON sys_user_identities (deleted_at);
+COMMENT ON COLUMN sys_user_identities.user_id IS '用户id';
+COMMENT ON COLUMN sys_user_identities.provider IS '身份提供方';
+COMMENT ON COLUMN sys_user_identities.subject IS '外部用户标识 sub';
+COMMENT ON COLUMN sys_user_identities.email IS '关联时的邮箱';
+COMMENT ON COLUMN sys_user_identities.last_login_at IS '最后一次登录时间';
+
+CREATE TABLE IF NOT EXISTS sys_oidc_states (
+  id bigserial,
+  created_at timestamptz,
+  updated_at timestamptz,
+  deleted_at timestamptz,
+  state_hash varchar(64),
+  provider varchar(64),
+  channel varchar(20),
+  nonce varchar(64),
+  code_verifier varchar(128),
+  link_user_id bigint,
+  expires_at timestamptz,
+  used_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sys_oidc_states_state_hash ON sys_oidc_states (state_hash);
+CREATE INDEX IF NOT EXISTS idx_sys_oidc_states_deleted_at ON sys_oidc_states (deleted_at);
+COMMENT ON COLUMN sys_oidc_states.state_hash IS 'state 摘要';
+COMMENT ON COLUMN sys_oidc_states.provider IS '身份提供方';
+COMMENT ON COLUMN sys_oidc_states.channel IS '发起端 backend/frontend';
+COMMENT ON COLUMN sys_oidc_states.nonce IS 'nonce';
+COMMENT ON COLUMN sys_oidc_states.code_verifier IS 'PKCE code_verifier';
+COMMENT ON COLUMN sys_oidc_states.link_user_id IS '不为0时回调只关联到该用户, 不登录';
+COMMENT ON COLUMN sys_oidc_states.expires_at IS '过期时间';
+COMMENT ON COLUMN sys_oidc_states.used_at IS '使用时间';
+
+CREATE TABLE IF NOT EXISTS sys_api_keys (
+  id bigserial,
+  created_at timestamptz,
+  updated_at timestamptz,
+  deleted_at timestamptz,
+  user_id bigint,
+  name varchar(64),
+  key_hash varchar(64),
+  hint varchar(16),
+  scopes text,
+  expires_at timestamptz,
+  last_used_at timestamptz,
+  last_used_ip varchar(64),
+  revoked_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sys_api_keys_key_hash ON sys_api_keys (key_hash);
+CREATE INDEX IF NOT EXISTS idx_sys_api_keys_user_id ON sys_api_keys (user_id);
+CREATE INDEX IF NOT EXISTS idx_sys_api_keys_deleted_at ON sys_api_keys (deleted_at);
+COMMENT ON COLUMN sys_api_keys.user_id IS '用户id';
+COMMENT ON COLUMN sys_api_keys.name IS '名称';
+COMMENT ON COLUMN sys_api_keys.key_hash IS '密钥摘要';
+COMMENT ON COLUMN sys_api_keys.hint IS '密钥前几位, 用于辨认';
+COMMENT ON COLUMN sys_api_keys.scopes IS '允许访问的接口';
+COMMENT ON COLUMN sys_api_keys.expires_at IS '过期时间, 为空不过期';
+COMMENT ON COLUMN sys_api_keys.last_used_at IS '最后使用时间';
+COMMENT ON COLUMN sys_api_keys.last_used_ip IS '最后使用ip';
+COMMENT ON COLUMN sys_api_keys.revoked_at IS '作废时间';
+
+CREATE TABLE IF NOT EXISTS sys_sessions (
+  id bigserial,
+  created_at timestamptz,
+  updated_at timestamptz,
+  deleted_at timestamptz,
+  jti varchar(36),
+  channel varchar(20),
+  user_id bigint,
+  ip varchar(64),
+  user_agent varchar(512),
+  last_active_at timestamptz,
+  expires_at timestamptz,
+  revoked_at timestamptz,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_session_user ON sys_sessions (channel,user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sys_sessions_jti ON sys_sessions (jti);
+CREATE INDEX IF NOT EXISTS idx_sys_sessions_deleted_at ON sys_sessions (deleted_at);
+COMMENT ON COLUMN sys_sessions.jti IS '令牌id';
+COMMENT ON COLUMN sys_sessions.channel IS '所属端';
+COMMENT ON COLUMN sys_sessions.user_id IS '用户id';
+COMMENT ON COLUMN sys_sessions.ip IS '最后使用ip';
+COMMENT ON COLUMN sys_sessions.user_agent IS '客户端';
+COMMENT ON COLUMN sys_sessions.last_active_at IS '最后活动时间';
+COMMENT ON COLUMN sys_sessions.expires_at IS '过期时间';
+COMMENT ON COLUMN sys_sessions.revoked_at IS '作废时间';
+
+CREATE TABLE IF NOT EXISTS sys_audit_logs (
+  id bigserial,
+  created_at timestamptz,
+  updated_at timestamptz,
+  deleted_at timestamptz,
+  action varchar(64),
+  user_id bigint,
+  authority_id varchar(90),
+  resource varchar(191),
+  record_ids varchar(255),
+  detail text,
+  method varchar(16),
+  path varchar(255),
+  ip varchar(64),
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_sys_audit_logs_resource ON sys_audit_logs (resource);
+CREATE INDEX IF NOT EXISTS idx_sys_audit_logs_user_id ON sys_audit_logs (user_id);
+CREATE INDEX IF NOT EXISTS idx_sys_audit_logs_action ON sys_audit_logs (action);
+CREATE INDEX IF NOT EXISTS idx_sys_audit_logs_deleted_at ON sys_audit_logs (deleted_at);
+COMMENT ON COLUMN sys_audit_logs.action IS '审计动作';
+COMMENT ON COLUMN sys_audit_logs.user_id IS '操作用户id';
+COMMENT ON COLUMN sys_audit_logs.authority_id IS '操作用户角色';
+COMMENT ON COLUMN sys_audit_logs.resource IS '操作对象, 一般为表名';
+COMMENT ON COLUMN sys_audit_logs.record_ids IS '操作的记录id, 多个以逗号分隔';
+COMMENT ON COLUMN sys_audit_logs.detail IS '补充信息';
+COMMENT ON COLUMN sys_audit_logs.method IS '请求方法';
+COMMENT ON COLUMN sys_audit_logs.path IS '请求路径';
+COMMENT ON COLUMN sys_audit_logs.ip IS '请求ip';
+
+CREATE TABLE IF NOT EXISTS sys_menu_btn_apis (
+  sys_base_menu_btn_id bigint,
+  sys_api_id bigint,
+  PRIMARY KEY (sys_base_menu_btn_id,sys_api_id)
+);
+ALTER TABLE jwt_blacklists ADD COLUMN IF NOT EXISTS jti varchar(64);
+COMMENT ON COLUMN jwt_blacklists.jti IS '令牌id, 旧令牌没有jti时为jwt的sha256';
+ALTER TABLE jwt_blacklists ADD COLUMN IF NOT EXISTS expires_at timestamptz;
+COMMENT ON COLUMN jwt_blacklists.expires_at IS '令牌过期时间';
+ALTER TABLE exa_file_upload_and_downloads ADD COLUMN IF NOT EXISTS user_id bigint;
+COMMENT ON COLUMN exa_file_upload_and_downloads.user_id IS '上传用户ID';
+ALTER TABLE sys_users ADD COLUMN IF NOT EXISTS phone_bidx varchar(64);
+COMMENT ON COLUMN sys_users.phone_bidx IS '手机号盲索引';
+ALTER TABLE sys_users ADD COLUMN IF NOT EXISTS email_bidx varchar(64);
+COMMENT ON COLUMN sys_users.email_bidx IS '邮箱盲索引';
+ALTER TABLE exa_customers ADD COLUMN IF NOT EXISTS customer_phone_bidx varchar(64);
+COMMENT ON COLUMN exa_customers.customer_phone_bidx IS '客户手机号盲索引';
+ALTER TABLE mobile_users ADD COLUMN IF NOT EXISTS phone_bidx varchar(64);
+COMMENT ON COLUMN mobile_users.phone_bidx IS '电话盲索引';
+ALTER TABLE mobile_users ADD COLUMN IF NOT EXISTS realname_bidx varchar(64);
+COMMENT ON COLUMN mobile_users.realname_bidx IS '真实姓名盲索引';
+CREATE INDEX IF NOT EXISTS idx_jwt_blacklists_jti ON jwt_blacklists (jti);
+CREATE INDEX IF NOT EXISTS idx_jwt_blacklists_expires_at ON jwt_blacklists (expires_at);
+CREATE INDEX IF NOT EXISTS idx_exa_file_upload_and_downloads_user_id ON exa_file_upload_and_downloads (user_id);
+CREATE INDEX IF NOT EXISTS idx_sys_users_phone_index ON sys_users (phone_bidx);
+CREATE INDEX IF NOT EXISTS idx_sys_users_email_index ON sys_users (email_bidx);
+CREATE INDEX IF NOT EXISTS idx_exa_customers_customer_phone_index ON exa_customers (customer_phone_bidx);
+CREATE INDEX IF NOT EXISTS idx_mobile_users_phone_index ON mobile_users (phone_bidx);
+CREATE INDEX IF NOT EXISTS idx_mobile_users_realname_index ON mobile_users (realname_bidx);
+ALTER TABLE mobile_users ALTER COLUMN realname TYPE varchar(255);
+ALTER TABLE mobile_users ALTER COLUMN phone TYPE varchar(255);
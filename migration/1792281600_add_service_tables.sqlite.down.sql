@@ -0,0 +1,32 @@
+-- 删除新增的表和字段, sqlite 删除字段前需要先删除字段上的索引
+DROP TABLE IF EXISTS `sys_refresh_tokens`;
+DROP TABLE IF EXISTS `sys_user_totps`;
+DROP TABLE IF EXISTS `sys_user_recovery_codes`;
+DROP TABLE IF EXISTS `sys_totp_challenges`;
+DROP TABLE IF EXISTS `sys_password_resets`;
+DROP TABLE IF EXISTS `sys_recovery_challenges`;
+DROP TABLE IF EXISTS `sys_login_locks`;
+DROP TABLE IF EXISTS `sys_login_histories`;
+DROP TABLE IF EXISTS `sys_user_identities`;
+DROP TABLE IF EXISTS `sys_oidc_states`;
+DROP TABLE IF EXISTS `sys_api_keys`;
+DROP TABLE IF EXISTS `sys_sessions`;
+DROP TABLE IF EXISTS `sys_audit_logs`;
+DROP TABLE IF EXISTS `sys_menu_btn_apis`;
+
+DROP INDEX IF EXISTS `idx_jwt_blacklists_jti`;
+DROP INDEX IF EXISTS `idx_jwt_blacklists_expires_at`;
+DROP INDEX IF EXISTS `idx_exa_file_upload_and_downloads_user_id`;
+DROP INDEX IF EXISTS `idx_sys_users_phone_index`;
+DROP INDEX IF EXISTS `idx_sys_users_email_index`;
+DROP INDEX IF EXISTS `idx_exa_customers_customer_phone_index`;
+DROP INDEX IF EXISTS `idx_mobile_users_phone_index`;
+DROP INDEX IF EXISTS `idx_mobile_users_realname_index`;
+ALTER TABLE `jwt_blacklists` DROP COLUMN `jti`;
+ALTER TABLE `jwt_blacklists` DROP COLUMN `expires_at`;
+ALTER TABLE `exa_file_upload_and_downloads` DROP COLUMN `user_id`;
+ALTER TABLE `sys_users` DROP COLUMN `phone_bidx`;
+ALTER TABLE `sys_users` DROP COLUMN `email_bidx`;
+ALTER TABLE `exa_customers` DROP COLUMN `customer_phone_bidx`;
+ALTER TABLE `mobile_users` DROP COLUMN `phone_bidx`;
+ALTER TABLE `mobile_users` DROP COLUMN `realname_bidx`;
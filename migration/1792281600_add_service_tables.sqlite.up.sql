@@ -0,0 +1,237 @@
+-- 登录安全、会话、api 密钥、审计等功能新增的表和字段
+
+CREATE TABLE IF NOT EXISTS `sys_refresh_tokens` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `token_hash` text,
+  `family_id` text,
+  `channel` text,
+  `user_id` integer,
+  `expires_at` datetime,
+  `used_at` datetime,
+  `revoked_at` datetime
+);
+CREATE INDEX `idx_sys_refresh_tokens_user_id` ON `sys_refresh_tokens`(`user_id`);
+CREATE INDEX `idx_sys_refresh_tokens_family_id` ON `sys_refresh_tokens`(`family_id`);
+CREATE UNIQUE INDEX `idx_sys_refresh_tokens_token_hash` ON `sys_refresh_tokens`(`token_hash`);
+CREATE INDEX `idx_sys_refresh_tokens_deleted_at` ON `sys_refresh_tokens`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `sys_user_totps` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `user_id` integer,
+  `secret` text,
+  `enabled` numeric,
+  `enabled_at` datetime,
+  `last_step` integer
+);
+CREATE UNIQUE INDEX `idx_sys_user_totps_user_id` ON `sys_user_totps`(`user_id`);
+CREATE INDEX `idx_sys_user_totps_deleted_at` ON `sys_user_totps`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `sys_user_recovery_codes` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `user_id` integer,
+  `code_hash` text,
+  `used_at` datetime
+);
+CREATE INDEX `idx_sys_user_recovery_codes_code_hash` ON `sys_user_recovery_codes`(`code_hash`);
+CREATE INDEX `idx_sys_user_recovery_codes_user_id` ON `sys_user_recovery_codes`(`user_id`);
+CREATE INDEX `idx_sys_user_recovery_codes_deleted_at` ON `sys_user_recovery_codes`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `sys_totp_challenges` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `token_hash` text,
+  `user_id` integer,
+  `expires_at` datetime,
+  `attempts` integer,
+  `used_at` datetime
+);
+CREATE INDEX `idx_sys_totp_challenges_user_id` ON `sys_totp_challenges`(`user_id`);
+CREATE UNIQUE INDEX `idx_sys_totp_challenges_token_hash` ON `sys_totp_challenges`(`token_hash`);
+CREATE INDEX `idx_sys_totp_challenges_deleted_at` ON `sys_totp_challenges`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `sys_password_resets` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `token_hash` text,
+  `user_id` integer,
+  `channel` text,
+  `request_ip` text,
+  `expires_at` datetime,
+  `used_at` datetime
+);
+CREATE INDEX `idx_sys_password_resets_user_id` ON `sys_password_resets`(`user_id`);
+CREATE UNIQUE INDEX `idx_sys_password_resets_token_hash` ON `sys_password_resets`(`token_hash`);
+CREATE INDEX `idx_sys_password_resets_deleted_at` ON `sys_password_resets`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `sys_recovery_challenges` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `token_hash` text,
+  `user_id` integer,
+  `problem_ids` text,
+  `expires_at` datetime,
+  `used_at` datetime
+);
+CREATE INDEX `idx_sys_recovery_challenges_user_id` ON `sys_recovery_challenges`(`user_id`);
+CREATE UNIQUE INDEX `idx_sys_recovery_challenges_token_hash` ON `sys_recovery_challenges`(`token_hash`);
+CREATE INDEX `idx_sys_recovery_challenges_deleted_at` ON `sys_recovery_challenges`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `sys_login_locks` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `kind` text,
+  `channel` text,
+  `subject` text,
+  `failures` integer,
+  `last_ip` text,
+  `locked_until` datetime
+);
+CREATE INDEX `idx_sys_login_locks_locked_until` ON `sys_login_locks`(`locked_until`);
+CREATE UNIQUE INDEX `idx_login_lock` ON `sys_login_locks`(`kind`,`channel`,`subject`);
+CREATE INDEX `idx_sys_login_locks_deleted_at` ON `sys_login_locks`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `sys_login_histories` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `channel` text,
+  `user_id` integer,
+  `username` text,
+  `ip` text,
+  `user_agent` text,
+  `success` numeric,
+  `reason` text
+);
+CREATE INDEX `idx_sys_login_histories_username` ON `sys_login_histories`(`username`);
+CREATE INDEX `idx_sys_login_histories_user_id` ON `sys_login_histories`(`user_id`);
+CREATE INDEX `idx_sys_login_histories_channel` ON `sys_login_histories`(`channel`);
+CREATE INDEX `idx_sys_login_histories_deleted_at` ON `sys_login_histories`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `sys_user_identities` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `user_id` integer,
+  `provider` text,
+  `subject` text,
+  `email` text,
+  `last_login_at` datetime
+);
+CREATE UNIQUE INDEX `idx_identity_subject` ON `sys_user_identities`(`provider`,`subject`);
+CREATE INDEX `idx_sys_user_identities_user_id` ON `sys_user_identities`(`user_id`);
+CREATE INDEX `idx_sys_user_identities_deleted_at` ON `sys_user_identities`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `sys_oidc_states` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `state_hash` text,
+  `provider` text,
+  `channel` text,
+  `nonce` text,
+  `code_verifier` text,
+  `link_user_id` integer,
+  `expires_at` datetime,
+  `used_at` datetime
+);
+CREATE UNIQUE INDEX `idx_sys_oidc_states_state_hash` ON `sys_oidc_states`(`state_hash`);
+CREATE INDEX `idx_sys_oidc_states_deleted_at` ON `sys_oidc_states`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `sys_api_keys` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `user_id` integer,
+  `name` text,
+  `key_hash` text,
+  `hint` text,
+  `scopes` text,
+  `expires_at` datetime,
+  `last_used_at` datetime,
+  `last_used_ip` text,
+  `revoked_at` datetime
+);
+CREATE UNIQUE INDEX `idx_sys_api_keys_key_hash` ON `sys_api_keys`(`key_hash`);
+CREATE INDEX `idx_sys_api_keys_user_id` ON `sys_api_keys`(`user_id`);
+CREATE INDEX `idx_sys_api_keys_deleted_at` ON `sys_api_keys`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `sys_sessions` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `jti` text,
+  `channel` text,
+  `user_id` integer,
+  `ip` text,
+  `user_agent` text,
+  `last_active_at` datetime,
+  `expires_at` datetime,
+  `revoked_at` datetime
+);
+CREATE INDEX `idx_session_user` ON `sys_sessions`(`channel`,`user_id`);
+CREATE UNIQUE INDEX `idx_sys_sessions_jti` ON `sys_sessions`(`jti`);
+CREATE INDEX `idx_sys_sessions_deleted_at` ON `sys_sessions`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `sys_audit_logs` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  `action` text,
+  `user_id` integer,
+  `authority_id` text,
+  `resource` text,
+  `record_ids` text,
+  `detail` text,
+  `method` text,
+  `path` text,
+  `ip` text
+);
+CREATE INDEX `idx_sys_audit_logs_resource` ON `sys_audit_logs`(`resource`);
+CREATE INDEX `idx_sys_audit_logs_user_id` ON `sys_audit_logs`(`user_id`);
+CREATE INDEX `idx_sys_audit_logs_action` ON `sys_audit_logs`(`action`);
+CREATE INDEX `idx_sys_audit_logs_deleted_at` ON `sys_audit_logs`(`deleted_at`);
+
+CREATE TABLE IF NOT EXISTS `sys_menu_btn_apis` (
+  `sys_base_menu_btn_id` integer,
+  `sys_api_id` integer,
+  PRIMARY KEY (`sys_base_menu_btn_id`,`sys_api_id`)
+);
+ALTER TABLE `jwt_blacklists` ADD COLUMN `jti` text;
+ALTER TABLE `jwt_blacklists` ADD COLUMN `expires_at` datetime;
+ALTER TABLE `exa_file_upload_and_downloads` ADD COLUMN `user_id` integer;
+ALTER TABLE `sys_users` ADD COLUMN `phone_bidx` text;
+ALTER TABLE `sys_users` ADD COLUMN `email_bidx` text;
+ALTER TABLE `exa_customers` ADD COLUMN `customer_phone_bidx` text;
+ALTER TABLE `mobile_users` ADD COLUMN `phone_bidx` text;
+ALTER TABLE `mobile_users` ADD COLUMN `realname_bidx` text;
+CREATE INDEX `idx_jwt_blacklists_jti` ON `jwt_blacklists`(`jti`);
+CREATE INDEX `idx_jwt_blacklists_expires_at` ON `jwt_blacklists`(`expires_at`);
+CREATE INDEX `idx_exa_file_upload_and_downloads_user_id` ON `exa_file_upload_and_downloads`(`user_id`);
+CREATE INDEX `idx_sys_users_phone_index` ON `sys_users`(`phone_bidx`);
+CREATE INDEX `idx_sys_users_email_index` ON `sys_users`(`email_bidx`);
+CREATE INDEX `idx_exa_customers_customer_phone_index` ON `exa_customers`(`customer_phone_bidx`);
+CREATE INDEX `idx_mobile_users_phone_index` ON `mobile_users`(`phone_bidx`);
+CREATE INDEX `idx_mobile_users_realname_index` ON `mobile_users`(`realname_bidx`);
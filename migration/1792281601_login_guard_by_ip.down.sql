@@ -0,0 +1,5 @@
+-- 同一账号在多个 ip 上的锁定无法放回原来的唯一索引, 回滚时清除账号锁定
+DELETE FROM sys_login_locks WHERE kind = 'account';
+DROP INDEX IF EXISTS idx_login_lock;
+CREATE UNIQUE INDEX idx_login_lock ON sys_login_locks (kind, channel, subject);
+UPDATE sys_login_histories SET user_id = 0 WHERE user_id IS NULL;
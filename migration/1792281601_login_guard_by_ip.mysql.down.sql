@@ -0,0 +1,6 @@
+-- 同一账号在多个 ip 上的锁定无法放回原来的唯一索引, 回滚时清除账号锁定
+DELETE FROM `sys_login_locks` WHERE `kind` = 'account';
+ALTER TABLE `sys_login_locks` DROP INDEX `idx_login_lock`, ADD UNIQUE INDEX `idx_login_lock` (`kind`,`channel`,`subject`);
+ALTER TABLE `sys_login_locks` MODIFY COLUMN `last_ip` varchar(64) COMMENT '最后一次失败的ip';
+UPDATE `sys_login_histories` SET `user_id` = 0 WHERE `user_id` IS NULL;
+ALTER TABLE `sys_login_histories` MODIFY COLUMN `user_id` bigint unsigned COMMENT '用户id, 账号不存在时为0';
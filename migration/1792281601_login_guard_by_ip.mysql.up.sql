@@ -0,0 +1,5 @@
+-- 账号锁定改为按用户名和 ip 区分, 登录失败的记录 user_id 为空, 不再写 0
+ALTER TABLE `sys_login_locks` DROP INDEX `idx_login_lock`, ADD UNIQUE INDEX `idx_login_lock` (`kind`,`channel`,`subject`,`last_ip`);
+ALTER TABLE `sys_login_locks` MODIFY COLUMN `last_ip` varchar(64) COMMENT '失败的ip, 账号锁定只对该ip生效';
+ALTER TABLE `sys_login_histories` MODIFY COLUMN `user_id` bigint unsigned NULL COMMENT '用户id, 登录失败时为空';
+UPDATE `sys_login_histories` SET `user_id` = NULL WHERE `user_id` = 0;
@@ -0,0 +1,4 @@
+-- 账号锁定改为按用户名和 ip 区分, 登录失败的记录 user_id 为空, 不再写 0
+DROP INDEX IF EXISTS idx_login_lock;
+CREATE UNIQUE INDEX idx_login_lock ON sys_login_locks (kind, channel, subject, last_ip);
+UPDATE sys_login_histories SET user_id = NULL WHERE user_id = 0;
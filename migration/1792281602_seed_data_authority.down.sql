@@ -0,0 +1,9 @@
+-- 删除只有本角色一条资源权限的记录, 即 up 补上的记录
+DELETE FROM sys_data_authority_id
+WHERE sys_authority_authority_id = data_authority_id_authority_id
+  AND sys_authority_authority_id IN (
+    SELECT authority_id FROM (
+      SELECT sys_authority_authority_id AS authority_id FROM sys_data_authority_id
+      GROUP BY sys_authority_authority_id HAVING COUNT(*) = 1
+    ) single
+  );
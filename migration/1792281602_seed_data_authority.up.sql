@@ -0,0 +1,5 @@
+-- 列表按资源权限过滤后, 没有资源权限的角色只能看到本人的数据; 给这些角色补上本角色的资源权限
+INSERT INTO sys_data_authority_id (sys_authority_authority_id, data_authority_id_authority_id)
+SELECT a.authority_id, a.authority_id FROM sys_authorities a
+WHERE a.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM sys_data_authority_id d WHERE d.sys_authority_authority_id = a.authority_id);
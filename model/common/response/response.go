@@ -2,6 +2,7 @@ package response
 
 import (
 	global "server/model"
+	"server/utils/mask"
 
 	"github.com/gofiber/fiber/v3"
 	"go.uber.org/zap"
@@ -24,6 +25,13 @@ const (
 
 // 底层的返回结果
 func Result(code int, data any, msg string, c fiber.Ctx) error {
+	// 列表数据按角色的规则脱敏, 详情接口用于编辑表单, 脱敏后提交会覆盖原值, 不在这里处理
+	if page, ok := data.(PageResult); ok {
+		if policy, ok := c.Locals(mask.LocalsKey).(mask.Policy); ok {
+			page.List = mask.Apply(page.List, policy)
+			data = page
+		}
+	}
 	// 返回的最终结果
 	return c.Status(code).JSON(Response{
 		code,
@@ -3,12 +3,16 @@ package example
 import (
 	global "server/model"
 	"server/model/system"
+	"server/utils/crypt"
+
+	"gorm.io/gorm"
 )
 
 type ExaCustomer struct {
 	global.MODEL
-	CustomerName       string         `json:"customerName" form:"customerName" gorm:"comment:客户名"`                // 客户名
-	CustomerPhoneData  string         `json:"customerPhoneData" form:"customerPhoneData" gorm:"comment:客户手机号"`    // 客户手机号
+	CustomerName       string         `json:"customerName" form:"customerName" gorm:"comment:客户名"`                                               // 客户名
+	CustomerPhoneData  string         `json:"customerPhoneData" form:"customerPhoneData" gorm:"serializer:encrypted;comment:客户手机号" mask:"phone"` // 客户手机号
+	CustomerPhoneIndex string         `json:"-" gorm:"column:customer_phone_bidx;size:64;index;comment:客户手机号盲索引"`
 	SysUserID          uint           `json:"sysUserId" form:"sysUserId" gorm:"comment:管理ID"`                     // 管理ID
 	SysUserAuthorityID string         `json:"sysUserAuthorityID" form:"sysUserAuthorityID" gorm:"comment:管理角色ID"` // 管理角色ID
 	SysUser            system.SysUser `json:"sysUser" form:"sysUser" gorm:"comment:管理详情"`                         // 管理详情
@@ -17,3 +21,9 @@ type ExaCustomer struct {
 func (ExaCustomer) TableName() string {
 	return "exa_customers"
 }
+
+// BeforeSave 同步客户手机号的盲索引
+func (e *ExaCustomer) BeforeSave(*gorm.DB) error {
+	e.CustomerPhoneIndex = crypt.BlindIndex(e.CustomerPhoneData)
+	return nil
+}
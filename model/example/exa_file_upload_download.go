@@ -14,6 +14,7 @@ type ExaFileUploadAndDownload struct {
 	Width      int     `json:"width" gorm:"comment:宽度"`                                                // 图片宽度
 	Proportion float64 `json:"proportion" gorm:"comment:长宽比例"`                                         // 图片长宽比例
 	IsCropper  int     `query:"is_cropper" json:"is_cropper" form:"is_cropper" gorm:"comment:是否为裁剪图片"` // 1 后台图片 2 后台截图 3 前台图片 4 mobile 图片
+	UserId     uint    `json:"user_id" gorm:"comment:上传用户ID;index"`                                    // 上传用户ID, 用于资源权限过滤
 }
 
 func (ExaFileUploadAndDownload) TableName() string {
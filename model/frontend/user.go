@@ -1,19 +1,26 @@
 package frontend
 
-import global "server/model"
+import (
+	global "server/model"
+	"server/utils/crypt"
+
+	"gorm.io/gorm"
+)
 
 type User struct {
 	global.MODEL
-	Username     string `json:"userName" gorm:"comment:用户登录名"`                         // 用户登录名
-	Password     string `json:"-"  gorm:"comment:用户登录密码"`                              // 用户登录密码
-	NickName     string `json:"nickName" gorm:"default:系统用户;comment:用户昵称"`             // 用户昵称
-	SideMode     string `json:"sideMode" gorm:"default:dark;comment:用户侧边主题"`           // 用户侧边主题
-	HeaderImg    string `json:"headerImg" gorm:"default:public/logo.png;comment:用户头像"` // 用户头像
-	BaseColor    string `json:"baseColor" gorm:"default:#fff;comment:基础颜色"`            // 基础颜色
-	ActiveColor  string `json:"activeColor" gorm:"default:#1890ff;comment:活跃颜色"`       // 活跃颜色
-	AuthorityId  string `json:"authorityId" gorm:"default:888;comment:用户角色ID"`         // 用户角色ID
-	Phone        string `json:"phone"  gorm:"comment:用户手机号"`                           // 用户手机号
-	Email        string `json:"email"  gorm:"comment:用户邮箱"`                            // 用户邮箱
+	Username     string `json:"userName" gorm:"comment:用户登录名"`                                 // 用户登录名
+	Password     string `json:"-"  gorm:"comment:用户登录密码"`                                      // 用户登录密码
+	NickName     string `json:"nickName" gorm:"default:系统用户;comment:用户昵称"`                     // 用户昵称
+	SideMode     string `json:"sideMode" gorm:"default:dark;comment:用户侧边主题"`                   // 用户侧边主题
+	HeaderImg    string `json:"headerImg" gorm:"default:public/logo.png;comment:用户头像"`         // 用户头像
+	BaseColor    string `json:"baseColor" gorm:"default:#fff;comment:基础颜色"`                    // 基础颜色
+	ActiveColor  string `json:"activeColor" gorm:"default:#1890ff;comment:活跃颜色"`               // 活跃颜色
+	AuthorityId  string `json:"authorityId" gorm:"default:888;comment:用户角色ID"`                 // 用户角色ID
+	Phone        string `json:"phone"  gorm:"serializer:encrypted;comment:用户手机号" mask:"phone"` // 用户手机号
+	Email        string `json:"email"  gorm:"serializer:encrypted;comment:用户邮箱" mask:"email"`  // 用户邮箱
+	PhoneIndex   string `json:"-" gorm:"column:phone_bidx;size:64;index;comment:手机号盲索引"`
+	EmailIndex   string `json:"-" gorm:"column:email_bidx;size:64;index;comment:邮箱盲索引"`
 	HeadImg      string `query:"" json:"head_img" gorm:"comment:背景图"`
 	Introduction string `json:"introduction" gorm:"comment:简介"`
 	Content      string `json:"content" gorm:"comment:介绍"`
@@ -37,6 +44,13 @@ func (User) TableName() string {
 	return "sys_users"
 }
 
+// BeforeSave 与 system.SysUser 共用 sys_users 表, 同样同步手机号和邮箱的盲索引
+func (u *User) BeforeSave(*gorm.DB) error {
+	u.PhoneIndex = crypt.BlindIndex(u.Phone)
+	u.EmailIndex = crypt.BlindIndex(u.Email)
+	return nil
+}
+
 func (ArticleUser) TableName() string {
 	return "sys_users"
 }
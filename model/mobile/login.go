@@ -4,4 +4,7 @@ type Login struct {
 	Username string `json:"username" form:"username" gorm:"column:username;comment:用户名;size:50;"`
 	Password string `json:"password" form:"password" gorm:"column:password;comment:密码;size:100;"`
 	Realname string `json:"realname" form:"realname" gorm:"column:realname;comment:真实姓名;size:50;"`
+	// 连续登录失败后需要验证码
+	Captcha   string `json:"captcha,omitempty" form:"captcha" gorm:"-"`
+	CaptchaId string `json:"captchaId,omitempty" form:"captchaId" gorm:"-"`
 }
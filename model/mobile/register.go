@@ -1,15 +1,30 @@
 package mobile
 
-import global "server/model"
+import (
+	global "server/model"
+	"server/utils/crypt"
+
+	"gorm.io/gorm"
+)
 
 type Register struct {
 	global.MODEL
 	Username string `json:"username" form:"username" gorm:"column:username;comment:用户名;size:50;"`
-	Realname string `json:"realname" form:"realname" gorm:"column:realname;comment:真实姓名;size:50;"`
+	Realname string `json:"realname" form:"realname" gorm:"column:realname;serializer:encrypted;comment:真实姓名;size:255;"`
 	Password string `json:"password" form:"password" gorm:"column:password;comment:密码;size:100;"`
-	Phone    string `json:"phone" form:"phone" gorm:"column:phone;comment:电话;"`
+	Phone    string `json:"phone" form:"phone" gorm:"column:phone;serializer:encrypted;comment:电话;size:255;"`
+
+	PhoneIndex    string `json:"-" gorm:"column:phone_bidx;size:64;index;comment:电话盲索引;"`
+	RealnameIndex string `json:"-" gorm:"column:realname_bidx;size:64;index;comment:真实姓名盲索引;"`
 }
 
 func (Register) TableName() string {
 	return "mobile_users"
 }
+
+// BeforeSave 与 MobileUser 相同, 同步电话和真实姓名的盲索引
+func (r *Register) BeforeSave(*gorm.DB) error {
+	r.PhoneIndex = crypt.BlindIndex(r.Phone)
+	r.RealnameIndex = crypt.BlindIndex(r.Realname)
+	return nil
+}
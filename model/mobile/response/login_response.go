@@ -1,6 +1,9 @@
 package response
 
 type LoginResponse struct {
-	Token     string `json:"token"`
-	ExpiresAt int64  `json:"expiresAt"`
+	UserId           uint   `json:"userId"`
+	Token            string `json:"token"`
+	ExpiresAt        int64  `json:"expiresAt"`
+	RefreshToken     string `json:"refreshToken"`     // 刷新令牌, token 过期后用于换新
+	RefreshExpiresAt int64  `json:"refreshExpiresAt"` // 刷新令牌过期时间
 }
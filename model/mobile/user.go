@@ -2,6 +2,9 @@ package mobile
 
 import (
 	global "server/model"
+	"server/utils/crypt"
+
+	"gorm.io/gorm"
 )
 
 // MobileUser 结构体
@@ -10,7 +13,7 @@ type MobileUser struct {
 	global.MODEL
 	Username string `json:"username" form:"username" gorm:"column:username;comment:用户名;size:50;"`
 	Nickname string `json:"nickname" form:"nickname" gorm:"column:nickname;comment:昵称;size:50;"`
-	Realname string `json:"realname" form:"realname" gorm:"column:realname;comment:真实姓名;size:50;"`
+	Realname string `json:"realname" form:"realname" gorm:"column:realname;serializer:encrypted;comment:真实姓名;size:255;" mask:"name"`
 	Avatar   string `json:"avatar" form:"avatar" gorm:"column:avatar;comment:头像;size:255;"`
 	Sign     string `json:"sign" form:"sign" gorm:"column:sign;comment:简介;size:255;"`
 	Cover    string `json:"cover" form:"cover" gorm:"column:cover;comment:主页封面;size:255;"`
@@ -18,10 +21,20 @@ type MobileUser struct {
 	// Password string `json:"password" form:"password" gorm:"column:password;comment:密码;size:100;"`
 	Industry uint8  `json:"industry" form:"industry" gorm:"column:industry;comment:行业;"`
 	Gender   uint8  `json:"gender" form:"gender" gorm:"column:gender;comment:性别;"`
-	Phone    string `json:"phone" form:"phone" gorm:"column:phone;comment:电话;"`
+	Phone    string `json:"phone" form:"phone" gorm:"column:phone;serializer:encrypted;comment:电话;size:255;" mask:"phone"`
+
+	PhoneIndex    string `json:"-" gorm:"column:phone_bidx;size:64;index;comment:电话盲索引;"`
+	RealnameIndex string `json:"-" gorm:"column:realname_bidx;size:64;index;comment:真实姓名盲索引;"`
 }
 
 // TableName MobileUser 表名
 func (MobileUser) TableName() string {
 	return "mobile_users"
 }
+
+// BeforeSave 同步电话和真实姓名的盲索引
+func (u *MobileUser) BeforeSave(*gorm.DB) error {
+	u.PhoneIndex = crypt.BlindIndex(u.Phone)
+	u.RealnameIndex = crypt.BlindIndex(u.Realname)
+	return nil
+}
@@ -0,0 +1,19 @@
+package request
+
+import (
+	"server/model/common/request"
+	"server/model/system"
+)
+
+// CreateApiKey 创建 API Key, ExpireDays 为 0 时不过期, Scopes 为空时可以访问所属用户有权限的全部接口
+type CreateApiKey struct {
+	Name       string               `json:"name" form:"name"`
+	ExpireDays int                  `json:"expireDays" form:"expireDays"`
+	Scopes     []system.ApiKeyScope `json:"scopes" form:"scopes"`
+}
+
+// SysApiKeySearch 管理员查询 API Key
+type SysApiKeySearch struct {
+	UserId uint `json:"userId" query:"userId" form:"userId"`
+	request.PageInfo
+}
@@ -0,0 +1,11 @@
+package request
+
+import "server/model/common/request"
+
+// SysAuditLogSearch 审计记录查询条件
+type SysAuditLogSearch struct {
+	Action   string `json:"action" form:"action"`     // 审计动作
+	Resource string `json:"resource" form:"resource"` // 操作对象
+	UserId   uint   `json:"userId" form:"userId"`     // 操作用户id
+	request.PageInfo
+}
@@ -5,3 +5,9 @@ type SysAuthorityBtnReq struct {
 	AuthorityId string `json:"authorityId" form:"authorityId"`
 	Selected    []uint `json:"selected" form:"selected"`
 }
+
+// SysBtnApisReq 按钮绑定的接口
+type SysBtnApisReq struct {
+	BtnID  uint   `json:"btnID" query:"btnID"`
+	ApiIds []uint `json:"apiIds"`
+}
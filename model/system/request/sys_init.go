@@ -13,6 +13,7 @@ type InitDB struct {
 	UserName string `json:"userName"` // 数据库用户名
 	Password string `json:"password"` // 数据库密码
 	DBName   string `json:"dbName"`   // 数据库名
+	DBPath   string `json:"dbPath"`   // sqlite 数据库文件目录
 }
 
 // MysqlEmptyDsn msyql 空数据库 建库链接
@@ -70,3 +71,15 @@ func (i *InitDB) ToPgsqlConfig() config.Pgsql {
 		Config:       "sslmode=disable TimeZone=Asia/Shanghai",
 	}
 }
+
+// ToSqliteConfig 转换 config.Sqlite
+func (i *InitDB) ToSqliteConfig() config.Sqlite {
+	return config.Sqlite{
+		Path:         i.DBPath,
+		Dbname:       i.DBName,
+		MaxIdleConns: 1,
+		MaxOpenConns: 1,
+		LogMode:      "error",
+		Config:       "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)",
+	}
+}
@@ -0,0 +1,20 @@
+package request
+
+import "server/model/common/request"
+
+// SysLoginLockSearch 锁定列表查询条件
+type SysLoginLockSearch struct {
+	Kind    string `json:"kind" form:"kind"`       // account/ip
+	Channel string `json:"channel" form:"channel"` // 所属端
+	Subject string `json:"subject" form:"subject"` // 用户名或ip
+	request.PageInfo
+}
+
+// SysLoginHistorySearch 登录记录查询条件
+type SysLoginHistorySearch struct {
+	Channel  string `json:"channel" form:"channel"`   // 所属端
+	Username string `json:"username" form:"username"` // 用户名
+	Ip       string `json:"ip" form:"ip"`             // 请求ip
+	Success  *bool  `json:"success" form:"success"`   // 是否成功, 不传时查询全部
+	request.PageInfo
+}
@@ -0,0 +1,6 @@
+package request
+
+// MigrationSteps 迁移步数, up 时 0 表示执行全部未执行的迁移, down 时 0 按 1 处理
+type MigrationSteps struct {
+	Steps int `json:"steps" query:"steps"`
+}
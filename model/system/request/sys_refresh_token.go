@@ -0,0 +1,17 @@
+package request
+
+// RefreshToken 刷新令牌, 换新时使用
+type RefreshToken struct {
+	RefreshToken string `json:"refreshToken" form:"refreshToken"`
+}
+
+// Logout 退出登录, 默认只作废当前登录, All 为 true 时退出该端全部设备
+type Logout struct {
+	RefreshToken string `json:"refreshToken" form:"refreshToken"` // 需要一起作废的刷新令牌
+	All          bool   `json:"all" form:"all"`                   // 退出该端全部设备
+}
+
+// RevokeSessions 退出全部设备, ExceptCurrent 为 true 时保留当前会话
+type RevokeSessions struct {
+	ExceptCurrent bool `json:"exceptCurrent" form:"exceptCurrent"`
+}
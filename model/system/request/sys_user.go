@@ -27,8 +27,10 @@ type Login struct {
 
 // get token login struct
 type LoginToken struct {
-	Username string `json:"username" form:"username" binding:"required"`
-	Password string `json:"password" form:"password" binding:"required"`
+	Username  string `json:"username" form:"username" binding:"required"`
+	Password  string `json:"password" form:"password" binding:"required"`
+	Captcha   string `json:"captcha" form:"captcha"`     // 连续失败后需要验证码
+	CaptchaId string `json:"captchaId" form:"captchaId"` // 验证码ID
 }
 
 // Modify password structure
@@ -63,3 +65,37 @@ type SearchInfo struct {
 	request.PageInfo
 	Username string `json:"username" form:"username"`
 }
+
+// ForgotPassword 找回密码
+type ForgotPassword struct {
+	Email string `json:"email" form:"email"`
+}
+
+// ConfirmPasswordReset 通过邮件中的令牌设置新密码
+type ConfirmPasswordReset struct {
+	Token    string `json:"token" form:"token"`
+	Password string `json:"password" form:"password"`
+}
+
+// RecoveryQuestions 通过安全问题找回账号
+type RecoveryQuestions struct {
+	Username string `json:"username" form:"username"`
+}
+
+// RecoveryAnswer 单个问题的回答
+type RecoveryAnswer struct {
+	ID     uint   `json:"id" form:"id"`
+	Answer string `json:"answer" form:"answer"`
+}
+
+// RecoveryAnswers 回答本次抽取的全部问题
+type RecoveryAnswers struct {
+	Challenge string           `json:"challenge" form:"challenge"`
+	Answers   []RecoveryAnswer `json:"answers" form:"answers"`
+}
+
+// OidcCallback 身份提供方回调页面拿到的 code 和 state
+type OidcCallback struct {
+	Code  string `json:"code" form:"code"`
+	State string `json:"state" form:"state"`
+}
@@ -0,0 +1,12 @@
+package request
+
+// TotpCode 验证码, 也可以填写恢复码
+type TotpCode struct {
+	Code string `json:"code" form:"code"`
+}
+
+// TotpChallenge 第二步登录, challenge 为密码校验通过后返回的凭证
+type TotpChallenge struct {
+	Challenge string `json:"challenge" form:"challenge"`
+	Code      string `json:"code" form:"code"`
+}
@@ -9,3 +9,15 @@ type SysAPIResponse struct {
 type SysAPIListResponse struct {
 	Apis []system.SysApi `json:"apis"`
 }
+
+// SysApiSyncResponse 已注册路由与 sys_apis 的差异
+type SysApiSyncResponse struct {
+	NewApis    []system.SysApi    `json:"newApis"`    // 已注册但未录入的接口
+	DeleteApis []system.SysApi    `json:"deleteApis"` // 已录入但路由不存在的接口
+	MethodApis []SysApiMethodDiff `json:"methodApis"` // 同一路径方法变化的接口
+}
+
+type SysApiMethodDiff struct {
+	Api    system.SysApi `json:"api"`    // 原记录
+	Method string        `json:"method"` // 路由中的方法
+}
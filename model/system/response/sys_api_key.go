@@ -0,0 +1,9 @@
+package response
+
+import "server/model/system"
+
+// ApiKeyCreateResponse 新建的 API Key, Key 只在此时返回一次
+type ApiKeyCreateResponse struct {
+	Key    string           `json:"key"`
+	ApiKey system.SysApiKey `json:"apiKey"`
+}
@@ -7,3 +7,24 @@ import (
 type PolicyPathResponse struct {
 	Paths []request.CasbinInfo `json:"paths"`
 }
+
+// EffectivePolicy 角色实际拥有的 api 权限, AuthorityId 为授予该权限的角色
+type EffectivePolicy struct {
+	Path        string `json:"path"`
+	Method      string `json:"method"`
+	AuthorityId string `json:"authorityId"`
+	Inherited   bool   `json:"inherited"` // 是否继承自父角色
+}
+
+// AuthorityTreeSyncResponse 按角色树同步 g 规则前的差异
+type AuthorityTreeSyncResponse struct {
+	Add    []AuthorityInherit `json:"add"`    // 同步后新增的继承关系
+	Remove []AuthorityInherit `json:"remove"` // 同步后删除的继承关系
+}
+
+// AuthorityInherit Apis 为子角色因这条继承关系获得(或失去)的权限
+type AuthorityInherit struct {
+	AuthorityId string               `json:"authorityId"`
+	ParentId    string               `json:"parentId"`
+	Apis        []request.CasbinInfo `json:"apis"`
+}
@@ -0,0 +1,17 @@
+package response
+
+import "time"
+
+// MigrationStatus 单个迁移文件的执行状态
+type MigrationStatus struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"appliedAt"`
+	HasDown   bool       `json:"hasDown"` // 是否存在 down 文件
+}
+
+// MigrationResult 本次执行的迁移版本
+type MigrationResult struct {
+	Versions []int64 `json:"versions"`
+}
@@ -0,0 +1,9 @@
+package response
+
+// SysOperationRecordStats 操作记录异步写入的计数, 自启动以来累计
+type SysOperationRecordStats struct {
+	Queued  int   `json:"queued"`  // 队列中等待写入的条数
+	Written int64 `json:"written"` // 已写入
+	Dropped int64 `json:"dropped"` // 队列已满丢弃
+	Failed  int64 `json:"failed"`  // 写入数据库失败
+}
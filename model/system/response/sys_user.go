@@ -9,7 +9,15 @@ type SysUserResponse struct {
 }
 
 type LoginResponse struct {
-	User      system.SysUser `json:"user"`
-	Token     string         `json:"token"`
-	ExpiresAt int64          `json:"expiresAt"`
+	User             system.SysUser `json:"user"`
+	Token            string         `json:"token"`
+	ExpiresAt        int64          `json:"expiresAt"`
+	RefreshToken     string         `json:"refreshToken"`            // 刷新令牌, token 过期后用于换新
+	RefreshExpiresAt int64          `json:"refreshExpiresAt"`        // 刷新令牌过期时间
+	RecoveryCodes    []string       `json:"recoveryCodes,omitempty"` // 登录时完成两步验证绑定才会返回, 只显示这一次
+}
+
+// OidcAuthorizeResponse 跳转到身份提供方的授权地址
+type OidcAuthorizeResponse struct {
+	URL string `json:"url"`
 }
@@ -21,3 +21,22 @@ type SysUserProblem struct {
 type SysUserProblemResponse struct {
 	Problems []SysUserProblem `json:"problems"`
 }
+
+// RecoveryQuestion 找回账号时展示的问题, 不含答案
+type RecoveryQuestion struct {
+	ID      uint   `json:"id"`
+	Problem string `json:"problem"`
+}
+
+// RecoveryQuestionsResponse 本次随机抽取的问题
+type RecoveryQuestionsResponse struct {
+	Challenge string             `json:"challenge"`
+	ExpiresAt int64              `json:"expiresAt"`
+	Questions []RecoveryQuestion `json:"questions"`
+}
+
+// RecoveryTokenResponse 答对后签发的一次性重置令牌, 用于 base/confirmPasswordReset
+type RecoveryTokenResponse struct {
+	Token     string `json:"token"`
+	ExpiresAt int64  `json:"expiresAt"`
+}
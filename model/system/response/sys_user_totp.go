@@ -0,0 +1,22 @@
+package response
+
+// TotpChallengeResponse 需要两步验证时登录接口返回的内容, 不含 token
+type TotpChallengeResponse struct {
+	NeedTotp  bool   `json:"needTotp"`
+	Enrolled  bool   `json:"enrolled"` // 为 false 时需要先调用 base/totp/enroll 绑定验证器
+	Challenge string `json:"challenge"`
+	ExpiresAt int64  `json:"expiresAt"`
+}
+
+// TotpSetupResponse 待绑定的验证器密钥, uri 用于生成二维码
+type TotpSetupResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// TotpStatusResponse 当前用户的两步验证状态
+type TotpStatusResponse struct {
+	Enabled       bool  `json:"enabled"`
+	Required      bool  `json:"required"`
+	RecoveryCodes int64 `json:"recoveryCodes"` // 剩余可用恢复码数量
+}
@@ -0,0 +1,35 @@
+package system
+
+import (
+	"time"
+
+	global "server/model"
+)
+
+// ApiKeyPrefix API Key 的固定前缀, 用于和 JWT 区分
+const ApiKeyPrefix = "sk_"
+
+// ApiKeyScope API Key 允许访问的接口, 路径与 sys_apis 一致, 支持 :id 形式的参数
+type ApiKeyScope struct {
+	Path   string `json:"path"`
+	Method string `json:"method"`
+}
+
+// SysApiKey 后台用户的 API Key, 只保存 sha256 摘要, 明文只在创建时返回一次
+// 使用 API Key 访问时按所属用户的角色鉴权, Scopes 不为空时只能访问其中的接口
+type SysApiKey struct {
+	global.MODEL
+	UserId     uint          `json:"userId" gorm:"index;comment:用户id"`
+	Name       string        `json:"name" gorm:"size:64;comment:名称"`
+	KeyHash    string        `json:"-" gorm:"size:64;uniqueIndex;comment:密钥摘要"`
+	Hint       string        `json:"hint" gorm:"size:16;comment:密钥前几位, 用于辨认"`
+	Scopes     []ApiKeyScope `json:"scopes" gorm:"type:text;serializer:json;comment:允许访问的接口"`
+	ExpiresAt  *time.Time    `json:"expiresAt" gorm:"comment:过期时间, 为空不过期"`
+	LastUsedAt *time.Time    `json:"lastUsedAt" gorm:"comment:最后使用时间"`
+	LastUsedIp string        `json:"lastUsedIp" gorm:"size:64;comment:最后使用ip"`
+	RevokedAt  *time.Time    `json:"revokedAt" gorm:"comment:作废时间"`
+}
+
+func (SysApiKey) TableName() string {
+	return "sys_api_keys"
+}
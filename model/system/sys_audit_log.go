@@ -0,0 +1,27 @@
+package system
+
+import global "server/model"
+
+// 审计动作
+const (
+	AuditOwnershipOverride = "ownership_override" // 使用越权权限修改他人的记录
+	AuditFieldReveal       = "field_reveal"       // 查看未脱敏的敏感字段
+)
+
+// SysAuditLog 敏感操作审计记录
+type SysAuditLog struct {
+	global.MODEL
+	Action      string `json:"action" form:"action" gorm:"size:64;index;comment:审计动作"`
+	UserId      uint   `json:"userId" form:"userId" gorm:"index;comment:操作用户id"`
+	AuthorityId string `json:"authorityId" form:"authorityId" gorm:"size:90;comment:操作用户角色"`
+	Resource    string `json:"resource" form:"resource" gorm:"size:191;index;comment:操作对象, 一般为表名"`
+	RecordIds   string `json:"recordIds" gorm:"size:255;comment:操作的记录id, 多个以逗号分隔"`
+	Detail      string `json:"detail" gorm:"type:text;comment:补充信息"`
+	Method      string `json:"method" gorm:"size:16;comment:请求方法"`
+	Path        string `json:"path" gorm:"size:255;comment:请求路径"`
+	Ip          string `json:"ip" gorm:"size:64;comment:请求ip"`
+}
+
+func (SysAuditLog) TableName() string {
+	return "sys_audit_logs"
+}
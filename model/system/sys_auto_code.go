@@ -15,6 +15,7 @@ type AutoCodeStruct struct {
 	Description        string   `json:"description"`        // Struct中文名称
 	AutoCreateApiToSql bool     `json:"autoCreateApiToSql"` // 是否自动创建api
 	AutoMoveFile       bool     `json:"autoMoveFile"`       // 是否自动移动文件
+	DataAuthority      bool     `json:"dataAuthority"`      // 列表是否按资源权限过滤, 开启后自动增加 created_by 字段
 	Fields             []*Field `json:"fields"`
 	DictTypes          []string `json:"-"`
 	Package            string   `json:"package"`
@@ -1,10 +1,15 @@
 package system
 
 import (
+	"time"
+
 	global "server/model"
 )
 
+// JwtBlacklist 按 jti 拉黑, 同一会话刷新后签发的 token 一起失效, 过期后由定时任务清理
 type JwtBlacklist struct {
 	global.MODEL
-	Jwt string `gorm:"type:text;comment:jwt"`
+	Jwt       string    `gorm:"type:text;comment:jwt"`
+	Jti       string    `gorm:"size:64;index;comment:令牌id, 旧令牌没有jti时为jwt的sha256"`
+	ExpiresAt time.Time `gorm:"index;comment:令牌过期时间"`
 }
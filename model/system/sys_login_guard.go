@@ -0,0 +1,44 @@
+package system
+
+import (
+	"time"
+
+	global "server/model"
+)
+
+// 登录锁定对象
+const (
+	LoginLockAccount = "account"
+	LoginLockIp      = "ip"
+)
+
+// SysLoginLock 连续登录失败后的临时锁定, 账号锁定按端、用户名和 ip 区分, 其他 ip 仍可登录; ip 锁定对所有端生效
+type SysLoginLock struct {
+	global.MODEL
+	Kind        string    `json:"kind" form:"kind" gorm:"size:20;uniqueIndex:idx_login_lock;comment:锁定对象 account/ip"`
+	Channel     string    `json:"channel" form:"channel" gorm:"size:20;uniqueIndex:idx_login_lock;comment:所属端, ip锁定为空"`
+	Subject     string    `json:"subject" form:"subject" gorm:"size:191;uniqueIndex:idx_login_lock;comment:用户名或ip"`
+	Failures    int64     `json:"failures" gorm:"comment:锁定时的失败次数"`
+	LastIp      string    `json:"lastIp" gorm:"size:64;uniqueIndex:idx_login_lock;comment:失败的ip, 账号锁定只对该ip生效"`
+	LockedUntil time.Time `json:"lockedUntil" gorm:"index;comment:锁定到期时间"`
+}
+
+func (SysLoginLock) TableName() string {
+	return "sys_login_locks"
+}
+
+// SysLoginHistory 登录记录, 成功和失败都会记录
+type SysLoginHistory struct {
+	global.MODEL
+	Channel   string `json:"channel" form:"channel" gorm:"size:20;index;comment:所属端"`
+	UserId    *uint  `json:"userId" form:"userId" gorm:"index;comment:用户id, 登录失败时为空"`
+	Username  string `json:"username" form:"username" gorm:"size:191;index;comment:登录时填写的用户名"`
+	Ip        string `json:"ip" form:"ip" gorm:"size:64;comment:请求ip"`
+	UserAgent string `json:"userAgent" gorm:"size:255;comment:客户端"`
+	Success   bool   `json:"success" form:"success" gorm:"comment:是否成功"`
+	Reason    string `json:"reason" gorm:"size:64;comment:失败原因"`
+}
+
+func (SysLoginHistory) TableName() string {
+	return "sys_login_histories"
+}
@@ -4,7 +4,8 @@ import global "server/model"
 
 type SysBaseMenuBtn struct {
 	global.MODEL
-	Name          string `json:"name" gorm:"comment:按钮关键key"`
-	Desc          string `json:"desc" gorm:"按钮备注"`
-	SysBaseMenuID uint   `json:"sysBaseMenuID" gorm:"comment:菜单ID"`
+	Name          string   `json:"name" gorm:"comment:按钮关键key"`
+	Desc          string   `json:"desc" gorm:"按钮备注"`
+	SysBaseMenuID uint     `json:"sysBaseMenuID" gorm:"comment:菜单ID"`
+	Apis          []SysApi `json:"apis,omitempty" gorm:"many2many:sys_menu_btn_apis;"` // 按钮对应的接口, 调用这些接口时要求角色拥有该按钮
 }
@@ -0,0 +1,14 @@
+package system
+
+import "time"
+
+// SysMigration 已执行的 sql 迁移记录, 每个版本一行
+type SysMigration struct {
+	Version   int64     `json:"version" gorm:"primarykey;autoIncrement:false;comment:迁移版本"` // 迁移版本(文件名前缀)
+	Name      string    `json:"name" gorm:"size:191;comment:迁移名称"`                          // 迁移名称
+	AppliedAt time.Time `json:"appliedAt" gorm:"comment:执行时间"`                              // 执行时间
+}
+
+func (SysMigration) TableName() string {
+	return "sys_migrations"
+}
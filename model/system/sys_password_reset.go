@@ -0,0 +1,25 @@
+package system
+
+import (
+	"time"
+
+	global "server/model"
+)
+
+// 通过安全问题找回时签发的重置令牌, 其余端沿用刷新令牌的端名
+const PasswordResetChannelQuestion = "question"
+
+// SysPasswordReset 找回密码令牌, 只保存 sha256 摘要, 使用一次后失效
+type SysPasswordReset struct {
+	global.MODEL
+	TokenHash string     `json:"-" gorm:"size:64;uniqueIndex;comment:令牌摘要"`
+	UserId    uint       `json:"userId" gorm:"index;comment:用户id"`
+	Channel   string     `json:"channel" gorm:"size:20;comment:发起端"`
+	RequestIp string     `json:"requestIp" gorm:"size:64;comment:请求ip"`
+	ExpiresAt time.Time  `json:"expiresAt" gorm:"comment:过期时间"`
+	UsedAt    *time.Time `json:"usedAt" gorm:"comment:使用时间"`
+}
+
+func (SysPasswordReset) TableName() string {
+	return "sys_password_resets"
+}
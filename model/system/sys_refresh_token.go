@@ -0,0 +1,31 @@
+package system
+
+import (
+	"time"
+
+	global "server/model"
+)
+
+// 刷新令牌所属端
+const (
+	RefreshChannelBackend  = "backend"
+	RefreshChannelFrontend = "frontend"
+	RefreshChannelMobile   = "mobile"
+)
+
+// SysRefreshToken 服务端保存的刷新令牌, 只保存 sha256 摘要
+// 同一次登录轮换出的令牌共享 FamilyId, 发现旧令牌被重复使用时整个 family 作废
+type SysRefreshToken struct {
+	global.MODEL
+	TokenHash string     `json:"-" gorm:"size:64;uniqueIndex;comment:令牌摘要"`
+	FamilyId  string     `json:"familyId" gorm:"size:36;index;comment:令牌族"`
+	Channel   string     `json:"channel" gorm:"size:20;comment:所属端"`
+	UserId    uint       `json:"userId" gorm:"index;comment:用户id"`
+	ExpiresAt time.Time  `json:"expiresAt" gorm:"comment:过期时间"`
+	UsedAt    *time.Time `json:"usedAt" gorm:"comment:轮换时间"`
+	RevokedAt *time.Time `json:"revokedAt" gorm:"comment:作废时间"`
+}
+
+func (SysRefreshToken) TableName() string {
+	return "sys_refresh_tokens"
+}
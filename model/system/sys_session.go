@@ -0,0 +1,26 @@
+package system
+
+import (
+	"time"
+
+	global "server/model"
+)
+
+// SysSession 一次登录的会话, Jti 与刷新令牌族 FamilyId 相同, 刷新后签发的访问令牌沿用同一个 jti
+// 作废会话时同时作废刷新令牌族并把 jti 加入黑名单
+type SysSession struct {
+	global.MODEL
+	Jti          string     `json:"-" gorm:"size:36;uniqueIndex;comment:令牌id"`
+	Channel      string     `json:"channel" gorm:"size:20;index:idx_session_user;comment:所属端"`
+	UserId       uint       `json:"userId" gorm:"index:idx_session_user;comment:用户id"`
+	Ip           string     `json:"ip" gorm:"size:64;comment:最后使用ip"`
+	UserAgent    string     `json:"userAgent" gorm:"size:512;comment:客户端"`
+	LastActiveAt time.Time  `json:"lastActiveAt" gorm:"comment:最后活动时间"`
+	ExpiresAt    time.Time  `json:"expiresAt" gorm:"comment:过期时间"`
+	RevokedAt    *time.Time `json:"revokedAt" gorm:"comment:作废时间"`
+	Current      bool       `json:"current" gorm:"-"` // 是否为发起请求的会话
+}
+
+func (SysSession) TableName() string {
+	return "sys_sessions"
+}
@@ -2,8 +2,10 @@ package system
 
 import (
 	global "server/model"
+	"server/utils/crypt"
 
 	uuid "github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type SysUser struct {
@@ -19,8 +21,10 @@ type SysUser struct {
 	AuthorityId  string         `json:"authorityId" gorm:"default:888;comment:用户角色ID"`         // 用户角色ID
 	Authority    SysAuthority   `json:"authority" gorm:"foreignKey:AuthorityId;references:AuthorityId;comment:用户角色"`
 	Authorities  []SysAuthority `json:"authorities" gorm:"many2many:sys_user_authority;"`
-	Phone        string         `json:"phone"  gorm:"comment:用户手机号"` // 用户手机号
-	Email        string         `json:"email"  gorm:"comment:用户邮箱"`  // 用户邮箱
+	Phone        string         `json:"phone"  gorm:"serializer:encrypted;comment:用户手机号" mask:"phone"` // 用户手机号
+	Email        string         `json:"email"  gorm:"serializer:encrypted;comment:用户邮箱" mask:"email"`  // 用户邮箱
+	PhoneIndex   string         `json:"-" gorm:"column:phone_bidx;size:64;index;comment:手机号盲索引"`
+	EmailIndex   string         `json:"-" gorm:"column:email_bidx;size:64;index;comment:邮箱盲索引"`
 	HeadImg      string         `query:"head_img" json:"head_img" gorm:"comment:背景图"`
 	Introduction string         `json:"introduction" gorm:"comment:简介"`
 	Content      string         `json:"content" gorm:"comment:介绍"`
@@ -29,3 +33,10 @@ type SysUser struct {
 func (SysUser) TableName() string {
 	return "sys_users"
 }
+
+// BeforeSave 同步手机号和邮箱的盲索引, 加密后按 phone_bidx/email_bidx 精确查询
+func (u *SysUser) BeforeSave(*gorm.DB) error {
+	u.PhoneIndex = crypt.BlindIndex(u.Phone)
+	u.EmailIndex = crypt.BlindIndex(u.Email)
+	return nil
+}
@@ -0,0 +1,38 @@
+package system
+
+import (
+	"time"
+
+	global "server/model"
+)
+
+// SysUserIdentity 用户关联的外部身份, 同一身份提供方的 sub 只能关联一个用户
+type SysUserIdentity struct {
+	global.MODEL
+	UserId      uint       `json:"userId" gorm:"index;comment:用户id"`
+	Provider    string     `json:"provider" gorm:"size:64;uniqueIndex:idx_identity_subject;comment:身份提供方"`
+	Subject     string     `json:"subject" gorm:"size:191;uniqueIndex:idx_identity_subject;comment:外部用户标识 sub"`
+	Email       string     `json:"email" gorm:"size:191;comment:关联时的邮箱"`
+	LastLoginAt *time.Time `json:"lastLoginAt" gorm:"comment:最后一次登录时间"`
+}
+
+func (SysUserIdentity) TableName() string {
+	return "sys_user_identities"
+}
+
+// SysOidcState 发起 OIDC 登录时保存的 state, 回调时校验并取回 nonce 和 PKCE code_verifier, 只能使用一次
+type SysOidcState struct {
+	global.MODEL
+	StateHash    string     `json:"-" gorm:"size:64;uniqueIndex;comment:state 摘要"`
+	Provider     string     `json:"provider" gorm:"size:64;comment:身份提供方"`
+	Channel      string     `json:"channel" gorm:"size:20;comment:发起端 backend/frontend"`
+	Nonce        string     `json:"-" gorm:"size:64;comment:nonce"`
+	CodeVerifier string     `json:"-" gorm:"size:128;comment:PKCE code_verifier"`
+	LinkUserId   uint       `json:"linkUserId" gorm:"comment:不为0时回调只关联到该用户, 不登录"`
+	ExpiresAt    time.Time  `json:"expiresAt" gorm:"comment:过期时间"`
+	UsedAt       *time.Time `json:"usedAt" gorm:"comment:使用时间"`
+}
+
+func (SysOidcState) TableName() string {
+	return "sys_oidc_states"
+}
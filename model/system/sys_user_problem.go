@@ -1,6 +1,8 @@
 package system
 
 import (
+	"time"
+
 	global "server/model"
 )
 
@@ -8,10 +10,24 @@ type SysUserProblem struct {
 	global.MODEL
 	SysUserId int    `query:"sys_user_id" json:"sys_user_id" form:"sys_user_id" gorm:"column:sys_user_id;comment:用户的ID"`
 	Problem   string `json:"problem" form:"problem" gorm:"column:problem;comment:问题"`
-	Answer    string `json:"answer" form:"answer" gorm:"column:answer;comment:答案"`
+	Answer    string `json:"answer" form:"answer" gorm:"column:answer;comment:答案"` // 保存规范化后的答案哈希
 }
 
 // TableName Comment 表名
 func (SysUserProblem) TableName() string {
 	return "sys_user_problems"
 }
+
+// SysRecoveryChallenge 安全问题找回时本次随机抽取的问题, 作答一次后失效
+type SysRecoveryChallenge struct {
+	global.MODEL
+	TokenHash  string     `json:"-" gorm:"size:64;uniqueIndex;comment:凭证摘要"`
+	UserId     uint       `json:"userId" gorm:"index;comment:用户id"`
+	ProblemIds string     `json:"problemIds" gorm:"size:255;comment:提问的问题id, 逗号分隔"`
+	ExpiresAt  time.Time  `json:"expiresAt" gorm:"comment:过期时间"`
+	UsedAt     *time.Time `json:"usedAt" gorm:"comment:作答时间"`
+}
+
+func (SysRecoveryChallenge) TableName() string {
+	return "sys_recovery_challenges"
+}
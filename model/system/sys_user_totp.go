@@ -0,0 +1,47 @@
+package system
+
+import (
+	"time"
+
+	global "server/model"
+)
+
+// SysUserTotp 后台用户的 TOTP 验证器, Enabled 为 false 时表示已生成密钥但尚未确认绑定
+type SysUserTotp struct {
+	global.MODEL
+	UserId    uint       `json:"userId" gorm:"uniqueIndex;comment:用户id"`
+	Secret    string     `json:"-" gorm:"size:64;comment:TOTP密钥"`
+	Enabled   bool       `json:"enabled" gorm:"comment:是否已开启"`
+	EnabledAt *time.Time `json:"enabledAt" gorm:"comment:开启时间"`
+	LastStep  int64      `json:"-" gorm:"comment:最后一次使用的时间步, 防止验证码重放"`
+}
+
+func (SysUserTotp) TableName() string {
+	return "sys_user_totps"
+}
+
+// SysUserRecoveryCode 一次性恢复码, 只保存 sha256 摘要
+type SysUserRecoveryCode struct {
+	global.MODEL
+	UserId   uint       `json:"userId" gorm:"index;comment:用户id"`
+	CodeHash string     `json:"-" gorm:"size:64;index;comment:恢复码摘要"`
+	UsedAt   *time.Time `json:"usedAt" gorm:"comment:使用时间"`
+}
+
+func (SysUserRecoveryCode) TableName() string {
+	return "sys_user_recovery_codes"
+}
+
+// SysTotpChallenge 密码校验通过后签发的第二步登录凭证, 只保存 sha256 摘要
+type SysTotpChallenge struct {
+	global.MODEL
+	TokenHash string     `json:"-" gorm:"size:64;uniqueIndex;comment:凭证摘要"`
+	UserId    uint       `json:"userId" gorm:"index;comment:用户id"`
+	ExpiresAt time.Time  `json:"expiresAt" gorm:"comment:过期时间"`
+	Attempts  int        `json:"attempts" gorm:"comment:验证失败次数"`
+	UsedAt    *time.Time `json:"usedAt" gorm:"comment:使用时间"`
+}
+
+func (SysTotpChallenge) TableName() string {
+	return "sys_totp_challenges"
+}
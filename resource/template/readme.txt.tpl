@@ -1,6 +1,10 @@
 代码解压后把fe的api文件内容粘贴进前端api文件夹下并修改为自己想要的名字即可
 
 后端代码解压后同理，放到自己想要的 mvc对应路径 并且到 initRouter中注册自动生成的路由 到registerTable中注册自动生成的model
+{{- if .DataAuthority}}
+
+已开启资源权限过滤: 创建时 created_by 写入当前用户id, 列表只返回本人及资源权限内角色下用户创建的数据
+{{- end}}
 
 
 希望大家给个star多多鼓励
@@ -0,0 +1,166 @@
+package {{.Package}}
+
+import (
+	"strconv"
+
+	"server/model/common/request"
+	"server/model/common/response"
+	"server/model/{{.Package}}"
+	{{.Package}}Req "server/model/{{.Package}}/request"
+	{{.Package}}Service "server/service/{{.Package}}"
+{{- if .DataAuthority}}
+	systemService "server/service/system"
+	"server/utils"
+{{- end}}
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type {{.StructName}}Api struct{}
+
+var {{.Abbreviation}}Service = new({{.Package}}Service.{{.StructName}}Service)
+
+// Create{{.StructName}} 创建{{.Description}}
+// @Tags {{.StructName}}
+// @Summary 创建{{.Description}}
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body {{.Package}}.{{.StructName}} true "创建{{.Description}}"
+// @Success 200 {object} response.Response{msg=string} "创建成功"
+// @Router /{{.Abbreviation}}/create{{.StructName}} [post]
+func ({{.Abbreviation}}Api *{{.StructName}}Api) Create{{.StructName}}(c fiber.Ctx) error {
+	var {{.Abbreviation}} {{.Package}}.{{.StructName}}
+	if err := c.Bind().Body(&{{.Abbreviation}}); err != nil {
+		return response.FailWithMessage("获取数据失败", 3, err, c)
+	}
+{{- if .DataAuthority}}
+	// 创建人取当前用户, 列表按该列过滤资源权限
+	var err error
+	if {{.Abbreviation}}.CreatedBy, err = utils.GetUserID(c); err != nil {
+		return response.FailWithMessage(err.Error(), 3, err, c)
+	}
+{{- end}}
+	if err := {{.Abbreviation}}Service.Create{{.StructName}}(&{{.Abbreviation}}); err != nil {
+		return response.FailWithMessage("创建失败", 3, err, c)
+	}
+	return response.OkWithId("创建成功", {{.Abbreviation}}.ID, c)
+}
+
+// Delete{{.StructName}} 删除{{.Description}}
+// @Tags {{.StructName}}
+// @Summary 删除{{.Description}}
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body {{.Package}}.{{.StructName}} true "删除{{.Description}}"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /{{.Abbreviation}}/delete{{.StructName}} [delete]
+func ({{.Abbreviation}}Api *{{.StructName}}Api) Delete{{.StructName}}(c fiber.Ctx) error {
+	var {{.Abbreviation}} {{.Package}}.{{.StructName}}
+	if err := c.Bind().Body(&{{.Abbreviation}}); err != nil {
+		return response.FailWithMessage("获取数据失败", 3, err, c)
+	}
+	if {{.Abbreviation}}.ID == 0 {
+		return response.FailWithMessage("id传递错误", 3, nil, c)
+	}
+	if err := {{.Abbreviation}}Service.Delete{{.StructName}}({{.Abbreviation}}); err != nil {
+		return response.FailWithMessage("删除失败", 3, err, c)
+	}
+	return response.OkWithMessage("删除成功", c)
+}
+
+// Delete{{.StructName}}ByIds 批量删除{{.Description}}
+// @Tags {{.StructName}}
+// @Summary 批量删除{{.Description}}
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.IdsReq true "批量删除{{.Description}}"
+// @Success 200 {object} response.Response{msg=string} "批量删除成功"
+// @Router /{{.Abbreviation}}/delete{{.StructName}}ByIds [delete]
+func ({{.Abbreviation}}Api *{{.StructName}}Api) Delete{{.StructName}}ByIds(c fiber.Ctx) error {
+	var ids request.IdsReq
+	if err := c.Bind().Body(&ids); err != nil {
+		return response.FailWithMessage("获取数据失败", 3, err, c)
+	}
+	if err := {{.Abbreviation}}Service.Delete{{.StructName}}ByIds(ids); err != nil {
+		return response.FailWithMessage("批量删除失败", 3, err, c)
+	}
+	return response.OkWithMessage("批量删除成功", c)
+}
+
+// Update{{.StructName}} 更新{{.Description}}
+// @Tags {{.StructName}}
+// @Summary 更新{{.Description}}
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body {{.Package}}.{{.StructName}} true "更新{{.Description}}"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /{{.Abbreviation}}/update{{.StructName}} [put]
+func ({{.Abbreviation}}Api *{{.StructName}}Api) Update{{.StructName}}(c fiber.Ctx) error {
+	var {{.Abbreviation}} {{.Package}}.{{.StructName}}
+	if err := c.Bind().Body(&{{.Abbreviation}}); err != nil {
+		return response.FailWithMessage("获取数据失败", 3, err, c)
+	}
+	if {{.Abbreviation}}.ID == 0 {
+		return response.FailWithMessage("id传递错误", 3, nil, c)
+	}
+	if err := {{.Abbreviation}}Service.Update{{.StructName}}({{.Abbreviation}}); err != nil {
+		return response.FailWithMessage("更新失败", 3, err, c)
+	}
+	return response.OkWithMessage("更新成功", c)
+}
+
+// Find{{.StructName}} 用id查询{{.Description}}
+// @Tags {{.StructName}}
+// @Summary 用id查询{{.Description}}
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param ID query uint true "主键ID"
+// @Success 200 {object} response.Response{data=object,msg=string} "查询成功"
+// @Router /{{.Abbreviation}}/find{{.StructName}} [get]
+func ({{.Abbreviation}}Api *{{.StructName}}Api) Find{{.StructName}}(c fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Query("ID"))
+	if err != nil || id <= 0 {
+		return response.FailWithMessage("id传递错误", 3, err, c)
+	}
+	re{{.Abbreviation}}, err := {{.Abbreviation}}Service.Get{{.StructName}}(uint(id))
+	if err != nil {
+		return response.FailWithMessage("查询失败", 3, err, c)
+	}
+	return response.OkWithData(fiber.Map{"re{{.Abbreviation}}": re{{.Abbreviation}}}, c)
+}
+
+// Get{{.StructName}}List 分页获取{{.Description}}列表
+// @Tags {{.StructName}}
+// @Summary 分页获取{{.Description}}列表
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query {{.Package}}Req.{{.StructName}}Search true "分页获取{{.Description}}列表"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /{{.Abbreviation}}/get{{.StructName}}List [get]
+func ({{.Abbreviation}}Api *{{.StructName}}Api) Get{{.StructName}}List(c fiber.Ctx) error {
+	var pageInfo {{.Package}}Req.{{.StructName}}Search
+	if err := c.Bind().Query(&pageInfo); err != nil {
+		return response.FailWithMessage("获取数据失败", 3, err, c)
+	}
+{{- if .DataAuthority}}
+	// 只返回本人及资源权限内角色下用户创建的数据
+	list, total, err := {{.Abbreviation}}Service.Get{{.StructName}}InfoList(pageInfo, systemService.AuthorityServiceApp.DataScope(utils.GetUserInfo(c), "created_by"))
+{{- else}}
+	list, total, err := {{.Abbreviation}}Service.Get{{.StructName}}InfoList(pageInfo)
+{{- end}}
+	if err != nil {
+		return response.FailWithMessage("获取失败", 3, err, c)
+	}
+	return response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
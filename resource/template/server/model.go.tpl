@@ -0,0 +1,25 @@
+// 自动生成模板{{.StructName}}
+package {{.Package}}
+
+import (
+	global "server/model"
+{{- $hasTime := false}}{{range .Fields}}{{if eq .FieldType "time.Time"}}{{$hasTime = true}}{{end}}{{end}}
+{{- if $hasTime}}
+	"time"
+{{- end}}
+)
+
+// {{.StructName}} {{.Description}}
+type {{.StructName}} struct {
+	global.MODEL
+{{- range .Fields}}
+	{{.FieldName}} {{.FieldType}} `json:"{{.FieldJson}}" form:"{{.FieldJson}}" gorm:"column:{{.ColumnName}};comment:{{.Comment}};{{if .DataTypeLong}}size:{{.DataTypeLong}};{{end}}{{if eq .ColumnName "created_by"}}index;{{end}}"`
+{{- end}}
+}
+{{- if .TableName}}
+
+// TableName {{.StructName}} 表名
+func ({{.StructName}}) TableName() string {
+	return "{{.TableName}}"
+}
+{{- end}}
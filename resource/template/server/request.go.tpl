@@ -0,0 +1,12 @@
+package request
+
+import (
+	"server/model/common/request"
+	"server/model/{{.Package}}"
+)
+
+// {{.StructName}}Search {{.Description}}列表的查询条件
+type {{.StructName}}Search struct {
+	{{.Package}}.{{.StructName}}
+	request.PageInfo
+}
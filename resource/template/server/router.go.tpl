@@ -0,0 +1,26 @@
+package {{.Package}}
+
+import (
+	v1 "server/api/v1/{{.Package}}"
+	"server/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type {{.StructName}}Router struct{}
+
+// Init{{.StructName}}Router 初始化{{.Description}}路由
+func (s *{{.StructName}}Router) Init{{.StructName}}Router(Router fiber.Router) {
+	{{.Abbreviation}}Router := Router.Group("{{.Abbreviation}}")
+	{{.Abbreviation}}Api := new(v1.{{.StructName}}Api)
+	{
+		{{.Abbreviation}}Router.Post("create{{.StructName}}", middleware.OperationRecord, {{.Abbreviation}}Api.Create{{.StructName}}) // 新建{{.Description}}
+		{{.Abbreviation}}Router.Delete("delete{{.StructName}}", middleware.OperationRecord, {{.Abbreviation}}Api.Delete{{.StructName}}) // 删除{{.Description}}
+		{{.Abbreviation}}Router.Delete("delete{{.StructName}}ByIds", middleware.OperationRecord, {{.Abbreviation}}Api.Delete{{.StructName}}ByIds) // 批量删除{{.Description}}
+		{{.Abbreviation}}Router.Put("update{{.StructName}}", middleware.OperationRecord, {{.Abbreviation}}Api.Update{{.StructName}}) // 更新{{.Description}}
+	}
+	{
+		{{.Abbreviation}}Router.Get("find{{.StructName}}", {{.Abbreviation}}Api.Find{{.StructName}}) // 根据ID获取{{.Description}}
+		{{.Abbreviation}}Router.Get("get{{.StructName}}List", {{.Abbreviation}}Api.Get{{.StructName}}List) // 获取{{.Description}}列表
+	}
+}
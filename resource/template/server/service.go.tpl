@@ -0,0 +1,102 @@
+package {{.Package}}
+
+import (
+	global "server/model"
+	"server/model/common/request"
+	"server/model/{{.Package}}"
+	{{.Package}}Req "server/model/{{.Package}}/request"
+{{- if .DataAuthority}}
+
+	"gorm.io/gorm"
+{{- end}}
+)
+
+type {{.StructName}}Service struct{}
+
+//@function: Create{{.StructName}}
+//@description: 创建{{.Description}}
+//@param: {{.Abbreviation}} *{{.Package}}.{{.StructName}}
+//@return: err error
+
+func ({{.Abbreviation}}Service *{{.StructName}}Service) Create{{.StructName}}({{.Abbreviation}} *{{.Package}}.{{.StructName}}) (err error) {
+	err = global.DB.Create({{.Abbreviation}}).Error
+	return err
+}
+
+//@function: Delete{{.StructName}}
+//@description: 删除{{.Description}}
+//@param: {{.Abbreviation}} {{.Package}}.{{.StructName}}
+//@return: err error
+
+func ({{.Abbreviation}}Service *{{.StructName}}Service) Delete{{.StructName}}({{.Abbreviation}} {{.Package}}.{{.StructName}}) (err error) {
+	err = global.DB.Delete(&{{.Abbreviation}}).Error
+	return err
+}
+
+//@function: Delete{{.StructName}}ByIds
+//@description: 批量删除{{.Description}}
+//@param: ids request.IdsReq
+//@return: err error
+
+func ({{.Abbreviation}}Service *{{.StructName}}Service) Delete{{.StructName}}ByIds(ids request.IdsReq) (err error) {
+	err = global.DB.Delete(&[]{{.Package}}.{{.StructName}}{}, "id in ?", ids.Ids).Error
+	return err
+}
+
+//@function: Update{{.StructName}}
+//@description: 更新{{.Description}}
+//@param: {{.Abbreviation}} {{.Package}}.{{.StructName}}
+//@return: err error
+
+func ({{.Abbreviation}}Service *{{.StructName}}Service) Update{{.StructName}}({{.Abbreviation}} {{.Package}}.{{.StructName}}) (err error) {
+{{- if .DataAuthority}}
+	// 创建人只在创建时写入
+	err = global.DB.Omit("created_by", "created_at").Save(&{{.Abbreviation}}).Error
+{{- else}}
+	err = global.DB.Save(&{{.Abbreviation}}).Error
+{{- end}}
+	return err
+}
+
+//@function: Get{{.StructName}}
+//@description: 根据id获取{{.Description}}
+//@param: id uint
+//@return: {{.Abbreviation}} {{.Package}}.{{.StructName}}, err error
+
+func ({{.Abbreviation}}Service *{{.StructName}}Service) Get{{.StructName}}(id uint) ({{.Abbreviation}} {{.Package}}.{{.StructName}}, err error) {
+	err = global.DB.Where("id = ?", id).First(&{{.Abbreviation}}).Error
+	return
+}
+
+//@function: Get{{.StructName}}InfoList
+//@description: 分页获取{{.Description}}列表{{if .DataAuthority}}, scopes 用于按资源权限过滤{{end}}
+//@param: info {{.Package}}Req.{{.StructName}}Search{{if .DataAuthority}}, scopes ...func(*gorm.DB) *gorm.DB{{end}}
+//@return: list []{{.Package}}.{{.StructName}}, total int64, err error
+
+func ({{.Abbreviation}}Service *{{.StructName}}Service) Get{{.StructName}}InfoList(info {{.Package}}Req.{{.StructName}}Search{{if .DataAuthority}}, scopes ...func(*gorm.DB) *gorm.DB{{end}}) (list []{{.Package}}.{{.StructName}}, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.DB.Model(&{{.Package}}.{{.StructName}}{}){{if .DataAuthority}}.Scopes(scopes...){{end}}
+{{- range .Fields}}
+{{- if .FieldSearchType}}
+{{- if eq .FieldType "string"}}
+	if info.{{.FieldName}} != "" {
+		db = db.Where("{{.ColumnName}} {{.FieldSearchType}} ?", {{if eq .FieldSearchType "LIKE"}}"%"+info.{{.FieldName}}+"%"{{else}}info.{{.FieldName}}{{end}})
+	}
+{{- else if eq .FieldType "time.Time"}}
+	if !info.{{.FieldName}}.IsZero() {
+		db = db.Where("{{.ColumnName}} {{.FieldSearchType}} ?", info.{{.FieldName}})
+	}
+{{- else if ne .FieldType "bool"}}
+	if info.{{.FieldName}} != 0 {
+		db = db.Where("{{.ColumnName}} {{.FieldSearchType}} ?", info.{{.FieldName}})
+	}
+{{- end}}
+{{- end}}
+{{- end}}
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Order("id desc").Limit(limit).Offset(offset).Find(&list).Error
+	return list, total, err
+}
@@ -3,6 +3,7 @@ package app
 import (
 	v1 "server/api/v1/app"
 	"server/middleware"
+	"server/model/app"
 
 	"github.com/gofiber/fiber/v3"
 )
@@ -13,12 +14,13 @@ type ArticleRouter struct{}
 func (s *ArticleRouter) InitArticleRouter(Router fiber.Router) {
 	articleApi := new(v1.ArticleApi)
 	articleRouter := Router.Group("article")
+	articleOwner := middleware.Ownership(app.Article{}, "user_id") // 只有作者或拥有越权权限的角色可以修改
 
-	articleRouter.Post("createArticle", middleware.OperationRecord, articleApi.CreateArticle).Name("createArticle") // 新建article
-	articleRouter.Delete("deleteArticle/:id", middleware.OperationRecord, articleApi.DeleteArticle)                 // 删除article
-	articleRouter.Delete("deleteArticleByIds", middleware.OperationRecord, articleApi.DeleteArticleByIds)           // 批量删除article
-	articleRouter.Put("updateArticle/:id", middleware.OperationRecord, articleApi.UpdateArticle)                    // 更新article
-	articleRouter.Put("PutArticleByIds", middleware.OperationRecord, articleApi.PutArticleByIds)                    // 批量更新 是否首页显示article
+	articleRouter.Post("createArticle", middleware.OperationRecord, articleApi.CreateArticle).Name("createArticle")     // 新建article
+	articleRouter.Delete("deleteArticle/:id", middleware.OperationRecord, articleOwner, articleApi.DeleteArticle)       // 删除article
+	articleRouter.Delete("deleteArticleByIds", middleware.OperationRecord, articleOwner, articleApi.DeleteArticleByIds) // 批量删除article
+	articleRouter.Put("updateArticle/:id", middleware.OperationRecord, articleOwner, articleApi.UpdateArticle)          // 更新article
+	articleRouter.Put("PutArticleByIds", middleware.OperationRecord, articleApi.PutArticleByIds)                        // 批量更新 是否首页显示article
 
 	articleRouter.Get("findArticle/:id", articleApi.FindArticle)         // 根据ID获取article
 	articleRouter.Get("getArticleList", articleApi.GetArticleList)       // 获取article列表
@@ -10,6 +10,7 @@ package app
 import (
 	v1 "server/api/v1/app"
 	"server/middleware"
+	"server/model/app"
 
 	"github.com/gofiber/fiber/v3"
 )
@@ -23,7 +24,7 @@ func (r *BaseMessageRouter) InitBaseMessageRouter(c fiber.Router) {
 	var uploadFileApi = new(v1.FileUploadAndDownloadApi)
 
 	baseMessageRouter.Post("createBaseMessage", middleware.OperationRecord, baseMessageApi.CreateBaseMessage)
-	baseMessageRouter.Put("updateBaseMessage", middleware.OperationRecord, baseMessageApi.UpdateBaseMessage)
+	baseMessageRouter.Put("updateBaseMessage/:id", middleware.OperationRecord, middleware.Ownership(app.BaseMessage{}, "user_id"), baseMessageApi.UpdateBaseMessage)
 	baseMessageRouter.Post("upload_file", middleware.OperationRecord, uploadFileApi.UploadFile)
 
 	baseMessageRouter.Get("getBaseMessage/:id", baseMessageApi.FindBaseMessage)
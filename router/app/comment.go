@@ -3,6 +3,7 @@ package app
 import (
 	v1 "server/api/v1/app"
 	"server/middleware"
+	"server/model/app"
 
 	"github.com/gofiber/fiber/v3"
 )
@@ -14,11 +15,12 @@ type CommentRouter struct {
 func (s *CommentRouter) InitCommentRouter(Router fiber.Router) {
 	commentRouter := Router.Group("comment")
 	var commentApi = new(v1.CommentApi)
+	commentOwner := middleware.Ownership(app.Comment{}, "user_id") // 只有评论人或拥有越权权限的角色可以修改
 
 	commentRouter.Post("createComment", middleware.OperationRecord, commentApi.CreateComment)             // 新建Comment
-	commentRouter.Delete("DeleteComment/:id", middleware.OperationRecord, commentApi.DeleteComment)       // 删除Comment
-	commentRouter.Delete("DeleteCommentByIds", middleware.OperationRecord, commentApi.DeleteCommentByIds) // 批量删除Comment
-	commentRouter.Put("updateComment/:id", middleware.OperationRecord, commentApi.UpdateComment) // 更新Comment
+	commentRouter.Delete("DeleteComment/:id", middleware.OperationRecord, commentOwner, commentApi.DeleteComment)       // 删除Comment
+	commentRouter.Delete("DeleteCommentByIds", middleware.OperationRecord, commentOwner, commentApi.DeleteCommentByIds) // 批量删除Comment
+	commentRouter.Put("updateComment/:id", middleware.OperationRecord, commentOwner, commentApi.UpdateComment)          // 更新Comment
 
 	commentRouter.Post(":id/like", commentApi.LikeComment) // 点赞评论
 	commentRouter.Delete(":id/like", commentApi.UnlikeComment)          // 取消点赞评论
@@ -26,20 +26,33 @@ func (s *FrontendRouter) InitFrontendRouter(Router fiber.Router) {
 	frontendCommentApi := new(v1.CommentApi)
 	{
 		frontend.Get("getArticleComment/:articleId", frontendCommentApi.GetCommentByArticleId)
-		frontend.Post("createdComment", middleware.JWTAuth, middleware.OperationRecord, frontendCommentApi.CreatedComment)
-		frontend.Post("comment/:id/like", middleware.JWTAuth, frontendCommentApi.LikeComment)
-		frontend.Delete("comment/:id/like", middleware.JWTAuth, frontendCommentApi.UnlikeComment)
-		frontend.Get("comment/:id/like", middleware.JWTAuth, frontendCommentApi.CheckCommentLiked)
+		frontend.Post("createdComment", middleware.JWTAuthFrontend, middleware.OperationRecord, frontendCommentApi.CreatedComment)
+		frontend.Post("comment/:id/like", middleware.JWTAuthFrontend, frontendCommentApi.LikeComment)
+		frontend.Delete("comment/:id/like", middleware.JWTAuthFrontend, frontendCommentApi.UnlikeComment)
+		frontend.Get("comment/:id/like", middleware.JWTAuthFrontend, frontendCommentApi.CheckCommentLiked)
 	}
 	frontendUserApi := new(v1.User)
 	{
-		frontend.Get("getImages", middleware.JWTAuth, frontendUserApi.GetImages)
+		frontend.Get("getImages", middleware.JWTAuthFrontend, frontendUserApi.GetImages)
 		frontend.Post("login", frontendUserApi.Login)
-		frontend.Get("getCurrentUser", middleware.JWTAuth, frontendUserApi.GetCurrent)
-		frontend.Put("updateBackgroundImage", middleware.JWTAuth, middleware.OperationRecord, frontendUserApi.UpdateUserBackgroudImage)
-		frontend.Put("resetPassword", middleware.JWTAuth, middleware.OperationRecord, frontendUserApi.UpdatePassword)
-		frontend.Post("register", middleware.JWTAuth, frontendUserApi.RegisterUser)
-		frontend.Put("updateUser", middleware.JWTAuth, middleware.OperationRecord, frontendUserApi.UpdateUser)
+		frontend.Post("refresh", frontendUserApi.RefreshToken)
+		frontend.Post("logout", middleware.JWTAuthFrontend, frontendUserApi.Logout)
+		frontend.Post("forgotPassword", frontendUserApi.ForgotPassword)
+		frontend.Post("confirmPasswordReset", frontendUserApi.ConfirmPasswordReset)
+		frontend.Get("oidc/providers", frontendUserApi.OidcProviders)
+		frontend.Get("oidc/:provider/authorize", frontendUserApi.OidcAuthorize)
+		frontend.Post("oidc/callback", frontendUserApi.OidcCallback)
+		frontend.Post("oidc/:provider/link", middleware.JWTAuthFrontend, middleware.OperationRecord, frontendUserApi.LinkOidc)
+		frontend.Get("oidc/identities", middleware.JWTAuthFrontend, frontendUserApi.GetOidcIdentities)
+		frontend.Post("oidc/unlink", middleware.JWTAuthFrontend, middleware.OperationRecord, frontendUserApi.UnlinkOidc)
+		frontend.Get("sessions", middleware.JWTAuthFrontend, frontendUserApi.GetSessions)
+		frontend.Post("sessions/revoke", middleware.JWTAuthFrontend, middleware.OperationRecord, frontendUserApi.RevokeSession)
+		frontend.Post("sessions/revokeAll", middleware.JWTAuthFrontend, middleware.OperationRecord, frontendUserApi.RevokeAllSessions)
+		frontend.Get("getCurrentUser", middleware.JWTAuthFrontend, frontendUserApi.GetCurrent)
+		frontend.Put("updateBackgroundImage", middleware.JWTAuthFrontend, middleware.OperationRecord, frontendUserApi.UpdateUserBackgroudImage)
+		frontend.Put("resetPassword", middleware.JWTAuthFrontend, middleware.OperationRecord, frontendUserApi.UpdatePassword)
+		frontend.Post("register", middleware.JWTAuthFrontend, frontendUserApi.RegisterUser)
+		frontend.Put("updateUser", middleware.JWTAuthFrontend, middleware.OperationRecord, frontendUserApi.UpdateUser)
 	}
 	frontendUploadApi := new(fileUpload.FileUploadAndDownloadApi)
 	{
@@ -17,12 +17,17 @@ func (m *MobileLoginRouter) InitMobileLoginRouter(Router fiber.Router) {
 	{
 		mobileLoginRouter.Post("login", mobileLoginApi.Login)
 		mobileLoginRouter.Post("register", registerApi.Register)
+		mobileLoginRouter.Post("refresh", mobileLoginApi.RefreshToken)
 	}
 	mobileGetUserApi := mobileLoginRouter.Use(middleware.JWTAuthMobileMiddleware())
 	{
 		mobileGetUserApi.Get("getUserInfo", mobileLoginApi.GetUserInfo)
 		mobileGetUserApi.Put("updateUser", mobileLoginApi.UpdateMobileUser)
 		mobileGetUserApi.Put("updatePassword", mobileLoginApi.UpdatePassword)
+		mobileGetUserApi.Post("logout", mobileLoginApi.Logout)
+		mobileGetUserApi.Get("sessions", mobileLoginApi.GetSessions)
+		mobileGetUserApi.Post("sessions/revoke", mobileLoginApi.RevokeSession)
+		mobileGetUserApi.Post("sessions/revokeAll", mobileLoginApi.RevokeAllSessions)
 	}
 	exaFileUploadAndDownloadApi := new(fileUpload.FileUploadAndDownloadApi)
 	{
@@ -56,13 +56,20 @@ type SystemRouter struct {
 	system.ProblemRouter
 	system.SysRouter
 	system.UserRouter
+	system.MigrationRouter
+	system.TotpRouter
+	system.LoginGuardRouter
+	system.OidcRouter
+	system.ApiKeyRouter
+	system.SessionRouter
+	system.AuditLogRouter
 }
 
 // 为了向后兼容，保留全局变量
 var (
 	AppRouterInstance      = &AppRouter{}
-	ExampleRouterInstance = &ExampleRouter{}
+	ExampleRouterInstance  = &ExampleRouter{}
 	FrontendRouterInstance = &FrontendRouter{}
-	MobileRouterInstance  = &MobileRouter{}
-	SystemRouterInstance  = &SystemRouter{}
+	MobileRouterInstance   = &MobileRouter{}
+	SystemRouterInstance   = &SystemRouter{}
 )
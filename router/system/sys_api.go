@@ -17,9 +17,11 @@ func (s *ApiRouter) InitApiRouter(Router fiber.Router) {
 	apiRouter.Delete("DeleteApi/:id", middleware.OperationRecord, apiRouterApi.DeleteApi)         // 删除Api
 	apiRouter.Put("updateApi/:id", middleware.OperationRecord, apiRouterApi.UpdateApi)            // 更新api
 	apiRouter.Delete("DeleteApisByIds", middleware.OperationRecord, apiRouterApi.DeleteApisByIds) // 删除选中api
+	apiRouter.Post("enterSyncApi", middleware.OperationRecord, apiRouterApi.EnterSyncApi)         // 同步已注册的路由
 
 	apiRouter.Get("getApiById/:id", apiRouterApi.GetApiById) // 获取单条Api消息
 	apiRouter.Get("getAllApis", apiRouterApi.GetAllApis)     // 获取所有api
 	apiRouter.Get("getApiList", apiRouterApi.GetApiList)     // 获取Api列表
+	apiRouter.Get("syncApi", apiRouterApi.SyncApi)           // 对比已注册的路由
 
 }
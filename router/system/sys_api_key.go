@@ -0,0 +1,22 @@
+package system
+
+import (
+	v1 "server/api/v1/system"
+	"server/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type ApiKeyRouter struct{}
+
+func (s *ApiKeyRouter) InitApiKeyRouter(Router fiber.Router) {
+	apiKeyRouter := Router.Group("apiKey")
+	apiKeyApi := new(v1.ApiKeyApi)
+
+	apiKeyRouter.Post("create", middleware.OperationRecord, apiKeyApi.CreateApiKey)         // 创建 API Key
+	apiKeyRouter.Post("revoke", middleware.OperationRecord, apiKeyApi.RevokeApiKey)         // 作废自己的 API Key
+	apiKeyRouter.Post("revokeById", middleware.OperationRecord, apiKeyApi.RevokeApiKeyById) // 管理员作废 API Key
+
+	apiKeyRouter.Get("getList", apiKeyApi.GetApiKeyList)       // 获取自己的 API Key
+	apiKeyRouter.Get("getAllList", apiKeyApi.GetAllApiKeyList) // 管理员获取全部 API Key
+}
@@ -0,0 +1,16 @@
+package system
+
+import (
+	v1 "server/api/v1/system"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type AuditLogRouter struct{}
+
+func (s *AuditLogRouter) InitAuditLogRouter(Router fiber.Router) {
+	auditLogRouter := Router.Group("auditLog")
+	auditLogApi := new(v1.AuditLogApi)
+
+	auditLogRouter.Get("getAuditLogList", auditLogApi.GetAuditLogList) // 获取审计记录
+}
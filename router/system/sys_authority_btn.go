@@ -15,7 +15,9 @@ func (s *AuthorityBtnRouter) InitAuthorityBtnRouterRouter(Router fiber.Router) {
 
 	authorityRouter.Post("setAuthorityBtn", middleware.OperationRecord, authorityBtnApi.SetAuthorityBtn)
 	authorityRouter.Delete("canRemoveAuthorityBtn/:id", middleware.OperationRecord, authorityBtnApi.CanRemoveAuthorityBtn)
+	authorityRouter.Post("setBtnApis", middleware.OperationRecord, authorityBtnApi.SetBtnApis) // 按钮绑定接口
 
 	authorityRouter.Get("getAuthorityBtn", authorityBtnApi.GetAuthorityBtn)
+	authorityRouter.Get("getBtnApis", authorityBtnApi.GetBtnApis)
 
 }
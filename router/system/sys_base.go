@@ -17,4 +17,15 @@ func (s *BaseRouter) InitBaseRouter(Router fiber.Router) {
 	baseRouter.Get("captcha", middleware.NeedInit, baseApi.Captcha)
 	baseRouter.Get("captcha/img", baseApi.CaptchaImg)
 	baseRouter.Post("getToken/login", baseApi.LoginToken)
+	baseRouter.Post("refresh", baseApi.RefreshToken)
+	baseRouter.Post("totp/login", baseApi.TotpLogin)                      // 两步验证登录
+	baseRouter.Post("totp/enroll", baseApi.TotpEnroll)                    // 登录时绑定验证器
+	baseRouter.Post("totp/activate", baseApi.TotpActivate)                // 登录时确认绑定并登录
+	baseRouter.Post("forgotPassword", baseApi.ForgotPassword)             // 邮件找回密码
+	baseRouter.Post("confirmPasswordReset", baseApi.ConfirmPasswordReset) // 使用邮件中的令牌重置密码
+	baseRouter.Post("recovery/questions", baseApi.RecoveryQuestions)      // 安全问题找回, 获取问题
+	baseRouter.Post("recovery/verify", baseApi.VerifyRecovery)            // 安全问题找回, 回答问题
+	baseRouter.Get("oidc/providers", baseApi.OidcProviders)               // 可用的 OIDC 身份提供方
+	baseRouter.Get("oidc/:provider/authorize", baseApi.OidcAuthorize)     // 发起 OIDC 登录
+	baseRouter.Post("oidc/callback", baseApi.OidcCallback)                // OIDC 登录回调
 }
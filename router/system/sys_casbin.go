@@ -14,7 +14,10 @@ func (s *CasbinRouter) InitCasbinRouter(Router fiber.Router) {
 	casbinApi := new(v1.CasbinApi)
 
 	casbinRouter.Post("updateCasbin", middleware.OperationRecord, casbinApi.UpdateCasbin)
+	casbinRouter.Post("syncAuthorityTree", middleware.OperationRecord, casbinApi.SyncAuthorityTree) // 按角色树同步继承关系
 
 	casbinRouter.Get("getPolicyPathByAuthorityId/:id", casbinApi.GetPolicyPathByAuthorityId)
+	casbinRouter.Get("getEffectivePolicyByAuthorityId/:id", casbinApi.GetEffectivePolicyByAuthorityId) // 含继承自父角色的权限
+	casbinRouter.Get("previewAuthorityTree", casbinApi.PreviewAuthorityTree)                           // 预览按角色树同步继承关系
 
 }
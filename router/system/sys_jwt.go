@@ -16,3 +16,9 @@ func (s *JwtRouter) InitJwtRouter(Router fiber.Router) {
 	jwtRouter.Post("jsonInBlacklist", middleware.OperationRecord, jwtApi.JsonInBlacklist) // jwt加入黑名单
 
 }
+
+// InitJwksRouter 公开验签公钥, 供其他服务校验本服务签发的 token
+func (s *JwtRouter) InitJwksRouter(Router fiber.Router) {
+	jwtApi := new(v1.JwtApi)
+	Router.Get("/.well-known/jwks.json", jwtApi.Jwks)
+}
@@ -0,0 +1,20 @@
+package system
+
+import (
+	v1 "server/api/v1/system"
+	"server/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type LoginGuardRouter struct{}
+
+func (s *LoginGuardRouter) InitLoginGuardRouter(Router fiber.Router) {
+	loginGuardRouter := Router.Group("loginGuard")
+	loginGuardApi := new(v1.LoginGuardApi)
+
+	loginGuardRouter.Post("unlock", middleware.OperationRecord, loginGuardApi.UnlockLogin) // 解除登录锁定
+
+	loginGuardRouter.Get("getLockList", loginGuardApi.GetLoginLockList)       // 获取锁定列表
+	loginGuardRouter.Get("getHistoryList", loginGuardApi.GetLoginHistoryList) // 获取登录记录
+}
@@ -0,0 +1,20 @@
+package system
+
+import (
+	v1 "server/api/v1/system"
+	"server/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type MigrationRouter struct{}
+
+func (s *MigrationRouter) InitMigrationRouter(Router fiber.Router) {
+	migrationRouter := Router.Group("migration")
+	migrationApi := new(v1.MigrationApi)
+
+	migrationRouter.Post("up", middleware.OperationRecord, migrationApi.MigrateUp)     // 执行sql迁移
+	migrationRouter.Post("down", middleware.OperationRecord, migrationApi.MigrateDown) // 回滚sql迁移
+
+	migrationRouter.Get("status", migrationApi.GetMigrationStatus) // 获取sql迁移状态
+}
@@ -0,0 +1,20 @@
+package system
+
+import (
+	v1 "server/api/v1/system"
+	"server/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type OidcRouter struct{}
+
+func (s *OidcRouter) InitOidcRouter(Router fiber.Router) {
+	oidcRouter := Router.Group("oidc")
+	oidcApi := new(v1.OidcApi)
+
+	oidcRouter.Post(":provider/link", middleware.OperationRecord, oidcApi.LinkOidc) // 关联外部账号
+	oidcRouter.Post("unlink", middleware.OperationRecord, oidcApi.UnlinkOidc)       // 解除外部账号关联
+
+	oidcRouter.Get("identities", oidcApi.GetOidcIdentities) // 获取已关联的外部账号
+}
@@ -17,4 +17,5 @@ func (s *OperationRecordRouter) InitSysOperationRecordRouter(Router fiber.Router
 	operationRecordRouter.Delete("deleteSysOperationRecordByIds", authorityMenuApi.DeleteSysOperationRecordByIds) // 批量删除SysOperationRecord
 	operationRecordRouter.Get("findSysOperationRecord/:id", authorityMenuApi.FindSysOperationRecord)              // 根据ID获取SysOperationRecord
 	operationRecordRouter.Get("getSysOperationRecordList", authorityMenuApi.GetSysOperationRecordList)            // 获取SysOperationRecord列表
+	operationRecordRouter.Get("getSysOperationRecordStats", authorityMenuApi.GetSysOperationRecordStats)          // 获取操作记录写入计数
 }
@@ -0,0 +1,22 @@
+package system
+
+import (
+	v1 "server/api/v1/system"
+	"server/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type SessionRouter struct{}
+
+func (s *SessionRouter) InitSessionRouter(Router fiber.Router) {
+	sessionRouter := Router.Group("session")
+	sessionApi := new(v1.SessionApi)
+
+	sessionRouter.Post("revoke", middleware.OperationRecord, sessionApi.RevokeSession)          // 退出指定设备
+	sessionRouter.Post("revokeAll", middleware.OperationRecord, sessionApi.RevokeAllSessions)   // 退出全部设备
+	sessionRouter.Post("revokeUser", middleware.OperationRecord, sessionApi.RevokeUserSessions) // 管理员强制用户下线
+
+	sessionRouter.Get("getList", sessionApi.GetSessionList)         // 获取当前用户的会话
+	sessionRouter.Get("getUserList", sessionApi.GetUserSessionList) // 管理员查看用户的会话
+}
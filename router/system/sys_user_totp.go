@@ -0,0 +1,23 @@
+package system
+
+import (
+	v1 "server/api/v1/system"
+	"server/middleware"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type TotpRouter struct{}
+
+func (s *TotpRouter) InitTotpRouter(Router fiber.Router) {
+	totpRouter := Router.Group("totp")
+	totpApi := new(v1.TotpApi)
+
+	totpRouter.Post("setup", middleware.OperationRecord, totpApi.SetupTotp)                       // 生成验证器密钥
+	totpRouter.Post("enable", middleware.OperationRecord, totpApi.EnableTotp)                     // 开启两步验证
+	totpRouter.Post("disable", middleware.OperationRecord, totpApi.DisableTotp)                   // 关闭两步验证
+	totpRouter.Post("recoveryCodes", middleware.OperationRecord, totpApi.RegenerateRecoveryCodes) // 重新生成恢复码
+	totpRouter.Post("reset", middleware.OperationRecord, totpApi.ResetTotp)                       // 管理员重置用户的两步验证
+
+	totpRouter.Get("status", totpApi.GetTotpStatus) // 获取两步验证状态
+}
@@ -62,6 +62,13 @@ var SystemRouterSet = wire.NewSet(
 	ProvideProblemRouter,
 	ProvideSysRouter,
 	ProvideSystemUserRouter,
+	ProvideMigrationRouter,
+	ProvideTotpRouter,
+	ProvideLoginGuardRouter,
+	ProvideOidcRouter,
+	ProvideApiKeyRouter,
+	ProvideSessionRouter,
+	ProvideAuditLogRouter,
 	ProvideSystemGroup,
 )
 
@@ -256,6 +263,34 @@ func ProvideSystemUserRouter() *system.UserRouter {
 	return &system.UserRouter{}
 }
 
+func ProvideMigrationRouter() *system.MigrationRouter {
+	return &system.MigrationRouter{}
+}
+
+func ProvideTotpRouter() *system.TotpRouter {
+	return &system.TotpRouter{}
+}
+
+func ProvideLoginGuardRouter() *system.LoginGuardRouter {
+	return &system.LoginGuardRouter{}
+}
+
+func ProvideOidcRouter() *system.OidcRouter {
+	return &system.OidcRouter{}
+}
+
+func ProvideApiKeyRouter() *system.ApiKeyRouter {
+	return &system.ApiKeyRouter{}
+}
+
+func ProvideSessionRouter() *system.SessionRouter {
+	return &system.SessionRouter{}
+}
+
+func ProvideAuditLogRouter() *system.AuditLogRouter {
+	return &system.AuditLogRouter{}
+}
+
 func ProvideSystemGroup(
 	apiRouter *system.ApiRouter,
 	githubRouter *system.GithubRouter,
@@ -274,6 +309,13 @@ func ProvideSystemGroup(
 	problemRouter *system.ProblemRouter,
 	sysRouter *system.SysRouter,
 	userRouter *system.UserRouter,
+	migrationRouter *system.MigrationRouter,
+	totpRouter *system.TotpRouter,
+	loginGuardRouter *system.LoginGuardRouter,
+	oidcRouter *system.OidcRouter,
+	apiKeyRouter *system.ApiKeyRouter,
+	sessionRouter *system.SessionRouter,
+	auditLogRouter *system.AuditLogRouter,
 ) *SystemRouter {
 	// 创建并返回 SystemRouter
 	return &SystemRouter{
@@ -294,5 +336,12 @@ func ProvideSystemGroup(
 		ProblemRouter:          *problemRouter,
 		SysRouter:              *sysRouter,
 		UserRouter:             *userRouter,
+		MigrationRouter:        *migrationRouter,
+		TotpRouter:             *totpRouter,
+		LoginGuardRouter:       *loginGuardRouter,
+		OidcRouter:             *oidcRouter,
+		ApiKeyRouter:           *apiKeyRouter,
+		SessionRouter:          *sessionRouter,
+		AuditLogRouter:         *auditLogRouter,
 	}
 }
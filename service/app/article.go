@@ -48,12 +48,18 @@ func (s *ArticleService) DeleteArticle(id uint) error {
 
 // DeleteArticleByIds deletes multiple articles by IDs
 func (s *ArticleService) DeleteArticleByIds(ids request.IdsReq) error {
-	return s.DeleteByIDs(ids)
+	return global.DB.Delete(&app.Article{}, "id IN ?", ids.Ids).Error
 }
 
-// UpdateArticle updates an existing article
+// UpdateArticle updates an existing article, the author is kept unchanged
 func (s *ArticleService) UpdateArticle(article *app.Article) error {
-	return s.Update(article)
+	var old app.Article
+	if err := global.DB.Select("id", "user_id", "created_at").Where("id = ?", article.ID).First(&old).Error; err != nil {
+		return err
+	}
+	article.UserId = old.UserId
+	article.CreatedAt = old.CreatedAt
+	return global.DB.Save(article).Error
 }
 
 // GetArticle retrieves an article by ID with related data
@@ -65,10 +71,10 @@ func (s *ArticleService) GetArticle(id uint) (app.Article, error) {
 
 // getList
 
-func (*ArticleService) GetArticleInfoList(info *appReq.ArticleSearch) (list []app.Article, total int64, err error) {
+func (*ArticleService) GetArticleInfoList(info *appReq.ArticleSearch, scopes ...func(*gorm.DB) *gorm.DB) (list []app.Article, total int64, err error) {
 	limit := info.PageSize
 	offset := info.PageSize * (info.Page - 1)
-	db := global.DB.Model(&app.Article{})
+	db := global.DB.Model(&app.Article{}).Scopes(scopes...)
 	err = db.Count(&total).Error
 	if err != nil {
 		return
@@ -25,12 +25,15 @@ func (*BaseMessageService) CreateBaseMessage(baseMessage *app.BaseMessage) (err
  */
 func (*BaseMessageService) UpdateBaseMessage(id int, baseMessage *app.BaseMessage) (err error) {
 	var baseMessageReplica app.BaseMessage
-	db := global.DB.Model(&app.BaseMessage{}).Where("id = ?", id).First(&baseMessageReplica)
+	global.DB.Model(&app.BaseMessage{}).Where("id = ?", id).First(&baseMessageReplica)
 	if baseMessageReplica.ID == 0 {
 		return errors.New("数据库没有记录")
 	}
-	// 这里可以添加对 baseMessageReplica 的字段赋值
-	result := db.Save(baseMessage)
+	// 归属用户和创建时间保持不变
+	baseMessage.ID = baseMessageReplica.ID
+	baseMessage.UserId = baseMessageReplica.UserId
+	baseMessage.CreatedAt = baseMessageReplica.CreatedAt
+	result := global.DB.Save(baseMessage)
 	if result.Error != nil {
 		err = result.Error
 		return
@@ -7,6 +7,8 @@ import (
 	commentReq "server/model/app/request"
 	"server/model/common/request"
 	"strings"
+
+	"gorm.io/gorm"
 )
 
 type CommentService struct{}
@@ -34,7 +36,8 @@ func (commentService *CommentService) UpdateComment(comment *app.Comment) (err e
 	if commentReplica.ID == 0 {
 		return errors.New("未找到该comment")
 	}
-	result := global.DB.Save(comment)
+	// 只允许修改评论内容, 归属和所在文章不随请求体变化
+	result := global.DB.Model(&commentReplica).Update("content", comment.Content)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -70,10 +73,10 @@ func (commentService *CommentService) GetCommentList(postId uint, page, pageSize
 	return comments, total, err
 }
 
-func (commentService *CommentService) GetCommentInfoList(info *commentReq.CommentSearch) (list []app.Comment, total int64, err error) {
+func (commentService *CommentService) GetCommentInfoList(info *commentReq.CommentSearch, scopes ...func(*gorm.DB) *gorm.DB) (list []app.Comment, total int64, err error) {
 	limit := info.PageSize
 	offset := info.PageSize * (info.Page - 1)
-	db := global.DB.Model(&app.Comment{}).Preload("Article").Preload("User").Preload("ToUser").Preload("Praises")
+	db := global.DB.Model(&app.Comment{}).Scopes(scopes...).Preload("Article").Preload("User").Preload("ToUser").Preload("Praises")
 	if info.ArticleId != 0 {
 		db = db.Where("article_id = ?", info.ArticleId)
 	}
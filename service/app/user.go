@@ -8,6 +8,7 @@ import (
 	global "server/model"
 
 	appReq "server/model/app/request"
+	"server/utils"
 
 	"gorm.io/gorm"
 )
@@ -21,7 +22,9 @@ func (userService *UserService) CreateUser(user *app.User) (err error) {
 	if !errors.Is(global.DB.Where("name = ?", user.Name).First(&userCurrent).Error, gorm.ErrRecordNotFound) { // 判断用户名是否注册
 		return errors.New("用户名已注册")
 	}
-	// user.Password = utils.MD5V([]byte(user.Password))
+	if user.Password, err = hashUserPassword(user.Password); err != nil {
+		return err
+	}
 	err = global.DB.Create(user).Error
 	return err
 }
@@ -48,9 +51,23 @@ func (userService *UserService) UpdateUser(user *app.User) (err error) {
 	if userReplice.ID == 0 {
 		return errors.New("更新错误， 未能找到该用户")
 	}
+	// 未填写密码时保留原密码, 填写了明文则重新哈希
+	if user.Password == "" {
+		user.Password = userReplice.Password
+	} else if user.Password, err = hashUserPassword(user.Password); err != nil {
+		return err
+	}
 	return db.Save(user).Error
 }
 
+// hashUserPassword 明文密码哈希后入库, 已经是哈希的原样返回
+func hashUserPassword(password string) (string, error) {
+	if password == "" || utils.IsPasswordHash(password) {
+		return password, nil
+	}
+	return utils.HashPassword(password)
+}
+
 // GetUser 根据id获取User记录
 // Author wuhao
 func (userService *UserService) GetUser(id uint) (user app.User, err error) {
@@ -5,9 +5,10 @@ import (
 	global "server/model"
 	"server/model/example"
 	"server/model/example/request"
-	"server/model/system"
-	systemService "server/service/system"
+	"server/utils/crypt"
 	"strings"
+
+	"gorm.io/gorm"
 )
 
 type CustomerService struct{}
@@ -60,24 +61,14 @@ func (exa *CustomerService) GetExaCustomer(id uint) (customer example.ExaCustome
 
 //@author: wuhao
 //@function: GetCustomerInfoList
-//@description: 分页获取客户列表
-//@param: sysUserAuthorityID string, info request.PageInfo
+//@description: 分页获取客户列表, scopes 用于按资源权限过滤
+//@param: info request.PageInfo, scopes ...func(*gorm.DB) *gorm.DB
 //@return: err error, list any, total int64
 
-func (exa *CustomerService) GetCustomerInfoList(sysUserAuthorityID string, info *request.SearchCustomerParams) (list []example.ExaCustomer, total int64, err error) {
+func (exa *CustomerService) GetCustomerInfoList(info *request.SearchCustomerParams, scopes ...func(*gorm.DB) *gorm.DB) (list []example.ExaCustomer, total int64, err error) {
 	limit := info.PageSize
 	offset := info.PageSize * (info.Page - 1)
-	db := global.DB.Model(&example.ExaCustomer{})
-	var a system.SysAuthority
-	a.AuthorityId = sysUserAuthorityID
-	auth, err := systemService.AuthorityServiceApp.GetAuthorityInfo(a)
-	if err != nil {
-		return
-	}
-	var dataId []string
-	for _, v := range auth.DataAuthorityId {
-		dataId = append(dataId, v.AuthorityId)
-	}
+	db := global.DB.Model(&example.ExaCustomer{}).Scopes(scopes...)
 	var CustomerList []example.ExaCustomer
 	if info.CustomerName != "" {
 		var build strings.Builder
@@ -88,13 +79,13 @@ func (exa *CustomerService) GetCustomerInfoList(sysUserAuthorityID string, info
 		db = db.Where("customer_name like ?", customername)
 	}
 	if info.CustomerPhoneData != "" {
-		db = db.Where("customer_phone_data = ?", info.CustomerPhoneData)
+		db = db.Where("customer_phone_bidx = ?", crypt.BlindIndex(info.CustomerPhoneData))
 	}
-	err = db.Where("sys_user_authority_id in ?", dataId).Count(&total).Error
+	err = db.Count(&total).Error
 	if err != nil {
 		return CustomerList, total, err
 	} else {
-		err = db.Order("id desc").Limit(limit).Offset(offset).Preload("SysUser").Where("sys_user_authority_id in ?", dataId).Find(&CustomerList).Error
+		err = db.Order("id desc").Limit(limit).Offset(offset).Preload("SysUser").Find(&CustomerList).Error
 	}
 	return CustomerList, total, err
 }
@@ -11,6 +11,8 @@ import (
 	global "server/model"
 
 	fileDimensionReq "server/model/example/request"
+
+	"gorm.io/gorm"
 )
 
 //@author: wuhao
@@ -66,11 +68,11 @@ func (e *FileUploadAndDownloadService) EditFileName(file *example.ExaFileUploadA
 //@param: info request.PageInfo
 //@return: err error, list any, total int64
 
-func (e *FileUploadAndDownloadService) GetFileRecordInfoList(info *request.PageInfo) (list []example.ExaFileUploadAndDownload, total int64, err error) {
+func (e *FileUploadAndDownloadService) GetFileRecordInfoList(info *request.PageInfo, scopes ...func(*gorm.DB) *gorm.DB) (list []example.ExaFileUploadAndDownload, total int64, err error) {
 	limit := info.PageSize
 	offset := info.PageSize * (info.Page - 1)
 	keyword := info.Keyword
-	db := global.DB.Model(&example.ExaFileUploadAndDownload{})
+	db := global.DB.Model(&example.ExaFileUploadAndDownload{}).Scopes(scopes...)
 	var fileLists []example.ExaFileUploadAndDownload
 	if len(keyword) > 0 {
 		db = db.Where("name LIKE ?", "%"+keyword+"%")
@@ -94,10 +96,10 @@ func (e *FileUploadAndDownloadService) GetFileRecordInfoList(info *request.PageI
 //@author: wuhao
 //@function: UploadFile
 //@description: 根据配置文件判断是文件上传到本地或者七牛云
-//@param: header *multipart.FileHeader, noSave string
+//@param: header *multipart.FileHeader, noSave string, fileDimension *fileDimensionReq.FileDimension, isCropper int, userId uint
 //@return: err error, file model.ExaFileUploadAndDownload
 
-func (e *FileUploadAndDownloadService) UploadFile(header *multipart.FileHeader, noSave string, fileDimension *fileDimensionReq.FileDimension, isCropper int) (file example.ExaFileUploadAndDownload, err error) {
+func (e *FileUploadAndDownloadService) UploadFile(header *multipart.FileHeader, noSave string, fileDimension *fileDimensionReq.FileDimension, isCropper int, userId uint) (file example.ExaFileUploadAndDownload, err error) {
 	oss := upload.NewOss()
 	filePath, key, uploadErr := oss.UploadFile(header)
 	if uploadErr != nil {
@@ -114,6 +116,7 @@ func (e *FileUploadAndDownloadService) UploadFile(header *multipart.FileHeader,
 			Height:     fileDimension.Height,
 			Proportion: fileDimension.Proportion,
 			IsCropper:  isCropper,
+			UserId:     userId,
 		}
 		return f, e.Upload(f)
 	}
@@ -1,14 +1,16 @@
 package frontend
 
 import (
-	"crypto/rsa"
 	"errors"
 	global "server/model"
 	"server/model/app"
 	"server/model/frontend"
 	frontendRequest "server/model/frontend/request"
 	frontendResponse "server/model/frontend/response"
+	"server/model/system"
+	systemService "server/service/system"
 	"server/utils"
+	"strconv"
 	"time"
 
 	jwt "github.com/golang-jwt/jwt/v5"
@@ -22,11 +24,18 @@ var (
 	ErrTokenInvalid     = errors.New("couldn't handle this token")
 )
 
+// MyClaims 前台用户 token, 只携带用户 id(sub) 和标准声明, 不能放任何敏感信息
 type MyClaims struct {
-	ID                   uint   `json:"id"`
-	Name                 string `json:"name"`
-	Password             string `json:"password"`
-	jwt.RegisteredClaims        // 注意!这是jwt-go的v4版本新增的，原先是jwt.StandardClaims
+	jwt.RegisteredClaims
+}
+
+// UserID 从 sub 中取用户 id
+func (m *MyClaims) UserID() (uint, error) {
+	id, err := strconv.ParseUint(m.Subject, 10, 64)
+	if err != nil || id == 0 {
+		return 0, ErrTokenInvalid
+	}
+	return uint(id), nil
 }
 
 type UpdateImage struct {
@@ -34,18 +43,20 @@ type UpdateImage struct {
 	HeapImage string
 }
 
-var MySecret *rsa.PrivateKey = global.RunCONFIG.JWT.PrivateKey
-
 type FrontendUser struct{}
 
 func (u *FrontendUser) Login(data frontendRequest.LoginForm) (userInter frontendResponse.LoginResponse, err error) {
 	var user frontend.User
-	// data.Password = utils.MD5V([]byte(data.Password))
-	err = global.DB.Where("username = ? and password = ?", data.Name, data.Password).First(&user).Error
+	err = global.DB.Where("username = ?", data.Name).First(&user).Error
+	if err == nil && !systemService.VerifyAndUpgradePassword(&frontend.User{}, user.ID, user.Password, data.Password) {
+		err = gorm.ErrRecordNotFound
+	}
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return userInter, errors.New("密码错误")
+	} else if err != nil {
+		return
 	}
-	tokenString, expiresAt, err := MakeToken(data, user.ID)
+	tokenString, expiresAt, err := MakeToken(user.ID)
 	if err != nil {
 		return
 	}
@@ -63,7 +74,9 @@ func (u *FrontendUser) RegisterUser(data *frontendRequest.RegisterUser) (err err
 		Content:      data.Content,
 		Header:       data.Header,
 	}
-	// user.Password = utils.MD5V([]byte(data.Password))
+	if user.Password, err = utils.HashPassword(data.Password); err != nil {
+		return err
+	}
 	if user.Header == "" {
 		user.Header = "uploads/file/2023/04/10/b53b3a3d6ab90ce0268229151c9bde11_162839.jpeg" // 默认图片
 	}
@@ -80,8 +93,11 @@ func (u *FrontendUser) GetUser(tokenString string) (userInter frontendResponse.L
 	if err != nil {
 		return
 	}
-	var userInfo frontend.User
-	err = global.DB.Where("name = ? and password = ?", myClaims.Name, myClaims.Password).First(&userInfo).Error
+	id, err := myClaims.UserID()
+	if err != nil {
+		return
+	}
+	userInfo, err := u.GetUserInfo(id)
 	userInter.User = userInfo
 	userInter.ExpiresAt = myClaims.RegisteredClaims.ExpiresAt.Unix()
 	return
@@ -106,44 +122,42 @@ func (u *FrontendUser) UpdateUser(data *frontend.User) (err error) {
 
 func (u *FrontendUser) ResetPassword(data *frontend.ResetPassword) (err error) {
 	var userInfo frontend.User
-	err = global.DB.Where("id = ? and password = ?", data.ID, data.Password).First(&userInfo).Error
-	if errors.Is(err, gorm.ErrRecordNotFound) {
+	if err = global.DB.Where("id = ?", data.ID).First(&userInfo).Error; err != nil {
+		return err
+	}
+	if ok, _ := utils.VerifyPassword(userInfo.Password, data.Password); !ok {
+		return gorm.ErrRecordNotFound
+	}
+	hashed, err := utils.HashPassword(data.NewPassword)
+	if err != nil {
 		return err
 	}
-	return global.DB.Model(&frontend.User{}).Where("id = ?", data.ID).Update("password", data.NewPassword).Error
+	return global.DB.Model(&frontend.User{}).Where("id = ?", data.ID).Update("password", hashed).Error
 }
 
-func MakeToken(data frontendRequest.LoginForm, id uint) (tokenString string, expiresAt int64, err error) {
+// MakeToken 签发前台用户 token, 与后台共用 utils.JWT 的密钥管理
+func MakeToken(id uint) (tokenString string, expiresAt int64, err error) {
 	claim := MyClaims{
-		ID:       id,
-		Name:     data.Name,
-		Password: data.Password,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(global.CONFIG.JWT.ExpiresTime) * time.Hour)), // 过期时间24小时
-			IssuedAt:  jwt.NewNumericDate(time.Now()),                                                               // 签发时间
-			NotBefore: jwt.NewNumericDate(time.Now()),                                                               // 生效时间
+			Subject:   strconv.FormatUint(uint64(id), 10),
+			Audience:  jwt.ClaimStrings{system.RefreshChannelFrontend},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(global.CONFIG.JWT.AccessExpires())), // 过期时间
+			IssuedAt:  jwt.NewNumericDate(time.Now()),                                        // 签发时间
+			NotBefore: jwt.NewNumericDate(time.Now()),                                        // 生效时间
 			Issuer:    global.CONFIG.JWT.Issuer,
 		},
 	}
-	// token := jwt.NewWithClaims(jwt.SigningMethodHS256, claim) // 使用HS256算法
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claim) // 使用RS256算法
-	tokenString, err = token.SignedString(MySecret)
+	tokenString, err = utils.NewJWT().Sign(claim)
 	return tokenString, claim.ExpiresAt.Unix(), err
 }
 
-func Secret() jwt.Keyfunc {
-	return func(token *jwt.Token) (any, error) {
-		return MySecret, nil // 这是我的secret
-	}
-}
-
 func ParseToken(tokenss string) (*MyClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenss, &MyClaims{}, Secret())
+	token, err := jwt.ParseWithClaims(tokenss, &MyClaims{}, utils.NewJWT().KeyFunc)
 	if err != nil {
 		return nil, utils.ReportError(err)
 	}
 	if claims, ok := token.Claims.(*MyClaims); ok && token.Valid {
 		return claims, nil
 	}
-	return nil, errors.New("couldn't handle this token")
+	return nil, ErrTokenInvalid
 }
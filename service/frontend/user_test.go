@@ -0,0 +1,92 @@
+package frontend
+
+import (
+	"encoding/base64"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"server/config"
+	global "server/model"
+	"server/model/frontend"
+	"server/model/system"
+	"server/utils"
+	"server/utils/crypt"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestFrontendToken(t *testing.T) {
+	keys, err := utils.GenerateJWTKeys()
+	require.NoError(t, err)
+	defer func(c config.JWTConfig) { global.RunCONFIG.JWT = c }(global.RunCONFIG.JWT)
+	global.RunCONFIG.JWT = keys
+	global.CONFIG.JWT.AccessExpiresMinutes = 1
+
+	token, _, err := MakeToken(42)
+	require.NoError(t, err)
+
+	t.Run("payload只包含sub和标准声明", func(t *testing.T) {
+		payload, err := base64.RawURLEncoding.DecodeString(strings.Split(token, ".")[1])
+		require.NoError(t, err)
+		assert.NotContains(t, string(payload), "password")
+		assert.Contains(t, string(payload), `"sub":"42"`)
+	})
+
+	t.Run("解析得到用户id", func(t *testing.T) {
+		claims, err := ParseToken(token)
+		require.NoError(t, err)
+		id, err := claims.UserID()
+		require.NoError(t, err)
+		assert.Equal(t, uint(42), id)
+	})
+
+	t.Run("其他密钥签发的token无效", func(t *testing.T) {
+		other, err := utils.GenerateJWTKeys()
+		require.NoError(t, err)
+		global.RunCONFIG.JWT = other
+		_, err = ParseToken(token)
+		assert.Error(t, err)
+	})
+}
+
+func TestFrontendUserEncrypted(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&system.SysUser{}))
+	global.DB, global.LOG = db, zap.NewNop()
+	t.Cleanup(func() { global.DB = nil })
+	old := global.CONFIG.Encryption
+	t.Cleanup(func() { global.CONFIG.Encryption = old })
+	global.CONFIG.Encryption = config.Encryption{
+		Keys:     []config.EncryptionKey{{Version: "v1", Key: "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="}},
+		IndexKey: "index",
+		Current:  "v1",
+	}
+	raw := func(column string, id uint) string {
+		var v string
+		require.NoError(t, global.DB.Table("sys_users").Select(column).Where("id = ?", id).Scan(&v).Error)
+		return v
+	}
+
+	// 后台写入的加密数据, 前台读取时解密
+	admin := system.SysUser{Username: "admin", Phone: "13800008000", Email: "a@example.com"}
+	require.NoError(t, global.DB.Create(&admin).Error)
+	user, err := (&FrontendUser{}).GetUserInfo(admin.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "13800008000", user.Phone)
+	assert.Equal(t, "a@example.com", user.Email)
+
+	// 前台写入时同样加密并同步盲索引
+	reader := frontend.User{Username: "reader", Phone: "13900009000", Email: "r@example.com"}
+	require.NoError(t, global.DB.Create(&reader).Error)
+	assert.Equal(t, "v1", crypt.Version(raw("phone", reader.ID)))
+	assert.Equal(t, "v1", crypt.Version(raw("email", reader.ID)))
+	assert.Equal(t, crypt.BlindIndex("13900009000"), raw("phone_bidx", reader.ID))
+	assert.Equal(t, crypt.BlindIndex("r@example.com"), raw("email_bidx", reader.ID))
+}
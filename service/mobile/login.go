@@ -6,29 +6,79 @@ import (
 	"server/model/mobile"
 	"server/model/mobile/request"
 	"server/model/mobile/response"
+	"server/model/system"
+	systemReq "server/model/system/request"
+	systemService "server/service/system"
 	"server/utils"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type MobileLoginService struct{}
 
-func (*MobileLoginService) Login(data *mobile.Login) (m response.LoginResponse, err error) {
-	var user mobile.MobileUser
-	// data.Password = utils.MD5V([]byte(data.Password))
-	err = global.DB.Where("username = ? and password = ?", data.Username, data.Password).First(&user).Error
+var ErrLoginFailed = errors.New("密码错误")
+
+func (*MobileLoginService) Login(data *mobile.Login, client systemService.SessionClient) (m response.LoginResponse, err error) {
+	// MobileUser 不带密码字段, 用 Register 读取密码
+	var account mobile.Register
+	err = global.DB.Where("username = ?", data.Username).First(&account).Error
+	if err == nil && !systemService.VerifyAndUpgradePassword(&mobile.MobileUser{}, account.ID, account.Password, data.Password) {
+		err = gorm.ErrRecordNotFound
+	}
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return m, errors.New("密码错误")
+		return m, ErrLoginFailed
+	} else if err != nil {
+		return
+	}
+	var user mobile.MobileUser
+	if err = global.DB.Where("id = ?", account.ID).First(&user).Error; err != nil {
+		return
 	}
 
-	j := utils.NewJWT()
+	jti := uuid.NewString()
+	refreshToken, refreshExpiresAt, err := systemService.RefreshTokenServiceApp.Issue(system.RefreshChannelMobile, user.ID, jti)
+	if err != nil {
+		return
+	}
+	return makeLoginResponse(user, jti, refreshToken, refreshExpiresAt, client)
+}
 
-	tokenString, expiresAt, err := j.MakeToken(*data, user.ID)
+// Refresh 使用刷新令牌换新, 旧的刷新令牌立即失效
+func (*MobileLoginService) Refresh(refreshToken string, client systemService.SessionClient) (m response.LoginResponse, err error) {
+	record, newRefreshToken, refreshExpiresAt, err := systemService.RefreshTokenServiceApp.Rotate(system.RefreshChannelMobile, refreshToken)
 	if err != nil {
 		return
 	}
+	var user mobile.MobileUser
+	if err = global.DB.Where("id = ?", record.UserId).First(&user).Error; err != nil {
+		return
+	}
+	return makeLoginResponse(user, record.FamilyId, newRefreshToken, refreshExpiresAt, client)
+}
+
+// Logout 作废当前登录 jti 对应的刷新令牌和会话, req.All 为 true 时作废该用户移动端的全部登录
+func (*MobileLoginService) Logout(req systemReq.Logout, userID uint, jti string) error {
+	return systemService.RefreshTokenServiceApp.Logout(system.RefreshChannelMobile, userID, jti, req)
+}
+
+// makeLoginResponse 记录会话并签发 token, jti 为刷新令牌族id
+func makeLoginResponse(user mobile.MobileUser, jti, refreshToken string, refreshExpiresAt time.Time, client systemService.SessionClient) (m response.LoginResponse, err error) {
+	if err = systemService.SessionServiceApp.Record(system.RefreshChannelMobile, user.ID, jti, client, refreshExpiresAt); err != nil {
+		return
+	}
+	j := utils.NewJWT()
+	tokenString, expiresAt, err := j.MakeToken(mobile.Login{Username: user.Username, Realname: user.Realname}, user.ID, jti)
+	if err != nil {
+		return
+	}
+	m.UserId = user.ID
 	m.Token = tokenString
 	m.ExpiresAt = expiresAt
+	m.RefreshToken = refreshToken
+	m.RefreshExpiresAt = refreshExpiresAt.Unix()
 	return
 }
 
@@ -39,20 +89,35 @@ func (*MobileLoginService) GetUserInfo(id uint) (u mobile.MobileUser, err error)
 }
 
 func (*MobileLoginService) UpdateUser(data *request.MobileUpdate, id uint) (err error) {
+	if strings.EqualFold(data.Field, "password") {
+		return errors.New("请使用修改密码接口")
+	}
+	// 加密字段按列更新会绕过序列化器和盲索引, 改为按结构体更新
+	if field := strings.ToLower(data.Field); field == "phone" || field == "realname" {
+		value, ok := data.Value.(string)
+		if !ok {
+			return errors.New("字段值必须是字符串")
+		}
+		user := mobile.MobileUser{Phone: value, Realname: value}
+		user.ID = id
+		return global.DB.Model(&user).Select(field, field+"_bidx").Updates(&user).Error
+	}
 	return global.DB.Model(&mobile.MobileUser{}).Where("id = ?", id).Update(data.Field, data.Value).Error
 }
 
 func (*MobileLoginService) UpdatePassword(data request.MobileUpdatePassword) (err error) {
-	var user mobile.MobileUser
-	// passwordMoken := utils.MD5V([]byte(data.Password))
-	db := global.DB.Model(&mobile.MobileUser{})
-	err = db.Where("id = ? and password = ?", data.ID, data.Password).First(&user).Error
+	var user mobile.Register
+	err = global.DB.Where("id = ?", data.ID).First(&user).Error
 	if err != nil {
 		return err
 	}
-	if user.ID == 0 {
-		return errors.New("未找到用户，请更新请求")
+	if ok, _ := utils.VerifyPassword(user.Password, data.Password); !ok {
+		return errors.New("原密码错误")
+	}
+	hashed, err := utils.HashPassword(data.NewPassword)
+	if err != nil {
+		return err
 	}
-	err = db.Update("password", data.NewPassword).Error
+	err = global.DB.Model(&mobile.MobileUser{}).Where("id = ?", data.ID).Update("password", hashed).Error
 	return err
 }
@@ -6,6 +6,7 @@ import (
 	"server/model/common/request"
 	"server/model/mobile"
 	mobileReq "server/model/mobile/request"
+	"server/utils/crypt"
 )
 
 type MobileUserService struct{}
@@ -14,7 +15,7 @@ type MobileUserService struct{}
 // Author [jianghao](https://github.com/JiangHaoCode)
 func (mobileUserService *MobileUserService) CreateMobileUser(mobileUser *mobile.MobileUser) (err error) {
 	var waitUser mobile.MobileUser
-	db := global.DB.Where("username = ?", mobileUser.Username).Where("phone = ?", mobileUser.Phone).Where("realname = ?", mobileUser.Realname).First(&waitUser)
+	db := global.DB.Where("username = ?", mobileUser.Username).Where("phone_bidx = ?", crypt.BlindIndex(mobileUser.Phone)).Where("realname_bidx = ?", crypt.BlindIndex(mobileUser.Realname)).First(&waitUser)
 	if waitUser.ID != 0 {
 		return errors.New("用户已存在")
 	}
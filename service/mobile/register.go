@@ -3,10 +3,14 @@ package mobile
 import (
 	global "server/model"
 	"server/model/mobile"
+	"server/utils"
 )
 
 type MobileRegisterService struct{}
 
-func (*MobileRegisterService) Register(data mobile.Register) error {
+func (*MobileRegisterService) Register(data mobile.Register) (err error) {
+	if data.Password, err = utils.HashPassword(data.Password); err != nil {
+		return err
+	}
 	return global.DB.Create(&data).Error
 }
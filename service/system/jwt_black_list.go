@@ -2,26 +2,41 @@ package system
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"server/model/system"
+	"strconv"
+	"strings"
 	"time"
 
+	jwt "github.com/golang-jwt/jwt/v5"
 	"go.uber.org/zap"
 
 	global "server/model"
 )
 
+const (
+	blacklistKeyPrefix = "jwt:blacklist:" // redis 中按 jti 保存的黑名单, ttl 为令牌剩余有效期
+	blacklistChannel   = "jwt:blacklist"  // 拉黑后通知其他实例写入本地缓存
+	blacklistMissTTL   = 10 * time.Second // 本地缓存"未拉黑"结果的时间, 通知丢失时最多延迟这么久生效
+)
+
 //@author: wuhao
 //@function: JsonInBlacklist
-//@description: 拉黑jwt
+//@description: 拉黑jwt, 按 jti 保存, 同一会话签发的 token 一起失效
 //@param: jwtList model.JwtBlacklist
 //@return: err error
 
 func (jwtService *JwtService) JsonInBlacklist(jwtList system.JwtBlacklist) (err error) {
+	jwtList.Jti, jwtList.ExpiresAt = blacklistId(jwtList.Jwt)
+	if !jwtList.ExpiresAt.After(time.Now()) {
+		return nil // 已过期的 token 本身就无法使用
+	}
 	err = global.DB.Create(&jwtList).Error
 	if err != nil {
 		return
 	}
-	global.BlackCache.SetDefault(jwtList.Jwt, struct{}{})
+	jwtService.JtiInBlacklist(jwtList.Jti, jwtList.ExpiresAt)
 	return
 }
 
@@ -32,11 +47,128 @@ func (jwtService *JwtService) JsonInBlacklist(jwtList system.JwtBlacklist) (err
 //@return: bool
 
 func (jwtService *JwtService) IsBlacklist(jwt string) bool {
-	_, ok := global.BlackCache.Get(jwt)
-	return ok
-	// err := global.DB.Where("jwt = ?", jwt).First(&system.JwtBlacklist{}).Error
-	// isNotFound := errors.Is(err, gorm.ErrRecordNotFound)
-	// return !isNotFound
+	jti, _ := blacklistId(jwt)
+	return jwtService.IsJtiBlacklist(jti)
+}
+
+//@function: JtiInBlacklist
+//@description: 按 jti 拉黑, 该会话签发的全部访问令牌失效, 到期后自动移除, 并通知其他实例
+//@param: jti string, expiresAt time.Time
+
+func (jwtService *JwtService) JtiInBlacklist(jti string, expiresAt time.Time) {
+	if !storeBlacklist(jti, expiresAt) || global.REDIS == nil {
+		return
+	}
+	message := jti + " " + strconv.FormatInt(expiresAt.Unix(), 10)
+	if err := global.REDIS.Publish(context.Background(), blacklistChannel, message).Err(); err != nil {
+		global.LOG.Error("jwt黑名单通知失败!", zap.Error(err))
+	}
+}
+
+//@function: IsJtiBlacklist
+//@description: 判断 jti 是否在黑名单内部, 本地缓存未命中时读取 redis
+//@param: jti string
+//@return: bool
+
+func (jwtService *JwtService) IsJtiBlacklist(jti string) bool {
+	if jti == "" {
+		return false
+	}
+	key := jtiBlacklistKey(jti)
+	if v, ok := global.BlackCache.Get(key); ok {
+		return v.(bool)
+	}
+	if global.REDIS == nil {
+		return false
+	}
+	ttl, err := global.REDIS.PTTL(context.Background(), blacklistKeyPrefix+jti).Result()
+	if err != nil {
+		// redis 不可用时不拦截, 本实例拉黑的令牌仍在本地缓存中
+		global.LOG.Error("读取jwt黑名单失败!", zap.Error(err))
+		return false
+	}
+	if ttl <= 0 {
+		global.BlackCache.Set(key, false, blacklistMissTTL)
+		return false
+	}
+	global.BlackCache.Set(key, true, ttl)
+	return true
+}
+
+//@function: SubscribeBlacklist
+//@description: 订阅其他实例的拉黑通知并写入本地缓存, ctx 结束后退出
+//@param: ctx context.Context
+
+func (jwtService *JwtService) SubscribeBlacklist(ctx context.Context) {
+	if global.REDIS == nil {
+		return
+	}
+	pubsub := global.REDIS.Subscribe(ctx, blacklistChannel)
+	defer pubsub.Close()
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			jti, unix, found := strings.Cut(msg.Payload, " ")
+			expiresAt, err := strconv.ParseInt(unix, 10, 64)
+			if !found || err != nil {
+				continue
+			}
+			if ttl := time.Until(time.Unix(expiresAt, 0)); ttl > 0 {
+				global.BlackCache.Set(jtiBlacklistKey(jti), true, ttl)
+			}
+		}
+	}
+}
+
+//@function: ClearExpiredBlacklist
+//@description: 删除已过期的黑名单记录
+//@return: int64, error
+
+func (jwtService *JwtService) ClearExpiredBlacklist() (int64, error) {
+	result := global.DB.Unscoped().Where("expires_at < ?", time.Now()).Delete(&system.JwtBlacklist{})
+	return result.RowsAffected, result.Error
+}
+
+// storeBlacklist 写入本地缓存和 redis, 已过期时返回 false
+func storeBlacklist(jti string, expiresAt time.Time) bool {
+	ttl := time.Until(expiresAt)
+	if jti == "" || ttl <= 0 {
+		return false
+	}
+	global.BlackCache.Set(jtiBlacklistKey(jti), true, ttl)
+	if global.REDIS != nil {
+		if err := global.REDIS.Set(context.Background(), blacklistKeyPrefix+jti, 1, ttl).Err(); err != nil {
+			global.LOG.Error("jwt黑名单写入redis失败!", zap.Error(err))
+		}
+	}
+	return true
+}
+
+func jtiBlacklistKey(jti string) string {
+	return "jti:" + jti
+}
+
+// blacklistId 取出 token 的 jti 和过期时间, 没有 jti 的旧令牌使用 token 的 sha256
+// 只用于拉黑和查询, 调用方已经校验过签名
+func blacklistId(token string) (string, time.Time) {
+	var claims jwt.RegisteredClaims
+	expiresAt := time.Now().Add(global.CONFIG.JWT.AccessExpires())
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err == nil {
+		if claims.ExpiresAt != nil {
+			expiresAt = claims.ExpiresAt.Time
+		}
+		if claims.ID != "" {
+			return claims.ID, expiresAt
+		}
+	}
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:]), expiresAt
 }
 
 //@author: wuhao
@@ -58,19 +190,35 @@ func (jwtService *JwtService) GetRedisJWT(userName string) (redisJWT string, err
 
 func (jwtService *JwtService) SetRedisJWT(jwt string, userName string) (err error) {
 	// 此处过期时间等于jwt过期时间
-	timer := time.Duration(global.CONFIG.JWT.ExpiresTime) * time.Second
+	timer := global.CONFIG.JWT.AccessExpires()
 	err = global.REDIS.Set(context.Background(), userName, jwt, timer).Err()
 	return err
 }
 
+// LoadAll 把数据库中未过期的黑名单写入本地缓存和 redis, redis 被清空后也能恢复
 func LoadAll() {
-	var data []string
-	err := global.DB.Model(&system.JwtBlacklist{}).Select("jwt").Find(&data).Error
+	var data []system.JwtBlacklist
+	err := global.DB.Where("expires_at > ? OR jti = '' OR jti IS NULL", time.Now()).Find(&data).Error
 	if err != nil {
 		global.LOG.Error("加载数据库jwt黑名单失败!", zap.Error(err))
 		return
 	}
-	for i := range len(data) {
-		global.BlackCache.SetDefault(data[i], struct{}{})
+	for i := range data {
+		// 旧记录只保存了 jwt, 补上 jti 和过期时间, 过期的由定时任务清理
+		if data[i].Jti == "" {
+			data[i].Jti, data[i].ExpiresAt = blacklistId(data[i].Jwt)
+			global.DB.Model(&data[i]).Updates(map[string]any{"jti": data[i].Jti, "expires_at": data[i].ExpiresAt})
+		}
+		storeBlacklist(data[i].Jti, data[i].ExpiresAt)
 	} // jwt黑名单 加入 BlackCache 中
+	// 已作废且未过期的会话按 jti 加入 BlackCache
+	var sessions []system.SysSession
+	err = global.DB.Select("jti", "expires_at").Where("revoked_at IS NOT NULL AND expires_at > ?", time.Now()).Find(&sessions).Error
+	if err != nil {
+		global.LOG.Error("加载已作废的会话失败!", zap.Error(err))
+		return
+	}
+	for _, session := range sessions {
+		storeBlacklist(session.Jti, session.ExpiresAt)
+	}
 }
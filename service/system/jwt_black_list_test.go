@@ -0,0 +1,88 @@
+package system
+
+import (
+	"testing"
+	"time"
+
+	global "server/model"
+	"server/model/system"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blacklistToken 签发测试用 token, 拉黑时不校验签名
+func blacklistToken(t *testing.T, jti string, expiresAt time.Time) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		ID:        jti,
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}).SignedString([]byte("test"))
+	require.NoError(t, err)
+	return token
+}
+
+func TestJwtBlacklistByJti(t *testing.T) {
+	setupTestDB(t, &system.JwtBlacklist{})
+	s := JwtServiceApp
+	old := blacklistToken(t, "session-1", time.Now().Add(time.Hour))
+	refreshed := blacklistToken(t, "session-1", time.Now().Add(2*time.Hour))
+	other := blacklistToken(t, "session-2", time.Now().Add(time.Hour))
+
+	require.NoError(t, s.JsonInBlacklist(system.JwtBlacklist{Jwt: old}))
+	assert.True(t, s.IsBlacklist(old))
+	assert.True(t, s.IsBlacklist(refreshed), "同一会话刷新后的 token 一起失效")
+	assert.False(t, s.IsBlacklist(other))
+
+	var row system.JwtBlacklist
+	require.NoError(t, global.DB.First(&row).Error)
+	assert.Equal(t, "session-1", row.Jti)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), row.ExpiresAt, 2*time.Second)
+
+	// 已过期的 token 不需要保存
+	require.NoError(t, s.JsonInBlacklist(system.JwtBlacklist{Jwt: blacklistToken(t, "session-3", time.Now().Add(-time.Minute))}))
+	var count int64
+	global.DB.Model(&system.JwtBlacklist{}).Count(&count)
+	assert.EqualValues(t, 1, count)
+
+	// 没有 jti 的旧令牌按整个 token 拉黑
+	legacy := blacklistToken(t, "", time.Now().Add(time.Hour))
+	require.NoError(t, s.JsonInBlacklist(system.JwtBlacklist{Jwt: legacy}))
+	assert.True(t, s.IsBlacklist(legacy))
+	assert.False(t, s.IsBlacklist(blacklistToken(t, "", time.Now().Add(2*time.Hour))))
+}
+
+func TestJwtBlacklistLoadAndClear(t *testing.T) {
+	setupTestDB(t, &system.JwtBlacklist{}, &system.SysSession{})
+	s := JwtServiceApp
+	live := blacklistToken(t, "live", time.Now().Add(time.Hour))
+	expired := blacklistToken(t, "expired", time.Now().Add(-time.Hour))
+	require.NoError(t, s.JsonInBlacklist(system.JwtBlacklist{Jwt: live}))
+	// 升级前只保存了 jwt 的记录
+	require.NoError(t, global.DB.Create(&[]system.JwtBlacklist{{Jwt: expired}, {Jwt: blacklistToken(t, "legacy", time.Now().Add(time.Hour))}}).Error)
+
+	global.BlackCache = local_cache.NewCache()
+	LoadAll()
+	assert.True(t, s.IsJtiBlacklist("live"))
+	assert.True(t, s.IsJtiBlacklist("legacy"))
+	assert.False(t, s.IsJtiBlacklist("expired"))
+
+	n, err := s.ClearExpiredBlacklist()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, n)
+	var jtis []string
+	global.DB.Unscoped().Model(&system.JwtBlacklist{}).Order("jti").Pluck("jti", &jtis)
+	assert.Equal(t, []string{"legacy", "live"}, jtis)
+}
+
+func TestBlacklistIdFallback(t *testing.T) {
+	old := global.CONFIG.JWT.AccessExpiresMinutes
+	global.CONFIG.JWT.AccessExpiresMinutes = 2
+	t.Cleanup(func() { global.CONFIG.JWT.AccessExpiresMinutes = old })
+
+	// 无法解析过期时间时按签发时长保存, 签发时长以分钟为单位
+	jti, expiresAt := blacklistId("not-a-jwt")
+	assert.Len(t, jti, 64)
+	assert.WithinDuration(t, time.Now().Add(2*time.Minute), expiresAt, 2*time.Second)
+}
@@ -7,7 +7,12 @@ import (
 	"server/model/common/request"
 	"server/model/system"
 	systemReq "server/model/system/request"
+	systemRes "server/model/system/response"
+	"sort"
+	"strings"
 
+	gormadapter "github.com/casbin/gorm-adapter/v3"
+	"github.com/gofiber/fiber/v3"
 	"gorm.io/gorm"
 )
 
@@ -112,7 +117,13 @@ func (apiService *ApiService) UpdateApi(api *system.SysApi) (err error) {
 			return errors.New("存在相同api路径")
 		}
 	}
-	return global.DB.Save(api).Error
+	if err = global.DB.Save(api).Error; err != nil {
+		return err
+	}
+	if oldA.Path != api.Path || oldA.Method != api.Method {
+		return CasbinServiceApp.UpdateCasbinApi(oldA.Path, api.Path, oldA.Method, api.Method)
+	}
+	return nil
 }
 
 //
@@ -129,3 +140,200 @@ func (apiService *ApiService) DeleteApisByIds(ids request.IdsReq) (err error) {
 func (apiService *ApiService) DeleteApiByIds(ids []string) (err error) {
 	return global.DB.Delete(&system.SysApi{}, "id in ?", ids).Error
 }
+
+// ApiSyncPrefix 只同步经过 casbin 鉴权的后台接口
+const ApiSyncPrefix = "/backend/"
+
+// ApiSyncIgnore 不需要鉴权的后台路由, 同步时两边都跳过
+var ApiSyncIgnore = []string{
+	"/backend/base/",
+	"/backend/init/",
+	"/backend/uploads/",
+	"/backend/public/",
+	"/backend/logs/",
+	"/backend/form-generator/",
+}
+
+//
+//@function: SyncApi
+//@description: 对比已注册的路由和 sys_apis, 返回新增、失效和方法变化的接口
+//@param: routes []fiber.Route
+//@return: result systemRes.SysApiSyncResponse, err error
+
+func (apiService *ApiService) SyncApi(routes []fiber.Route) (result systemRes.SysApiSyncResponse, err error) {
+	var apis []system.SysApi
+	if err = global.DB.Where("path LIKE ?", ApiSyncPrefix+"%").Find(&apis).Error; err != nil {
+		return
+	}
+	registered := map[string]bool{}
+	for _, route := range routes {
+		if route.Method == fiber.MethodHead || !syncableApiPath(route.Path) {
+			continue
+		}
+		registered[route.Method+" "+route.Path] = true
+	}
+	stored := map[string]bool{}
+	var stale []system.SysApi
+	for _, api := range apis {
+		if !syncableApiPath(api.Path) {
+			continue
+		}
+		key := api.Method + " " + api.Path
+		stored[key] = true
+		if !registered[key] {
+			stale = append(stale, api)
+		}
+	}
+	newByPath := map[string][]string{}
+	for key := range registered {
+		if !stored[key] {
+			method, path, _ := strings.Cut(key, " ")
+			newByPath[path] = append(newByPath[path], method)
+		}
+	}
+	// 同一路径只有一个失效和一个新增时视为改了方法, 保留原记录和已分配的权限
+	for _, api := range stale {
+		if methods := newByPath[api.Path]; len(methods) == 1 && countApiPath(stale, api.Path) == 1 {
+			result.MethodApis = append(result.MethodApis, systemRes.SysApiMethodDiff{Api: api, Method: methods[0]})
+			delete(newByPath, api.Path)
+			continue
+		}
+		result.DeleteApis = append(result.DeleteApis, api)
+	}
+	for path, methods := range newByPath {
+		for _, method := range methods {
+			result.NewApis = append(result.NewApis, system.SysApi{
+				Path:        path,
+				Method:      method,
+				ApiGroup:    apiGroupOf(path),
+				Description: path,
+			})
+		}
+	}
+	sort.Slice(result.NewApis, func(i, j int) bool {
+		if result.NewApis[i].Path != result.NewApis[j].Path {
+			return result.NewApis[i].Path < result.NewApis[j].Path
+		}
+		return result.NewApis[i].Method < result.NewApis[j].Method
+	})
+	return result, nil
+}
+
+//
+//@function: EnterSyncApi
+//@description: 按 SyncApi 的结果录入新接口、删除失效接口并同步 casbin 规则
+//@param: routes []fiber.Route
+//@return: result systemRes.SysApiSyncResponse, err error
+
+func (apiService *ApiService) EnterSyncApi(routes []fiber.Route) (result systemRes.SysApiSyncResponse, err error) {
+	if result, err = apiService.SyncApi(routes); err != nil {
+		return
+	}
+	if len(result.NewApis) > 0 {
+		if err = global.DB.Create(&result.NewApis).Error; err != nil {
+			return
+		}
+	}
+	for _, api := range result.DeleteApis {
+		if err = apiService.DeleteApi(api); err != nil {
+			return
+		}
+	}
+	for _, diff := range result.MethodApis {
+		if err = global.DB.Model(&system.SysApi{}).Where("id = ?", diff.Api.ID).Update("method", diff.Method).Error; err != nil {
+			return
+		}
+		if err = CasbinServiceApp.UpdateCasbinApi(diff.Api.Path, diff.Api.Path, diff.Api.Method, diff.Method); err != nil {
+			return
+		}
+	}
+	return result, nil
+}
+
+func syncableApiPath(path string) bool {
+	if !strings.HasPrefix(path, ApiSyncPrefix) {
+		return false
+	}
+	for _, prefix := range ApiSyncIgnore {
+		if strings.HasPrefix(path, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// apiGroupOf 取 /backend/ 后的第一段作为 api 组, 例如 /backend/user/getUserList 为 user
+func apiGroupOf(path string) string {
+	group, _, _ := strings.Cut(strings.TrimPrefix(path, ApiSyncPrefix), "/")
+	return group
+}
+
+func countApiPath(apis []system.SysApi, path string) (n int) {
+	for _, api := range apis {
+		if api.Path == path {
+			n++
+		}
+	}
+	return
+}
+
+// RenamedApi 改过路径的后台接口
+type RenamedApi struct {
+	Method  string
+	OldPath string
+	NewPath string
+}
+
+// RenamedApis 路由改名后, 已有数据库中的 api 记录和角色权限仍是旧路径, 启动时迁移到新路径
+var RenamedApis = []RenamedApi{
+	{Method: "PUT", OldPath: "/backend/base_message/updateBaseMessage", NewPath: "/backend/base_message/updateBaseMessage/:id"},
+}
+
+//
+//@function: MigrateRenamedApis
+//@description: 把 RenamedApis 中旧路径的 api 记录和 casbin 规则改为新路径, 已迁移时不做处理, 可以重复执行
+//@return: error
+
+func (apiService *ApiService) MigrateRenamedApis() error {
+	for _, r := range RenamedApis {
+		if err := apiService.migrateRenamedApi(r); err != nil {
+			return fmt.Errorf("迁移 %s %s 失败: %w", r.Method, r.OldPath, err)
+		}
+	}
+	return nil
+}
+
+func (apiService *ApiService) migrateRenamedApi(r RenamedApi) error {
+	var old system.SysApi
+	err := global.DB.Where("path = ? AND method = ?", r.OldPath, r.Method).First(&old).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+	case err != nil:
+		return err
+	case errors.Is(global.DB.Where("path = ? AND method = ?", r.NewPath, r.Method).First(&system.SysApi{}).Error, gorm.ErrRecordNotFound):
+		if err = global.DB.Model(&old).Update("path", r.NewPath).Error; err != nil {
+			return err
+		}
+	default:
+		// 新路径已经存在, 例如同步过 api, 删除旧记录
+		if err = global.DB.Delete(&old).Error; err != nil {
+			return err
+		}
+	}
+
+	var authorityIds []string
+	if err = global.DB.Model(&gormadapter.CasbinRule{}).Where("v1 = ? AND v2 = ?", r.OldPath, r.Method).Pluck("v0", &authorityIds).Error; err != nil || len(authorityIds) == 0 {
+		return err
+	}
+	// 已经有新路径的角色删除旧规则, 避免改名后规则重复
+	var migrated []string
+	if err = global.DB.Model(&gormadapter.CasbinRule{}).Where("v1 = ? AND v2 = ? AND v0 IN ?", r.NewPath, r.Method, authorityIds).Pluck("v0", &migrated).Error; err != nil {
+		return err
+	}
+	if len(migrated) > 0 {
+		if err = global.DB.Where("v1 = ? AND v2 = ? AND v0 IN ?", r.OldPath, r.Method, migrated).Delete(&gormadapter.CasbinRule{}).Error; err != nil {
+			return err
+		}
+	}
+	return CasbinServiceApp.UpdateCasbinApi(r.OldPath, r.NewPath, r.Method, r.Method)
+}
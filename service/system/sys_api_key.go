@@ -0,0 +1,182 @@
+package system
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	global "server/model"
+	"server/model/system"
+	systemReq "server/model/system/request"
+	"server/utils"
+
+	"github.com/casbin/casbin/v3/util"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrApiKeyInvalid = errors.New("API Key 无效或已作废")
+	ErrApiKeyExpired = errors.New("API Key 已过期")
+	ErrApiKeyScope   = errors.New("API Key 的接口范围不存在")
+)
+
+// 最后使用时间的更新间隔, 避免每个请求都写库
+const apiKeyTouchInterval = time.Minute
+
+//@function: Create
+//@description: 创建 API Key, 返回的明文只出现这一次
+//@param: userId uint, req systemReq.CreateApiKey
+//@return: key string, record system.SysApiKey, err error
+
+func (a *ApiKeyService) Create(userId uint, req systemReq.CreateApiKey) (key string, record system.SysApiKey, err error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || req.ExpireDays < 0 {
+		return "", record, errors.New("名称不能为空, 有效天数不能小于0")
+	}
+	scopes := make([]system.ApiKeyScope, 0, len(req.Scopes))
+	for _, s := range req.Scopes {
+		s.Method = strings.ToUpper(s.Method)
+		var count int64
+		if err = global.DB.Model(&system.SysApi{}).Where("path = ? AND method = ?", s.Path, s.Method).Count(&count).Error; err != nil {
+			return "", record, err
+		}
+		if count == 0 {
+			return "", record, errors.Join(ErrApiKeyScope, errors.New(s.Method+" "+s.Path))
+		}
+		scopes = append(scopes, s)
+	}
+	secret, err := utils.RandomToken(32)
+	if err != nil {
+		return "", record, err
+	}
+	key = system.ApiKeyPrefix + secret
+	record = system.SysApiKey{
+		UserId:  userId,
+		Name:    req.Name,
+		KeyHash: utils.Sha256V(key),
+		Hint:    key[:len(system.ApiKeyPrefix)+6],
+		Scopes:  scopes,
+	}
+	if req.ExpireDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, req.ExpireDays)
+		record.ExpiresAt = &expiresAt
+	}
+	err = global.DB.Create(&record).Error
+	return key, record, err
+}
+
+//@function: GetList
+//@description: 用户自己的 API Key
+//@param: userId uint
+//@return: list []system.SysApiKey, err error
+
+func (a *ApiKeyService) GetList(userId uint) (list []system.SysApiKey, err error) {
+	err = global.DB.Where("user_id = ?", userId).Order("id desc").Find(&list).Error
+	return list, err
+}
+
+//@function: GetApiKeyList
+//@description: 分页获取全部 API Key
+//@param: info systemReq.SysApiKeySearch
+//@return: list []system.SysApiKey, total int64, err error
+
+func (a *ApiKeyService) GetApiKeyList(info systemReq.SysApiKeySearch) (list []system.SysApiKey, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.DB.Model(&system.SysApiKey{})
+	if info.UserId != 0 {
+		db = db.Where("user_id = ?", info.UserId)
+	}
+	if info.Keyword != "" {
+		db = db.Where("name LIKE ?", "%"+info.Keyword+"%")
+	}
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Order("id desc").Limit(limit).Offset(offset).Find(&list).Error
+	return list, total, err
+}
+
+//@function: Revoke
+//@description: 作废用户自己的 API Key
+//@param: userId uint, id uint
+//@return: error
+
+func (a *ApiKeyService) Revoke(userId, id uint) error {
+	return a.revoke(global.DB.Where("user_id = ?", userId), id)
+}
+
+//@function: RevokeById
+//@description: 管理员作废任意 API Key
+//@param: id uint
+//@return: error
+
+func (a *ApiKeyService) RevokeById(id uint) error {
+	return a.revoke(global.DB, id)
+}
+
+//@function: Authenticate
+//@description: 校验 API Key, 返回 API Key 和所属用户, 并记录最后使用时间
+//@param: key string, ip string
+//@return: *system.SysApiKey, *system.SysUser, error
+
+func (a *ApiKeyService) Authenticate(key, ip string) (*system.SysApiKey, *system.SysUser, error) {
+	if !strings.HasPrefix(key, system.ApiKeyPrefix) {
+		return nil, nil, ErrApiKeyInvalid
+	}
+	var record system.SysApiKey
+	if err := global.DB.Where("key_hash = ?", utils.Sha256V(key)).First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrApiKeyInvalid
+		}
+		return nil, nil, err
+	}
+	now := time.Now()
+	if record.RevokedAt != nil {
+		return nil, nil, ErrApiKeyInvalid
+	}
+	if record.ExpiresAt != nil && now.After(*record.ExpiresAt) {
+		return nil, nil, ErrApiKeyExpired
+	}
+	var user system.SysUser
+	if err := global.DB.Where("id = ?", record.UserId).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrApiKeyInvalid
+		}
+		return nil, nil, err
+	}
+	if record.LastUsedAt == nil || now.Sub(*record.LastUsedAt) > apiKeyTouchInterval || record.LastUsedIp != ip {
+		record.LastUsedAt, record.LastUsedIp = &now, ip
+		global.DB.Model(&system.SysApiKey{}).Where("id = ?", record.ID).
+			Updates(map[string]any{"last_used_at": now, "last_used_ip": ip})
+	}
+	return &record, &user, nil
+}
+
+//@function: Allowed
+//@description: API Key 的接口范围是否包含该请求, 范围为空时不额外限制
+//@param: key *system.SysApiKey, path string, method string
+//@return: bool
+
+func (a *ApiKeyService) Allowed(key *system.SysApiKey, path, method string) bool {
+	if len(key.Scopes) == 0 {
+		return true
+	}
+	for _, s := range key.Scopes {
+		if strings.EqualFold(s.Method, method) && util.KeyMatch2(path, s.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *ApiKeyService) revoke(db *gorm.DB, id uint) error {
+	result := db.Model(&system.SysApiKey{}).Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package system
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	global "server/model"
+	"server/model/common/request"
+	"server/model/system"
+	systemReq "server/model/system/request"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupApiKeyTest(t *testing.T) (*ApiKeyService, system.SysUser) {
+	setupTestDB(t, &system.SysUser{}, &system.SysApi{}, &system.SysApiKey{})
+	user := system.SysUser{Username: "robot", Password: "x", AuthorityId: "888"}
+	require.NoError(t, global.DB.Create(&user).Error)
+	require.NoError(t, global.DB.Create(&[]system.SysApi{
+		{Path: "/backend/user/getUserList", Method: "GET"},
+		{Path: "/backend/api/:id", Method: "DELETE"},
+	}).Error)
+	return &ApiKeyService{}, user
+}
+
+func TestApiKeyCreateAndAuthenticate(t *testing.T) {
+	a, user := setupApiKeyTest(t)
+
+	key, record, err := a.Create(user.ID, systemReq.CreateApiKey{Name: " ci ", ExpireDays: 30})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(key, system.ApiKeyPrefix))
+	assert.True(t, strings.HasPrefix(key, record.Hint))
+	assert.Equal(t, "ci", record.Name)
+	assert.NotContains(t, record.KeyHash, key)
+	require.NotNil(t, record.ExpiresAt)
+
+	got, owner, err := a.Authenticate(key, "10.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, record.ID, got.ID)
+	assert.Equal(t, user.ID, owner.ID)
+	assert.Equal(t, "888", owner.AuthorityId)
+	list, err := a.GetList(user.ID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.NotNil(t, list[0].LastUsedAt)
+	assert.Equal(t, "10.0.0.1", list[0].LastUsedIp)
+
+	_, _, err = a.Authenticate(key+"x", "")
+	assert.ErrorIs(t, err, ErrApiKeyInvalid)
+	_, _, err = a.Authenticate("Bearer-token", "")
+	assert.ErrorIs(t, err, ErrApiKeyInvalid)
+
+	// 只能作废自己的
+	assert.Error(t, a.Revoke(user.ID+1, record.ID))
+	require.NoError(t, a.Revoke(user.ID, record.ID))
+	_, _, err = a.Authenticate(key, "")
+	assert.ErrorIs(t, err, ErrApiKeyInvalid)
+	assert.Error(t, a.RevokeById(record.ID))
+}
+
+func TestApiKeyExpired(t *testing.T) {
+	a, user := setupApiKeyTest(t)
+	key, record, err := a.Create(user.ID, systemReq.CreateApiKey{Name: "old"})
+	require.NoError(t, err)
+	assert.Nil(t, record.ExpiresAt)
+	require.NoError(t, global.DB.Model(&record).Update("expires_at", time.Now().Add(-time.Minute)).Error)
+	_, _, err = a.Authenticate(key, "")
+	assert.ErrorIs(t, err, ErrApiKeyExpired)
+
+	// 所属用户删除后不能再使用
+	key, _, err = a.Create(user.ID, systemReq.CreateApiKey{Name: "orphan"})
+	require.NoError(t, err)
+	require.NoError(t, global.DB.Delete(&user).Error)
+	_, _, err = a.Authenticate(key, "")
+	assert.ErrorIs(t, err, ErrApiKeyInvalid)
+
+	_, _, err = a.Create(user.ID, systemReq.CreateApiKey{Name: " "})
+	assert.Error(t, err)
+}
+
+func TestApiKeyScopes(t *testing.T) {
+	a, user := setupApiKeyTest(t)
+
+	_, _, err := a.Create(user.ID, systemReq.CreateApiKey{Name: "bad", Scopes: []system.ApiKeyScope{{Path: "/backend/nope", Method: "GET"}}})
+	assert.ErrorIs(t, err, ErrApiKeyScope)
+
+	_, record, err := a.Create(user.ID, systemReq.CreateApiKey{Name: "narrow", Scopes: []system.ApiKeyScope{
+		{Path: "/backend/user/getUserList", Method: "get"},
+		{Path: "/backend/api/:id", Method: "DELETE"},
+	}})
+	require.NoError(t, err)
+	assert.True(t, a.Allowed(&record, "/backend/user/getUserList", "GET"))
+	assert.True(t, a.Allowed(&record, "/backend/api/12", "DELETE"))
+	assert.False(t, a.Allowed(&record, "/backend/api/12", "GET"))
+	assert.False(t, a.Allowed(&record, "/backend/user/setUserInfo", "PUT"))
+
+	// 不限定范围时只按角色鉴权
+	assert.True(t, a.Allowed(&system.SysApiKey{}, "/backend/user/setUserInfo", "PUT"))
+
+	list, total, err := a.GetApiKeyList(systemReq.SysApiKeySearch{UserId: user.ID, PageInfo: request.PageInfo{Page: 1, PageSize: 10}})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, total)
+	require.Len(t, list, 1)
+	assert.Len(t, list[0].Scopes, 2)
+}
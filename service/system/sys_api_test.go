@@ -0,0 +1,95 @@
+package system
+
+import (
+	"testing"
+
+	global "server/model"
+	"server/model/system"
+	"server/model/system/request"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncApi(t *testing.T) {
+	setupCasbinTest(t, false)
+	require.NoError(t, global.DB.AutoMigrate(&system.SysApi{}))
+	require.NoError(t, global.DB.Create(&[]system.SysApi{
+		{Path: "/backend/user/getUserList", Method: "GET", ApiGroup: "系统用户"},
+		{Path: "/backend/user/old", Method: "POST", ApiGroup: "系统用户"},
+		{Path: "/backend/user/setInfo", Method: "POST", ApiGroup: "系统用户"},
+		{Path: "/backend/base/login", Method: "POST", ApiGroup: "base"},
+	}).Error)
+	require.NoError(t, CasbinServiceApp.UpdateCasbin("888", []request.CasbinInfo{
+		{Path: "/backend/user/old", Method: "POST"},
+		{Path: "/backend/user/setInfo", Method: "POST"},
+	}))
+
+	app := fiber.New()
+	handler := func(c fiber.Ctx) error { return nil }
+	app.Get("/backend/user/getUserList", handler)
+	app.Put("/backend/user/setInfo", handler)
+	app.Get("/backend/api/syncApi", handler)
+	app.Post("/backend/base/login", handler)
+	app.Get("/backend/uploads/*", handler)
+	routes := app.GetRoutes(true)
+
+	s := ApiServiceApp
+	result, err := s.SyncApi(routes)
+	require.NoError(t, err)
+	require.Len(t, result.NewApis, 1)
+	assert.Equal(t, system.SysApi{Path: "/backend/api/syncApi", Method: "GET", ApiGroup: "api", Description: "/backend/api/syncApi"}, result.NewApis[0])
+	require.Len(t, result.DeleteApis, 1)
+	assert.Equal(t, "/backend/user/old", result.DeleteApis[0].Path)
+	require.Len(t, result.MethodApis, 1)
+	assert.Equal(t, "/backend/user/setInfo", result.MethodApis[0].Api.Path)
+	assert.Equal(t, "PUT", result.MethodApis[0].Method)
+
+	_, err = s.EnterSyncApi(routes)
+	require.NoError(t, err)
+	var apis []string
+	global.DB.Model(&system.SysApi{}).Order("path").Pluck("method || ' ' || path", &apis)
+	assert.Equal(t, []string{"GET /backend/api/syncApi", "POST /backend/base/login", "GET /backend/user/getUserList", "PUT /backend/user/setInfo"}, apis)
+	// 失效接口的权限被删除, 改了方法的接口权限随动
+	assert.Equal(t, []request.CasbinInfo{{Path: "/backend/user/setInfo", Method: "PUT"}}, CasbinServiceApp.GetPolicyPathByAuthorityId("888"))
+
+	result, err = s.SyncApi(routes)
+	require.NoError(t, err)
+	assert.Empty(t, result.NewApis)
+	assert.Empty(t, result.DeleteApis)
+	assert.Empty(t, result.MethodApis)
+}
+
+func TestMigrateRenamedApis(t *testing.T) {
+	setupCasbinTest(t, false)
+	require.NoError(t, global.DB.AutoMigrate(&system.SysApi{}))
+	old := RenamedApis
+	t.Cleanup(func() { RenamedApis = old })
+	RenamedApis = []RenamedApi{{Method: "PUT", OldPath: "/backend/msg/update", NewPath: "/backend/msg/update/:id"}}
+	require.NoError(t, global.DB.Create(&system.SysApi{Path: "/backend/msg/update", Method: "PUT", ApiGroup: "msg"}).Error)
+	require.NoError(t, CasbinServiceApp.UpdateCasbin("888", []request.CasbinInfo{{Path: "/backend/msg/update", Method: "PUT"}}))
+	// 9528 已经分配过新路径
+	require.NoError(t, CasbinServiceApp.UpdateCasbin("9528", []request.CasbinInfo{
+		{Path: "/backend/msg/update", Method: "PUT"},
+		{Path: "/backend/msg/update/:id", Method: "PUT"},
+	}))
+
+	s := ApiServiceApp
+	require.NoError(t, s.MigrateRenamedApis())
+	var apis []system.SysApi
+	require.NoError(t, global.DB.Find(&apis).Error)
+	require.Len(t, apis, 1)
+	assert.Equal(t, "/backend/msg/update/:id", apis[0].Path)
+	for _, id := range []string{"888", "9528"} {
+		ok, err := CasbinServiceApp.Casbin().Enforce(id, "/backend/msg/update/1", "PUT")
+		require.NoError(t, err)
+		assert.True(t, ok, id)
+		assert.Len(t, CasbinServiceApp.GetPolicyPathByAuthorityId(id), 1, id)
+	}
+
+	// 重复执行不做修改
+	require.NoError(t, s.MigrateRenamedApis())
+	require.NoError(t, global.DB.Find(&apis).Error)
+	assert.Len(t, apis, 1)
+}
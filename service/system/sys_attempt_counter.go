@@ -0,0 +1,92 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	global "server/model"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// attemptCounter 固定时间窗口内的次数统计, 用于限流和失败锁定
+// 开启 redis 时多个实例共享计数, 否则退化为进程内计数
+type attemptCounter struct {
+	prefix string
+	mu     sync.Mutex
+	local  map[string]localAttempt
+}
+
+type localAttempt struct {
+	count     int64
+	expiresAt time.Time
+}
+
+// 进程内计数超过该数量时顺带清理过期的 key
+const attemptSweepSize = 10000
+
+func newAttemptCounter(prefix string) *attemptCounter {
+	return &attemptCounter{prefix: prefix, local: make(map[string]localAttempt)}
+}
+
+// Incr 计数加一并返回窗口内的次数, 窗口从第一次计数开始
+func (a *attemptCounter) Incr(key string, window time.Duration) (int64, error) {
+	key = a.prefix + key
+	if global.REDIS != nil {
+		ctx := context.Background()
+		n, err := global.REDIS.Incr(ctx, key).Result()
+		if err == nil && n == 1 {
+			err = global.REDIS.Expire(ctx, key, window).Err()
+		}
+		return n, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := time.Now()
+	if len(a.local) >= attemptSweepSize {
+		for k, v := range a.local {
+			if now.After(v.expiresAt) {
+				delete(a.local, k)
+			}
+		}
+	}
+	v, ok := a.local[key]
+	if !ok || now.After(v.expiresAt) {
+		v = localAttempt{expiresAt: now.Add(window)}
+	}
+	v.count++
+	a.local[key] = v
+	return v.count, nil
+}
+
+// Count 窗口内的次数
+func (a *attemptCounter) Count(key string) (int64, error) {
+	key = a.prefix + key
+	if global.REDIS != nil {
+		n, err := global.REDIS.Get(context.Background(), key).Int64()
+		if errors.Is(err, redis.Nil) {
+			return 0, nil
+		}
+		return n, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if v, ok := a.local[key]; ok && time.Now().Before(v.expiresAt) {
+		return v.count, nil
+	}
+	return 0, nil
+}
+
+// Reset 清除计数
+func (a *attemptCounter) Reset(key string) error {
+	key = a.prefix + key
+	if global.REDIS != nil {
+		return global.REDIS.Del(context.Background(), key).Err()
+	}
+	a.mu.Lock()
+	delete(a.local, key)
+	a.mu.Unlock()
+	return nil
+}
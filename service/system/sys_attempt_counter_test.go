@@ -0,0 +1,34 @@
+package system
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttemptCounterLocal(t *testing.T) {
+	counter := newAttemptCounter("test:")
+	for i := int64(1); i <= 3; i++ {
+		n, err := counter.Incr("k", 50*time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, i, n)
+	}
+	n, err := counter.Count("k")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+
+	time.Sleep(60 * time.Millisecond)
+	n, err = counter.Count("k")
+	require.NoError(t, err)
+	assert.Zero(t, n, "窗口结束后重新计数")
+	n, err = counter.Incr("k", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	require.NoError(t, counter.Reset("k"))
+	n, err = counter.Count("k")
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
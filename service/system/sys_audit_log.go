@@ -0,0 +1,41 @@
+package system
+
+import (
+	global "server/model"
+	"server/model/system"
+	systemReq "server/model/system/request"
+)
+
+//@function: CreateAuditLog
+//@description: 写入一条审计记录
+//@param: log *system.SysAuditLog
+//@return: error
+
+func (a *AuditLogService) CreateAuditLog(log *system.SysAuditLog) error {
+	return global.DB.Create(log).Error
+}
+
+//@function: GetAuditLogList
+//@description: 分页获取审计记录
+//@param: info systemReq.SysAuditLogSearch
+//@return: list []system.SysAuditLog, total int64, err error
+
+func (a *AuditLogService) GetAuditLogList(info systemReq.SysAuditLogSearch) (list []system.SysAuditLog, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.DB.Model(&system.SysAuditLog{})
+	if info.Action != "" {
+		db = db.Where("action = ?", info.Action)
+	}
+	if info.Resource != "" {
+		db = db.Where("resource = ?", info.Resource)
+	}
+	if info.UserId != 0 {
+		db = db.Where("user_id = ?", info.UserId)
+	}
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Order("id desc").Limit(limit).Offset(offset).Find(&list).Error
+	return list, total, err
+}
@@ -23,8 +23,15 @@ func (authorityService *AuthorityService) CreateAuthority(auth system.SysAuthori
 	if !errors.Is(global.DB.Where("authority_id = ?", auth.AuthorityId).First(&authorityBox).Error, gorm.ErrRecordNotFound) {
 		return auth, errors.New("存在相同角色id")
 	}
+	// 未指定资源权限时默认可以查看本角色的数据, 否则列表只能看到本人的数据
+	if len(auth.DataAuthorityId) == 0 {
+		auth.DataAuthorityId = []*system.SysAuthority{{AuthorityId: auth.AuthorityId}}
+	}
 	err = global.DB.Create(&auth).Error
-	return auth, err
+	if err != nil {
+		return auth, err
+	}
+	return auth, CasbinServiceApp.UpdateParent(auth.AuthorityId, auth.ParentId)
 }
 
 //
@@ -72,6 +79,9 @@ func (authorityService *AuthorityService) CopyAuthority(copyInfo response.SysAut
 	}
 	paths := CasbinServiceApp.GetPolicyPathByAuthorityId(copyInfo.OldAuthorityId)
 	err = CasbinServiceApp.UpdateCasbin(copyInfo.Authority.AuthorityId, paths)
+	if err == nil {
+		err = CasbinServiceApp.UpdateParent(copyInfo.Authority.AuthorityId, copyInfo.Authority.ParentId)
+	}
 	if err != nil {
 		_ = authorityService.DeleteAuthority(&copyInfo.Authority)
 	}
@@ -85,8 +95,18 @@ func (authorityService *AuthorityService) CopyAuthority(copyInfo response.SysAut
 //@return: err error, authority model.SysAuthority
 
 func (authorityService *AuthorityService) UpdateAuthority(auth system.SysAuthority) (authority system.SysAuthority, err error) {
-	err = global.DB.Where("authority_id = ?", auth.AuthorityId).First(&system.SysAuthority{}).Updates(&auth).Error
-	return auth, err
+	if err = authorityService.checkParent(auth.AuthorityId, auth.ParentId); err != nil {
+		return auth, err
+	}
+	var old system.SysAuthority
+	if err = global.DB.Where("authority_id = ?", auth.AuthorityId).First(&old).Error; err != nil {
+		return auth, err
+	}
+	err = global.DB.Model(&old).Updates(&auth).Error
+	if err != nil || auth.ParentId == "" {
+		return auth, err
+	}
+	return auth, CasbinServiceApp.UpdateParent(auth.AuthorityId, auth.ParentId)
 }
 
 //
@@ -131,6 +151,7 @@ func (authorityService *AuthorityService) DeleteAuthority(auth *system.SysAuthor
 	}
 	err = global.DB.Delete(&[]system.SysAuthorityBtn{}, "authority_id = ?", auth.AuthorityId).Error
 	CasbinServiceApp.ClearCasbin(0, auth.AuthorityId)
+	CasbinServiceApp.ClearParent(auth.AuthorityId)
 	return err
 }
 
@@ -213,3 +234,26 @@ func (authorityService *AuthorityService) findChildrenAuthority(authority *syste
 	}
 	return err
 }
+
+//
+//@function: checkParent
+//@description: 父角色不能是自己或自己的子角色, 否则权限继承成环
+//@param: authorityId string, parentId string
+//@return: err error
+
+func (authorityService *AuthorityService) checkParent(authorityId string, parentId string) error {
+	for id := parentId; hasParentAuthority(id); {
+		if id == authorityId {
+			return errors.New("父角色不能是自己或自己的子角色")
+		}
+		var parent system.SysAuthority
+		if err := global.DB.Select("authority_id", "parent_id").Where("authority_id = ?", id).First(&parent).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("父角色不存在")
+			}
+			return err
+		}
+		id = parent.ParentId
+	}
+	return nil
+}
@@ -6,7 +6,10 @@ import (
 	"server/model/system"
 	"server/model/system/request"
 	"server/model/system/response"
+	"sync"
+	"time"
 
+	"github.com/casbin/casbin/v3/util"
 	"gorm.io/gorm"
 )
 
@@ -25,6 +28,7 @@ func (a *AuthorityBtnService) GetAuthorityBtn(req request.SysAuthorityBtnReq) (r
 }
 
 func (a *AuthorityBtnService) SetAuthorityBtn(req request.SysAuthorityBtnReq) (err error) {
+	defer ClearBtnAuthCache()
 	return global.DB.Transaction(func(tx *gorm.DB) error {
 		var authorityBtn []system.SysAuthorityBtn
 		err = tx.Delete(&[]system.SysAuthorityBtn{}, "authority_id = ? and sys_menu_id = ?", req.AuthorityId, req.MenuID).Error
@@ -55,3 +59,146 @@ func (a *AuthorityBtnService) CanRemoveAuthorityBtn(ID int) (err error) {
 	}
 	return errors.New("此按钮正在被使用无法删除")
 }
+
+//@function: GetBtnApis
+//@description: 获取按钮绑定的接口
+//@param: btnId uint
+//@return: apis []system.SysApi, err error
+
+func (a *AuthorityBtnService) GetBtnApis(btnId uint) (apis []system.SysApi, err error) {
+	var btn system.SysBaseMenuBtn
+	err = global.DB.Preload("Apis").First(&btn, "id = ?", btnId).Error
+	return btn.Apis, err
+}
+
+//@function: SetBtnApis
+//@description: 设置按钮绑定的接口, 绑定后调用这些接口时要求角色拥有该按钮
+//@param: btnId uint, apiIds []uint
+//@return: err error
+
+func (a *AuthorityBtnService) SetBtnApis(btnId uint, apiIds []uint) (err error) {
+	var btn system.SysBaseMenuBtn
+	if err = global.DB.First(&btn, "id = ?", btnId).Error; err != nil {
+		return err
+	}
+	apis := []system.SysApi{}
+	if len(apiIds) > 0 {
+		if err = global.DB.Find(&apis, "id in ?", apiIds).Error; err != nil {
+			return err
+		}
+	}
+	defer ClearBtnAuthCache()
+	return global.DB.Model(&btn).Association("Apis").Replace(apis)
+}
+
+//@function: CheckBtnAuth
+//@description: 接口绑定了按钮时, 角色(含继承的父角色)必须拥有其中一个按钮, 未绑定按钮的接口不限制
+//@param: authorityId string, path string, method string
+//@return: bool, error
+
+func (a *AuthorityBtnService) CheckBtnAuth(authorityId string, path string, method string) (bool, error) {
+	index, err := loadBtnAuthIndex()
+	if err != nil {
+		return false, err
+	}
+	var roles []string
+	required := false
+	for _, rule := range index.rules {
+		if rule.method != method || !util.KeyMatch2(path, rule.path) {
+			continue
+		}
+		if !required {
+			required = true
+			roles, _ = CasbinServiceApp.Casbin().GetImplicitRolesForUser(authorityId)
+			roles = append(roles, authorityId)
+		}
+		for _, role := range roles {
+			for _, btnId := range rule.btnIds {
+				if index.holders[role][btnId] {
+					return true, nil
+				}
+			}
+		}
+	}
+	return !required, nil
+}
+
+// btnAuthIndex 绑定了按钮的接口和持有这些按钮的角色, 数据量小, 整体缓存
+type btnAuthIndex struct {
+	rules   []btnAuthRule
+	holders map[string]map[uint]bool // authorityId -> 按钮id
+}
+
+type btnAuthRule struct {
+	path   string
+	method string
+	btnIds []uint
+}
+
+// btnAuthTTL 本实例的修改会立即清除缓存, 其他实例最多延迟这么久生效
+const btnAuthTTL = time.Minute
+
+var (
+	btnAuthMu       sync.RWMutex
+	btnAuthCache    *btnAuthIndex
+	btnAuthLoadedAt time.Time
+)
+
+// ClearBtnAuthCache 按钮、角色按钮或按钮绑定的接口变化后调用
+func ClearBtnAuthCache() {
+	btnAuthMu.Lock()
+	btnAuthCache = nil
+	btnAuthMu.Unlock()
+}
+
+func loadBtnAuthIndex() (*btnAuthIndex, error) {
+	btnAuthMu.RLock()
+	index, loadedAt := btnAuthCache, btnAuthLoadedAt
+	btnAuthMu.RUnlock()
+	if index != nil && time.Since(loadedAt) < btnAuthTTL {
+		return index, nil
+	}
+	var links []struct {
+		SysBaseMenuBtnId uint
+		Path             string
+		Method           string
+	}
+	err := global.DB.Table("sys_menu_btn_apis").
+		Select("sys_menu_btn_apis.sys_base_menu_btn_id, sys_apis.path, sys_apis.method").
+		Joins("JOIN sys_apis ON sys_apis.id = sys_menu_btn_apis.sys_api_id AND sys_apis.deleted_at IS NULL").
+		Joins("JOIN sys_base_menu_btns ON sys_base_menu_btns.id = sys_menu_btn_apis.sys_base_menu_btn_id AND sys_base_menu_btns.deleted_at IS NULL").
+		Scan(&links).Error
+	if err != nil {
+		return nil, err
+	}
+	index = &btnAuthIndex{holders: map[string]map[uint]bool{}}
+	rules := map[string]*btnAuthRule{}
+	var btnIds []uint
+	for _, link := range links {
+		key := link.Method + " " + link.Path
+		if rules[key] == nil {
+			rules[key] = &btnAuthRule{path: link.Path, method: link.Method}
+		}
+		rules[key].btnIds = append(rules[key].btnIds, link.SysBaseMenuBtnId)
+		btnIds = append(btnIds, link.SysBaseMenuBtnId)
+	}
+	for _, rule := range rules {
+		index.rules = append(index.rules, *rule)
+	}
+	if len(btnIds) > 0 {
+		var holders []system.SysAuthorityBtn
+		if err = global.DB.Select("authority_id", "sys_base_menu_btn_id").Find(&holders, "sys_base_menu_btn_id in ?", btnIds).Error; err != nil {
+			return nil, err
+		}
+		for _, holder := range holders {
+			if index.holders[holder.AuthorityId] == nil {
+				index.holders[holder.AuthorityId] = map[uint]bool{}
+			}
+			index.holders[holder.AuthorityId][holder.SysBaseMenuBtnID] = true
+		}
+	}
+	btnAuthMu.Lock()
+	btnAuthCache, btnAuthLoadedAt = index, time.Now()
+	btnAuthMu.Unlock()
+	return index, nil
+}
@@ -0,0 +1,65 @@
+package system
+
+import (
+	"testing"
+
+	global "server/model"
+	"server/model/system"
+	"server/model/system/request"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBtnAuth(t *testing.T) {
+	setupCasbinTest(t, false)
+	require.NoError(t, global.DB.AutoMigrate(&system.SysApi{}, &system.SysBaseMenuBtn{}, &system.SysAuthorityBtn{}))
+	ClearBtnAuthCache()
+	t.Cleanup(ClearBtnAuthCache)
+	s := AuthorityBtnServiceApp
+	_, err := AuthorityServiceApp.CreateAuthority(system.SysAuthority{AuthorityId: "888", ParentId: "0"})
+	require.NoError(t, err)
+	_, err = AuthorityServiceApp.CreateAuthority(system.SysAuthority{AuthorityId: "8881", ParentId: "888"})
+	require.NoError(t, err)
+	apis := []system.SysApi{
+		{Path: "/backend/user/deleteUser", Method: "DELETE"},
+		{Path: "/backend/user/:id", Method: "GET"},
+	}
+	require.NoError(t, global.DB.Create(&apis).Error)
+	btn := system.SysBaseMenuBtn{Name: "delete", SysBaseMenuID: 1}
+	require.NoError(t, global.DB.Create(&btn).Error)
+	require.NoError(t, s.SetAuthorityBtn(request.SysAuthorityBtnReq{AuthorityId: "888", MenuID: 1, Selected: []uint{btn.ID}}))
+
+	// 未绑定接口时不限制
+	ok, err := s.CheckBtnAuth("999", "/backend/user/deleteUser", "DELETE")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	require.NoError(t, s.SetBtnApis(btn.ID, []uint{apis[0].ID, apis[1].ID}))
+	bound, err := s.GetBtnApis(btn.ID)
+	require.NoError(t, err)
+	assert.Len(t, bound, 2)
+	for _, c := range []struct {
+		authorityId, path, method string
+		ok                        bool
+	}{
+		{"999", "/backend/user/deleteUser", "DELETE", false},
+		{"999", "/backend/user/7", "GET", false},
+		{"888", "/backend/user/deleteUser", "DELETE", true},
+		{"8881", "/backend/user/deleteUser", "DELETE", true}, // 继承父角色的按钮
+		{"999", "/backend/api/getApiList", "GET", true},
+	} {
+		ok, err = s.CheckBtnAuth(c.authorityId, c.path, c.method)
+		require.NoError(t, err)
+		assert.Equal(t, c.ok, ok, "%s %s %s", c.authorityId, c.method, c.path)
+	}
+
+	// 取消角色的按钮后立即生效
+	require.NoError(t, s.SetAuthorityBtn(request.SysAuthorityBtnReq{AuthorityId: "888", MenuID: 1}))
+	ok, _ = s.CheckBtnAuth("888", "/backend/user/deleteUser", "DELETE")
+	assert.False(t, ok)
+
+	require.NoError(t, s.SetBtnApis(btn.ID, nil))
+	ok, _ = s.CheckBtnAuth("999", "/backend/user/deleteUser", "DELETE")
+	assert.True(t, ok)
+}
@@ -38,6 +38,9 @@ const (
 	packageAPIName     = "api/v1"
 )
 
+// DataAuthorityColumn 自动化代码中记录创建人的列
+const DataAuthorityColumn = "created_by"
+
 type autoPackage struct {
 	path string
 	temp string
@@ -141,6 +144,7 @@ type tplData struct {
 
 func (autoCodeService *AutoCodeService) PreviewTemp(autoCode system.AutoCodeStruct) (map[string]string, error) {
 	makeDictTypes(&autoCode)
+	makeDataAuthority(&autoCode)
 	dataList, _, needMkdir, err := autoCodeService.getNeedList(&autoCode)
 	if err != nil {
 		return nil, err
@@ -212,6 +216,26 @@ func makeDictTypes(autoCode *system.AutoCodeStruct) {
 	}
 }
 
+// makeDataAuthority 开启资源权限过滤时补充记录创建人字段, 生成的列表查询以该列调用 DataScope
+func makeDataAuthority(autoCode *system.AutoCodeStruct) {
+	if !autoCode.DataAuthority {
+		return
+	}
+	for _, v := range autoCode.Fields {
+		if v.ColumnName == DataAuthorityColumn {
+			return
+		}
+	}
+	autoCode.Fields = append(autoCode.Fields, &system.Field{
+		FieldName:  "CreatedBy",
+		FieldDesc:  "创建者",
+		FieldType:  "uint",
+		FieldJson:  "createdBy",
+		ColumnName: DataAuthorityColumn,
+		Comment:    "创建者",
+	})
+}
+
 //@author: wuhao
 //@function: CreateTemp
 //@description: 创建代码
@@ -220,6 +244,7 @@ func makeDictTypes(autoCode *system.AutoCodeStruct) {
 
 func (autoCodeService *AutoCodeService) CreateTemp(autoCode system.AutoCodeStruct, ids ...uint) (err error) {
 	makeDictTypes(&autoCode)
+	makeDataAuthority(&autoCode)
 	// 增加判断: 重复创建struct
 	if autoCode.AutoMoveFile && AutoCodeHistoryServiceApp.Repeat(autoCode.StructName, autoCode.Package) {
 		return ErrRepeat
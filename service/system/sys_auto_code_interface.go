@@ -17,6 +17,8 @@ func (autoCodeService *AutoCodeService) Database() Database {
 		return AutoCodeMysql
 	case "pgsql":
 		return AutoCodePgsql
+	case "sqlite":
+		return AutoCodeSqlite
 	default:
 		return AutoCodeMysql
 	}
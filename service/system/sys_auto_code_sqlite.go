@@ -0,0 +1,48 @@
+package system
+
+import (
+	"strings"
+
+	global "server/model"
+	"server/model/system/response"
+)
+
+// GetDB 获取数据库的所有数据库名
+// sqlite 一个文件即一个数据库, 返回当前连接中 attach 的数据库
+func (s *autoCodeSqlite) GetDB() (data []response.Db, err error) {
+	var entities []response.Db
+	sql := `SELECT name AS "database" FROM pragma_database_list`
+	err = global.DB.Raw(sql).Scan(&entities).Error
+	return entities, err
+}
+
+// GetTables 获取数据库的所有表名
+func (s *autoCodeSqlite) GetTables(dbName string) (data []response.Table, err error) {
+	var entities []response.Table
+	sql := `SELECT name AS table_name FROM ` + sqliteSchema(dbName) + `.sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'`
+	err = global.DB.Raw(sql).Scan(&entities).Error
+	return entities, err
+}
+
+// GetColumn 获取指定数据库和指定数据表的所有字段名,类型值等
+// sqlite 没有字段注释, column_comment 返回空
+func (s *autoCodeSqlite) GetColumn(tableName string, dbName string) (data []response.Column, err error) {
+	var entities []response.Column
+	sql := `
+	SELECT name AS column_name,
+	       lower(CASE WHEN instr(type, '(') > 0 THEN substr(type, 1, instr(type, '(') - 1) ELSE type END) AS data_type,
+	       CASE WHEN instr(type, '(') > 0 THEN substr(type, instr(type, '(') + 1, length(type) - instr(type, '(') - 1) ELSE '' END AS data_type_long,
+	       '' AS column_comment
+	FROM pragma_table_info(?, ?)
+	`
+	err = global.DB.Raw(sql, tableName, sqliteSchema(dbName)).Scan(&entities).Error
+	return entities, err
+}
+
+// sqliteSchema 前端传入的可能是配置中的库名, 统一转换为 sqlite 能识别的 schema 名
+func sqliteSchema(dbName string) string {
+	if dbName == "" || dbName == global.CONFIG.Sqlite.Dbname {
+		return "main"
+	}
+	return `"` + strings.ReplaceAll(dbName, `"`, `""`) + `"`
+}
@@ -0,0 +1,71 @@
+package system
+
+import (
+	"go/format"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"server/model/system"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// renderServerTemplates 渲染 resource/template/server 下的模板, 返回文件名到代码的映射, 需在仓库根目录执行
+func renderServerTemplates(t *testing.T, autoCode system.AutoCodeStruct) map[string]string {
+	makeDictTypes(&autoCode)
+	makeDataAuthority(&autoCode)
+	dataList, _, _, err := new(AutoCodeService).getNeedList(&autoCode)
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, data := range dataList {
+		if !strings.HasPrefix(data.locationPath, basePath+"/server/") {
+			continue
+		}
+		var b strings.Builder
+		require.NoError(t, data.template.Execute(&b, autoCode))
+		source, err := format.Source([]byte(b.String()))
+		require.NoError(t, err, b.String())
+		files[filepath.Base(data.locationPath)] = string(source)
+	}
+	return files
+}
+
+func TestAutoCodeTemplateDataAuthority(t *testing.T) {
+	t.Chdir("../..")
+	autoCode := system.AutoCodeStruct{
+		StructName:      "Book",
+		TableName:       "books",
+		PackageName:     "book",
+		HumpPackageName: "book",
+		Abbreviation:    "book",
+		Description:     "图书",
+		Package:         "library",
+		DataAuthority:   true,
+		Fields: []*system.Field{
+			{FieldName: "Title", FieldType: "string", FieldJson: "title", ColumnName: "title", FieldSearchType: "LIKE"},
+			{FieldName: "PublishedAt", FieldType: "time.Time", FieldJson: "publishedAt", ColumnName: "published_at", FieldSearchType: ">"},
+		},
+	}
+	files := renderServerTemplates(t, autoCode)
+	require.Len(t, files, 5)
+
+	// 开启后模型增加创建人, 创建时写入当前用户, 列表按资源权限过滤
+	assert.Contains(t, files["model.go.tpl"], `CreatedBy   uint      `+"`"+`json:"createdBy" form:"createdBy" gorm:"column:created_by;comment:创建者;index;"`)
+	assert.Contains(t, files["model.go.tpl"], `"time"`)
+	assert.Contains(t, files["api.go.tpl"], "book.CreatedBy, err = utils.GetUserID(c)")
+	assert.Contains(t, files["api.go.tpl"], `bookService.GetBookInfoList(pageInfo, systemService.AuthorityServiceApp.DataScope(utils.GetUserInfo(c), "created_by"))`)
+	assert.Contains(t, files["service.go.tpl"], "global.DB.Model(&library.Book{}).Scopes(scopes...)")
+	assert.Contains(t, files["service.go.tpl"], `db.Where("title LIKE ?", "%"+info.Title+"%")`)
+	assert.Contains(t, files["service.go.tpl"], `Omit("created_by", "created_at")`)
+
+	// 未开启时不生成相关代码
+	autoCode.DataAuthority = false
+	autoCode.Fields = autoCode.Fields[:1]
+	files = renderServerTemplates(t, autoCode)
+	assert.NotContains(t, files["model.go.tpl"], "CreatedBy")
+	assert.NotContains(t, files["model.go.tpl"], `"time"`)
+	assert.NotContains(t, files["api.go.tpl"], "DataScope")
+	assert.NotContains(t, files["service.go.tpl"], "gorm.io/gorm")
+}
@@ -16,6 +16,7 @@ import (
 //@return: err error
 
 func (baseMenuService *BaseMenuService) DeleteBaseMenu(id int) (err error) {
+	defer ClearBtnAuthCache()
 	err = global.DB.Preload("MenuBtn").Preload("Parameters").Where("parent_id = ?", id).First(&system.SysBaseMenu{}).Error
 	if err != nil {
 		var menu system.SysBaseMenu
@@ -53,6 +54,7 @@ func (baseMenuService *BaseMenuService) DeleteBaseMenu(id int) (err error) {
 //@return: err error
 
 func (baseMenuService *BaseMenuService) UpdateBaseMenu(menu system.SysBaseMenu) (err error) {
+	defer ClearBtnAuthCache() // 按钮被重建, 绑定的接口随之变化
 	var oldMenu system.SysBaseMenu
 	upDateMap := make(map[string]any)
 	upDateMap["keep_alive"] = menu.KeepAlive
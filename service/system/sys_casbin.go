@@ -2,8 +2,15 @@ package system
 
 import (
 	"errors"
+	"fmt"
+	"server/model/system"
 	"server/model/system/request"
+	"server/model/system/response"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	global "server/model"
 
@@ -20,12 +27,13 @@ import (
 //@return: error
 
 func (casbinService *CasbinService) UpdateCasbin(authorityId string, casbinInfos []request.CasbinInfo) error {
-	casbinService.ClearCasbin(0, authorityId)
+	e := casbinService.Casbin()
+	defer casbinService.policyChanged()
+	_, _ = e.RemoveFilteredPolicy(0, authorityId)
 	rules := [][]string{}
 	for _, v := range casbinInfos {
 		rules = append(rules, []string{authorityId, v.Path, v.Method})
 	}
-	e := casbinService.Casbin()
 	success, _ := e.AddPolicies(rules)
 	if !success {
 		return errors.New("存在相同api,添加失败,请联系管理员")
@@ -44,7 +52,11 @@ func (casbinService *CasbinService) UpdateCasbinApi(oldPath string, newPath stri
 		"v1": newPath,
 		"v2": newMethod,
 	}).Error
-	return err
+	if err != nil {
+		return err
+	}
+	// 直接改了数据库, 需要重新加载
+	return casbinService.Reload()
 }
 
 //
@@ -65,6 +77,175 @@ func (casbinService *CasbinService) GetPolicyPathByAuthorityId(authorityId strin
 	return pathMaps
 }
 
+//
+//@function: GetEffectivePolicy
+//@description: 获取角色自身和从父角色继承的全部 api 权限
+//@param: authorityId string
+//@return: []response.EffectivePolicy, error
+
+func (casbinService *CasbinService) GetEffectivePolicy(authorityId string) ([]response.EffectivePolicy, error) {
+	list, err := casbinService.Casbin().GetImplicitPermissionsForUser(authorityId)
+	if err != nil {
+		return nil, err
+	}
+	policies := make([]response.EffectivePolicy, 0, len(list))
+	for _, v := range list {
+		policies = append(policies, response.EffectivePolicy{
+			Path:        v[1],
+			Method:      v[2],
+			AuthorityId: v[0],
+			Inherited:   v[0] != authorityId,
+		})
+	}
+	sort.SliceStable(policies, func(i, j int) bool {
+		if policies[i].Path != policies[j].Path {
+			return policies[i].Path < policies[j].Path
+		}
+		return policies[i].Method < policies[j].Method
+	})
+	return policies, nil
+}
+
+//
+//@function: UpdateParent
+//@description: 按父角色生成 g 规则, 子角色继承父角色的 api 权限
+//@param: authorityId string, parentId string
+//@return: error
+
+func (casbinService *CasbinService) UpdateParent(authorityId string, parentId string) error {
+	e := casbinService.Casbin()
+	defer casbinService.policyChanged()
+	if _, err := e.RemoveFilteredGroupingPolicy(0, authorityId); err != nil {
+		return err
+	}
+	if !hasParentAuthority(parentId) {
+		return nil
+	}
+	_, err := e.AddGroupingPolicy(authorityId, parentId)
+	return err
+}
+
+//
+//@function: ClearParent
+//@description: 删除角色时清除与其相关的 g 规则
+//@param: authorityId string
+
+func (casbinService *CasbinService) ClearParent(authorityId string) {
+	e := casbinService.Casbin()
+	_, _ = e.RemoveFilteredGroupingPolicy(0, authorityId)
+	_, _ = e.RemoveFilteredGroupingPolicy(1, authorityId)
+	casbinService.policyChanged()
+}
+
+//
+//@function: PreviewAuthorityTree
+//@description: 对比角色树和现有的 g 规则, 列出同步后新增和删除的继承关系及其影响的 api 权限, 不做修改
+//@return: result response.AuthorityTreeSyncResponse, err error
+
+func (casbinService *CasbinService) PreviewAuthorityTree() (result response.AuthorityTreeSyncResponse, err error) {
+	add, remove, err := casbinService.authorityTreeDiff()
+	if err != nil {
+		return result, err
+	}
+	e := casbinService.Casbin()
+	inherit := func(rule []string) (response.AuthorityInherit, error) {
+		item := response.AuthorityInherit{AuthorityId: rule[0], ParentId: rule[1], Apis: []request.CasbinInfo{}}
+		// 按当前生效的规则计算父角色的权限, 子角色自身已有的不算在内
+		list, err := e.GetImplicitPermissionsForUser(rule[1])
+		if err != nil {
+			return item, err
+		}
+		for _, v := range list {
+			if has, _ := e.HasPolicy(rule[0], v[1], v[2]); !has {
+				item.Apis = append(item.Apis, request.CasbinInfo{Path: v[1], Method: v[2]})
+			}
+		}
+		return item, nil
+	}
+	result.Add, result.Remove = []response.AuthorityInherit{}, []response.AuthorityInherit{}
+	for _, rule := range add {
+		item, err := inherit(rule)
+		if err != nil {
+			return result, err
+		}
+		result.Add = append(result.Add, item)
+	}
+	for _, rule := range remove {
+		item, err := inherit(rule)
+		if err != nil {
+			return result, err
+		}
+		result.Remove = append(result.Remove, item)
+	}
+	return result, nil
+}
+
+//
+//@function: SyncAuthorityTree
+//@description: 按角色树校正全部 g 规则, 补齐升级前已有的父子关系; 子角色会获得父角色的全部权限, 由管理员预览后手动执行
+//@return: error
+
+func (casbinService *CasbinService) SyncAuthorityTree() error {
+	add, remove, err := casbinService.authorityTreeDiff()
+	if err != nil || (len(remove) == 0 && len(add) == 0) {
+		return err
+	}
+	e := casbinService.Casbin()
+	defer casbinService.policyChanged()
+	if len(remove) > 0 {
+		if _, err = e.RemoveGroupingPolicies(remove); err != nil {
+			return err
+		}
+	}
+	if len(add) > 0 {
+		_, err = e.AddGroupingPolicies(add)
+	}
+	return err
+}
+
+// authorityTreeDiff 角色树中有但缺少的 g 规则和角色树中已经不存在的 g 规则, 按角色id排序
+func (casbinService *CasbinService) authorityTreeDiff() (add, remove [][]string, err error) {
+	var authorities []system.SysAuthority
+	if err = global.DB.Select("authority_id", "parent_id").Find(&authorities).Error; err != nil {
+		return nil, nil, err
+	}
+	want := map[[2]string]bool{}
+	for _, authority := range authorities {
+		if hasParentAuthority(authority.ParentId) {
+			want[[2]string{authority.AuthorityId, authority.ParentId}] = true
+		}
+	}
+	existing, err := casbinService.Casbin().GetGroupingPolicy()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, rule := range existing {
+		key := [2]string{rule[0], rule[1]}
+		if want[key] {
+			delete(want, key)
+			continue
+		}
+		remove = append(remove, rule)
+	}
+	for key := range want {
+		add = append(add, []string{key[0], key[1]})
+	}
+	byAuthority := func(a, b []string) int {
+		if a[0] != b[0] {
+			return strings.Compare(a[0], b[0])
+		}
+		return strings.Compare(a[1], b[1])
+	}
+	slices.SortFunc(add, byAuthority)
+	slices.SortFunc(remove, byAuthority)
+	return add, remove, nil
+}
+
+// hasParentAuthority 顶级角色的 ParentId 为 "0"
+func hasParentAuthority(parentId string) bool {
+	return parentId != "" && parentId != "0"
+}
+
 //
 //@function: ClearCasbin
 //@description: 清除匹配的权限
@@ -74,38 +255,127 @@ func (casbinService *CasbinService) GetPolicyPathByAuthorityId(authorityId strin
 func (casbinService *CasbinService) ClearCasbin(v int, p ...string) bool {
 	e := casbinService.Casbin()
 	success, _ := e.RemoveFilteredPolicy(v, p...)
+	casbinService.policyChanged()
 	return success
 }
 
 //
-//@function: Casbin
-//@description: 持久化到数据库  引入自定义规则
-//@return: *casbin.Enforcer
+//@function: Reload
+//@description: 从数据库重新加载策略并通知其他实例
+//@return: error
+
+func (casbinService *CasbinService) Reload() error {
+	if err := casbinService.Casbin().LoadPolicy(); err != nil {
+		return err
+	}
+	casbinService.notify()
+	return nil
+}
+
+// policyChanged 通过 Enforcer 修改策略后, 清空鉴权缓存并通知其他实例
+func (casbinService *CasbinService) policyChanged() {
+	_ = casbinService.Casbin().InvalidateCache()
+	casbinService.notify()
+}
+
+func (casbinService *CasbinService) notify() {
+	if casbinWatch == nil {
+		return
+	}
+	if err := casbinWatch.Update(); err != nil {
+		global.LOG.Error("casbin 策略变更通知失败", zap.Error(err))
+	}
+}
+
+//
+//@function: Init
+//@description: 启动时加载策略, 数据库暂时不可用时按间隔重试, 仍然失败返回错误由调用方终止启动
+//@return: error
 
 var (
-	syncedEnforcer *casbin.SyncedEnforcer
-	once           sync.Once
+	syncedEnforcer *casbin.SyncedCachedEnforcer
+	casbinWatch    *casbinWatcher
+	casbinMu       sync.RWMutex
 )
 
-func (casbinService *CasbinService) Casbin() *casbin.SyncedEnforcer {
-	once.Do(func() {
-		defer func() {
-			if r := recover(); r != nil {
-				// gorm-adapter 在策略为空或 ptype 为空时会 panic: slice bounds out of range
-				// 回退为仅模型、无 DB 策略的 Enforcer，避免服务崩溃
-				global.LOG.Warn("casbin 从数据库加载策略时发生 panic，使用空策略 Enforcer。请检查 casbin_rule 表：ptype 字段不能为空，且无异常数据", zap.Any("panic", r))
-				var err error
-				syncedEnforcer, err = casbin.NewSyncedEnforcer(global.CONFIG.Casbin.ModelPath)
-				if err != nil {
-					global.LOG.Error("casbin 回退 Enforcer 创建失败", zap.Error(err))
-				}
-			}
-		}()
-		a, _ := gormadapter.NewAdapterByDB(global.DB)
-		syncedEnforcer, _ = casbin.NewSyncedEnforcer(global.CONFIG.Casbin.ModelPath, a)
-	})
+// casbinLoadAttempts 启动时加载策略的次数, 第 n 次失败后等待 n 倍 casbinLoadBackoff 再重试
+const casbinLoadAttempts = 5
+
+var casbinLoadBackoff = time.Second
+
+func (casbinService *CasbinService) Init() error {
+	var err error
+	for i := 1; i <= casbinLoadAttempts; i++ {
+		if _, err = loadEnforcer(); err == nil {
+			return nil
+		}
+		global.LOG.Warn("casbin 加载策略失败", zap.Int("attempt", i), zap.Error(err))
+		if i < casbinLoadAttempts {
+			time.Sleep(casbinLoadBackoff * time.Duration(i))
+		}
+	}
+	return err
+}
+
+//
+//@function: Casbin
+//@description: 持久化到数据库  引入自定义规则, 加载成功后不再重复加载, 之后由策略变更和 watcher 触发重新加载; 加载失败返回 nil, 下次调用重新加载
+//@return: *casbin.SyncedCachedEnforcer
+
+func (casbinService *CasbinService) Casbin() *casbin.SyncedCachedEnforcer {
+	casbinMu.RLock()
+	e := syncedEnforcer
+	casbinMu.RUnlock()
+	if e != nil {
+		return e
+	}
+	e, err := loadEnforcer()
+	if err != nil {
+		global.LOG.Error("casbin 加载策略失败", zap.Error(err))
+	}
+	return e
+}
+
+// loadEnforcer 加载成功才保存 Enforcer, 不会退化成没有策略的 Enforcer
+func loadEnforcer() (*casbin.SyncedCachedEnforcer, error) {
+	casbinMu.Lock()
+	defer casbinMu.Unlock()
 	if syncedEnforcer != nil {
-		_ = syncedEnforcer.LoadPolicy()
+		return syncedEnforcer, nil
+	}
+	e, err := newEnforcer()
+	if err != nil {
+		return nil, err
+	}
+	e.EnableCache(global.CONFIG.Casbin.Cache)
+	e.SetExpireTime(time.Duration(global.CONFIG.Casbin.CacheExpire) * time.Second)
+	syncedEnforcer = e
+	if global.REDIS == nil {
+		return e, nil
+	}
+	// 由 policyChanged 统一通知, 一次变更只通知一次
+	e.EnableAutoNotifyWatcher(false)
+	casbinWatch = newCasbinWatcher(global.REDIS)
+	_ = e.SetWatcher(casbinWatch)
+	_ = casbinWatch.SetUpdateCallback(func(string) {
+		if err := e.LoadPolicy(); err != nil {
+			global.LOG.Error("casbin 重新加载策略失败", zap.Error(err))
+		}
+	})
+	return e, nil
+}
+
+// newEnforcer 创建 Enforcer 并从数据库加载策略
+func newEnforcer() (e *casbin.SyncedCachedEnforcer, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			// gorm-adapter 遇到 ptype 为空等异常数据时会 panic: slice bounds out of range
+			e, err = nil, fmt.Errorf("casbin 从数据库加载策略时发生 panic, 请检查 casbin_rule 表: ptype 字段不能为空, 且无异常数据: %v", r)
+		}
+	}()
+	a, err := gormadapter.NewAdapterByDB(global.DB)
+	if err != nil {
+		return nil, err
 	}
-	return syncedEnforcer
+	return casbin.NewSyncedCachedEnforcer(global.CONFIG.Casbin.ModelPath, a)
 }
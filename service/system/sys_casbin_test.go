@@ -0,0 +1,144 @@
+package system
+
+import (
+	"testing"
+
+	global "server/model"
+	"server/model/system"
+	"server/model/system/request"
+	"server/model/system/response"
+
+	gormadapter "github.com/casbin/gorm-adapter/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupCasbinTest 每个测试使用新的 Enforcer
+func setupCasbinTest(t *testing.T, cache bool) {
+	setupTestDB(t, &gormadapter.CasbinRule{}, &system.SysAuthority{})
+	old := global.CONFIG.Casbin
+	global.CONFIG.Casbin.ModelPath = "../../rbac_model.conf"
+	global.CONFIG.Casbin.Cache = cache
+	reset := func() { syncedEnforcer, casbinWatch = nil, nil }
+	reset()
+	t.Cleanup(func() {
+		reset()
+		global.CONFIG.Casbin = old
+	})
+}
+
+func TestCasbinLoadsPolicyOnce(t *testing.T) {
+	setupCasbinTest(t, false)
+	s := CasbinServiceApp
+	require.NoError(t, global.DB.Create(&gormadapter.CasbinRule{Ptype: "p", V0: "888", V1: "/backend/user/:id", V2: "GET"}).Error)
+	ok, err := s.Casbin().Enforce("888", "/backend/user/1", "GET")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// 绕过服务直接写库的策略要 Reload 后才生效
+	require.NoError(t, global.DB.Create(&gormadapter.CasbinRule{Ptype: "p", V0: "888", V1: "/backend/api/list", V2: "POST"}).Error)
+	ok, _ = s.Casbin().Enforce("888", "/backend/api/list", "POST")
+	assert.False(t, ok)
+	require.NoError(t, s.Reload())
+	ok, _ = s.Casbin().Enforce("888", "/backend/api/list", "POST")
+	assert.True(t, ok)
+
+	// 接口改名时策略随动
+	require.NoError(t, s.UpdateCasbinApi("/backend/api/list", "/backend/api/getList", "POST", "GET"))
+	ok, _ = s.Casbin().Enforce("888", "/backend/api/list", "POST")
+	assert.False(t, ok)
+	ok, _ = s.Casbin().Enforce("888", "/backend/api/getList", "GET")
+	assert.True(t, ok)
+}
+
+func TestCasbinLoadFailure(t *testing.T) {
+	setupCasbinTest(t, false)
+	old := casbinLoadBackoff
+	casbinLoadBackoff = 0
+	t.Cleanup(func() { casbinLoadBackoff = old })
+	s := CasbinServiceApp
+
+	// 异常数据导致加载失败时不使用空策略的 Enforcer
+	require.NoError(t, global.DB.Create(&gormadapter.CasbinRule{Ptype: "", V0: "888"}).Error)
+	assert.Error(t, s.Init())
+	assert.Nil(t, s.Casbin())
+
+	// 修复数据后下次调用重新加载
+	require.NoError(t, global.DB.Where("ptype = ?", "").Delete(&gormadapter.CasbinRule{}).Error)
+	require.NoError(t, global.DB.Create(&gormadapter.CasbinRule{Ptype: "p", V0: "888", V1: "/backend/user/:id", V2: "GET"}).Error)
+	e := s.Casbin()
+	require.NotNil(t, e)
+	ok, err := e.Enforce("888", "/backend/user/1", "GET")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.NoError(t, s.Init())
+	assert.Same(t, e, s.Casbin())
+}
+
+func TestCasbinDecisionCacheInvalidated(t *testing.T) {
+	setupCasbinTest(t, true)
+	s := CasbinServiceApp
+	require.NoError(t, s.UpdateCasbin("888", []request.CasbinInfo{{Path: "/backend/user/:id", Method: "GET"}}))
+	ok, _ := s.Casbin().Enforce("888", "/backend/user/1", "GET")
+	assert.True(t, ok)
+
+	// 删除角色或接口时清除策略, 缓存的鉴权结果一起失效
+	s.ClearCasbin(0, "888")
+	ok, _ = s.Casbin().Enforce("888", "/backend/user/1", "GET")
+	assert.False(t, ok)
+
+	require.NoError(t, s.UpdateCasbin("888", []request.CasbinInfo{{Path: "/backend/user/:id", Method: "GET"}}))
+	ok, _ = s.Casbin().Enforce("888", "/backend/user/1", "GET")
+	assert.True(t, ok)
+	assert.Equal(t, []request.CasbinInfo{{Path: "/backend/user/:id", Method: "GET"}}, s.GetPolicyPathByAuthorityId("888"))
+}
+
+func TestCasbinAuthorityInheritance(t *testing.T) {
+	setupCasbinTest(t, true)
+	s, a := CasbinServiceApp, AuthorityServiceApp
+	_, err := a.CreateAuthority(system.SysAuthority{AuthorityId: "888", AuthorityName: "admin", ParentId: "0"})
+	require.NoError(t, err)
+	_, err = a.CreateAuthority(system.SysAuthority{AuthorityId: "8881", AuthorityName: "sub", ParentId: "888"})
+	require.NoError(t, err)
+	require.NoError(t, s.UpdateCasbin("888", []request.CasbinInfo{{Path: "/backend/user/:id", Method: "GET"}}))
+	require.NoError(t, s.UpdateCasbin("8881", []request.CasbinInfo{{Path: "/backend/api/list", Method: "POST"}}))
+
+	ok, _ := s.Casbin().Enforce("8881", "/backend/user/1", "GET")
+	assert.True(t, ok, "子角色继承父角色的权限")
+	ok, _ = s.Casbin().Enforce("888", "/backend/api/list", "POST")
+	assert.False(t, ok, "父角色不继承子角色的权限")
+
+	policies, err := s.GetEffectivePolicy("8881")
+	require.NoError(t, err)
+	assert.Equal(t, []response.EffectivePolicy{
+		{Path: "/backend/api/list", Method: "POST", AuthorityId: "8881"},
+		{Path: "/backend/user/:id", Method: "GET", AuthorityId: "888", Inherited: true},
+	}, policies)
+
+	// 不能成环
+	_, err = a.UpdateAuthority(system.SysAuthority{AuthorityId: "888", ParentId: "8881"})
+	assert.Error(t, err)
+	_, err = a.UpdateAuthority(system.SysAuthority{AuthorityId: "8881", ParentId: "0"})
+	require.NoError(t, err)
+	ok, _ = s.Casbin().Enforce("8881", "/backend/user/1", "GET")
+	assert.False(t, ok)
+
+	// 管理员预览后按角色树校正 g 规则
+	require.NoError(t, global.DB.Model(&system.SysAuthority{}).Where("authority_id = ?", "8881").Update("parent_id", "888").Error)
+	_, err = s.Casbin().AddGroupingPolicy("888", "9999")
+	require.NoError(t, err)
+	preview, err := s.PreviewAuthorityTree()
+	require.NoError(t, err)
+	assert.Equal(t, response.AuthorityTreeSyncResponse{
+		Add:    []response.AuthorityInherit{{AuthorityId: "8881", ParentId: "888", Apis: []request.CasbinInfo{{Path: "/backend/user/:id", Method: "GET"}}}},
+		Remove: []response.AuthorityInherit{{AuthorityId: "888", ParentId: "9999", Apis: []request.CasbinInfo{}}},
+	}, preview)
+	ok, _ = s.Casbin().Enforce("8881", "/backend/user/1", "GET")
+	assert.False(t, ok, "预览不修改规则")
+	require.NoError(t, s.SyncAuthorityTree())
+	rules, err := s.Casbin().GetGroupingPolicy()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"8881", "888"}}, rules)
+	ok, _ = s.Casbin().Enforce("8881", "/backend/user/1", "GET")
+	assert.True(t, ok)
+}
@@ -0,0 +1,71 @@
+package system
+
+import (
+	"context"
+	"sync"
+
+	global "server/model"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const casbinPolicyChannel = "casbin:policy" // 策略变更后通知其他实例重新加载
+
+// casbinWatcher 实现 persist.Watcher, 通过 redis 发布订阅在多个实例之间同步策略
+type casbinWatcher struct {
+	id       string // 区分本实例发出的通知
+	client   *redis.Client
+	cancel   context.CancelFunc
+	mu       sync.RWMutex
+	callback func(string)
+}
+
+func newCasbinWatcher(client *redis.Client) *casbinWatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &casbinWatcher{id: uuid.NewString(), client: client, cancel: cancel}
+	pubsub := client.Subscribe(ctx, casbinPolicyChannel)
+	go w.run(ctx, pubsub)
+	return w
+}
+
+func (w *casbinWatcher) SetUpdateCallback(callback func(string)) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.callback = callback
+	return nil
+}
+
+func (w *casbinWatcher) Update() error {
+	return w.client.Publish(context.Background(), casbinPolicyChannel, w.id).Err()
+}
+
+func (w *casbinWatcher) Close() {
+	w.cancel()
+}
+
+func (w *casbinWatcher) run(ctx context.Context, pubsub *redis.PubSub) {
+	defer pubsub.Close()
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			if msg.Payload == w.id {
+				continue
+			}
+			w.mu.RLock()
+			callback := w.callback
+			w.mu.RUnlock()
+			if callback != nil {
+				global.LOG.Info("casbin 策略已在其他实例变更, 重新加载", zap.String("from", msg.Payload))
+				callback(msg.Payload)
+			}
+		}
+	}
+}
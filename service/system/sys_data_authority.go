@@ -0,0 +1,58 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+
+	global "server/model"
+	"server/model/system"
+	systemReq "server/model/system/request"
+
+	"gorm.io/gorm"
+)
+
+//
+//@function: GetDataAuthorityIds
+//@description: 获取角色可以查看的数据所属角色id
+//@param: authorityId string
+//@return: ids []string, err error
+
+func (authorityService *AuthorityService) GetDataAuthorityIds(authorityId string) (ids []string, err error) {
+	var auth system.SysAuthority
+	err = global.DB.Preload("DataAuthorityId").Where("authority_id = ?", authorityId).First(&auth).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range auth.DataAuthorityId {
+		ids = append(ids, v.AuthorityId)
+	}
+	return ids, nil
+}
+
+//
+//@function: DataScope
+//@description: 按调用者角色的资源权限过滤记录, column 为记录创建人的用户id列, 只保留本人及可见角色下用户创建的数据
+//@param: claims *systemReq.CustomClaims, column string
+//@return: func(*gorm.DB) *gorm.DB
+
+func (authorityService *AuthorityService) DataScope(claims *systemReq.CustomClaims, column string) func(*gorm.DB) *gorm.DB {
+	if claims == nil {
+		return func(db *gorm.DB) *gorm.DB {
+			db.AddError(errors.New("未获取到用户信息"))
+			return db
+		}
+	}
+	// 在生成 scope 时查询一次, 分页查询中 Count 和 Find 共用结果
+	dataIds, err := authorityService.GetDataAuthorityIds(claims.AuthorityId)
+	return func(db *gorm.DB) *gorm.DB {
+		if err != nil {
+			db.AddError(err)
+			return db
+		}
+		if len(dataIds) == 0 {
+			return db.Where(column+" = ?", claims.BaseClaims.ID)
+		}
+		return db.Where(fmt.Sprintf("(%[1]s = ? OR %[1]s IN (SELECT id FROM sys_users WHERE authority_id IN ?) OR %[1]s IN (SELECT sys_user_id FROM sys_user_authority WHERE sys_authority_authority_id IN ?))", column),
+			claims.BaseClaims.ID, dataIds, dataIds)
+	}
+}
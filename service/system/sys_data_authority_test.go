@@ -0,0 +1,106 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	global "server/model"
+	commonReq "server/model/common/request"
+	"server/model/system"
+	"server/model/system/request"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataScope(t *testing.T) {
+	setupTestDB(t, &system.SysAuthority{}, &system.SysUser{}, &system.SysOperationRecord{})
+	auths := []system.SysAuthority{{AuthorityId: "9528"}, {AuthorityId: "8881"}, {AuthorityId: "888"}}
+	require.NoError(t, global.DB.Create(&auths).Error)
+	require.NoError(t, AuthorityServiceApp.SetDataAuthority(&system.SysAuthority{
+		AuthorityId:     "888",
+		DataAuthorityId: []*system.SysAuthority{{AuthorityId: "888"}, {AuthorityId: "8881"}},
+	}))
+	users := []system.SysUser{
+		{Username: "admin", AuthorityId: "888"},
+		{Username: "editor", AuthorityId: "8881"},
+		{Username: "guest", AuthorityId: "9528"},
+		{Username: "multi", AuthorityId: "9528", Authorities: []system.SysAuthority{{AuthorityId: "8881"}}},
+	}
+	require.NoError(t, global.DB.Create(&users).Error)
+	for _, u := range users {
+		require.NoError(t, global.DB.Create(&system.SysOperationRecord{UserID: int(u.ID), Path: u.Username}).Error)
+	}
+
+	paths := func(claims *request.CustomClaims) []string {
+		list, total, err := OperationRecordServiceApp.GetSysOperationRecordInfoList(
+			&request.SysOperationRecordSearch{PageInfo: commonReq.PageInfo{Page: 1, PageSize: 10}}, AuthorityServiceApp.DataScope(claims, "user_id"))
+		require.NoError(t, err)
+		var ret []string
+		for _, v := range list {
+			ret = append(ret, v.Path)
+		}
+		assert.Len(t, ret, int(total))
+		return ret
+	}
+	claims := func(u system.SysUser) *request.CustomClaims {
+		return &request.CustomClaims{BaseClaims: request.BaseClaims{ID: u.ID, AuthorityId: u.AuthorityId}}
+	}
+
+	// 可见角色下的用户(包括附加角色)及本人
+	assert.ElementsMatch(t, []string{"admin", "editor", "multi"}, paths(claims(users[0])))
+	// 未配置资源权限时只能看到本人的数据
+	assert.ElementsMatch(t, []string{"guest"}, paths(claims(users[2])))
+
+	_, _, err := OperationRecordServiceApp.GetSysOperationRecordInfoList(
+		&request.SysOperationRecordSearch{PageInfo: commonReq.PageInfo{Page: 1, PageSize: 10}}, AuthorityServiceApp.DataScope(nil, "user_id"))
+	assert.Error(t, err)
+}
+
+func TestMakeDataAuthority(t *testing.T) {
+	autoCode := system.AutoCodeStruct{DataAuthority: true}
+	makeDataAuthority(&autoCode)
+	makeDataAuthority(&autoCode)
+	require.Len(t, autoCode.Fields, 1)
+	assert.Equal(t, DataAuthorityColumn, autoCode.Fields[0].ColumnName)
+
+	autoCode = system.AutoCodeStruct{}
+	makeDataAuthority(&autoCode)
+	assert.Empty(t, autoCode.Fields)
+}
+
+// 补充资源权限的迁移只给没有资源权限的角色加上本角色
+func TestSeedDataAuthorityMigration(t *testing.T) {
+	dir := setupMigrationTest(t)
+	require.NoError(t, global.DB.AutoMigrate(&system.SysAuthority{}))
+	for _, name := range []string{"1792281602_seed_data_authority.up.sql", "1792281602_seed_data_authority.down.sql"} {
+		content, err := os.ReadFile(filepath.Join("..", "..", "migration", name))
+		require.NoError(t, err)
+		writeMigration(t, dir, name, string(content))
+	}
+	auths := []system.SysAuthority{{AuthorityId: "66"}, {AuthorityId: "888"}, {AuthorityId: "998"}}
+	require.NoError(t, global.DB.Create(&auths).Error)
+	require.NoError(t, AuthorityServiceApp.SetDataAuthority(&system.SysAuthority{
+		AuthorityId:     "888",
+		DataAuthorityId: []*system.SysAuthority{{AuthorityId: "888"}, {AuthorityId: "998"}},
+	}))
+
+	svc := &MigrationService{}
+	_, err := svc.Up(0)
+	require.NoError(t, err)
+	for id, want := range map[string][]string{"66": {"66"}, "888": {"888", "998"}, "998": {"998"}} {
+		ids, err := AuthorityServiceApp.GetDataAuthorityIds(id)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, want, ids, id)
+	}
+
+	_, err = svc.Down(1)
+	require.NoError(t, err)
+	ids, err := AuthorityServiceApp.GetDataAuthorityIds("66")
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+	ids, err = AuthorityServiceApp.GetDataAuthorityIds("888")
+	require.NoError(t, err)
+	assert.Len(t, ids, 2)
+}
@@ -0,0 +1,171 @@
+package system
+
+import (
+	"database/sql"
+	"fmt"
+
+	global "server/model"
+	"server/model/example"
+	"server/model/mobile"
+	"server/model/system"
+	"server/utils/crypt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// EncryptedTable 包含加密字段的表, Columns 为加密列到盲索引列的映射, 没有盲索引时为空
+type EncryptedTable struct {
+	Model   schema.Tabler
+	Columns map[string]string
+}
+
+// EncryptedTables 使用 serializer:encrypted 的全部字段, 新增加密字段时需要同步登记
+var EncryptedTables = []EncryptedTable{
+	{Model: system.SysUser{}, Columns: map[string]string{"phone": "phone_bidx", "email": "email_bidx"}},
+	{Model: mobile.MobileUser{}, Columns: map[string]string{"phone": "phone_bidx", "realname": "realname_bidx"}},
+	{Model: example.ExaCustomer{}, Columns: map[string]string{"customer_phone_data": "customer_phone_bidx"}},
+}
+
+// EncryptResult 单张表的处理结果
+type EncryptResult struct {
+	Table   string
+	Scanned int
+	Updated int
+}
+
+//@function: EncryptData
+//@description: 按当前配置重写已有数据: 明文和旧版本密钥的密文用当前密钥重新加密, 关闭加密时还原为明文, 同时重建盲索引;
+//@description: 直接读写原始列值, 包括已软删除的记录, 可以重复执行
+//@param: batch int 每批处理的行数
+//@return: []EncryptResult, error
+
+func (e *EncryptionService) EncryptData(batch int) ([]EncryptResult, error) {
+	if batch <= 0 {
+		batch = 500
+	}
+	results := make([]EncryptResult, 0, len(EncryptedTables))
+	for _, t := range EncryptedTables {
+		result, err := e.encryptTable(t, batch, false)
+		results = append(results, result)
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+//@function: BackfillIndexes
+//@description: 补全为空的盲索引, 不改动加密列; 升级前写入的记录没有盲索引, 按盲索引查询时会查不到, 服务启动时执行
+//@param: batch int 每批处理的行数
+//@return: []EncryptResult, error
+
+func (e *EncryptionService) BackfillIndexes(batch int) ([]EncryptResult, error) {
+	if batch <= 0 {
+		batch = 500
+	}
+	results := make([]EncryptResult, 0, len(EncryptedTables))
+	for _, t := range EncryptedTables {
+		result, err := e.encryptTable(t, batch, true)
+		results = append(results, result)
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+// encryptTable indexOnly 时只处理有值但盲索引为空的记录
+func (e *EncryptionService) encryptTable(t EncryptedTable, batch int, indexOnly bool) (result EncryptResult, err error) {
+	result.Table = t.Model.TableName()
+	var columns, indexes []string
+	for column, index := range t.Columns {
+		columns = append(columns, column)
+		indexes = append(indexes, index)
+	}
+	selects := []string{"id"}
+	for i := range columns {
+		selects = append(selects, columns[i])
+		if indexes[i] != "" {
+			selects = append(selects, indexes[i])
+		}
+	}
+
+	query := global.DB.Table(result.Table)
+	if indexOnly {
+		missing := global.DB.Where("1 = 0")
+		for i := range columns {
+			if indexes[i] != "" {
+				missing = missing.Or(fmt.Sprintf("(%s IS NULL OR %s = '') AND %s <> ''", indexes[i], indexes[i], columns[i]))
+			}
+		}
+		query = query.Where(missing)
+	}
+
+	type row struct {
+		id     uint
+		values map[string]sql.NullString
+	}
+	var lastId uint
+	for {
+		// 先读完一批再更新, 避免 sqlite 等单连接数据库在遍历结果时写入阻塞
+		rows, err := query.Session(&gorm.Session{}).Select(selects).Where("id > ?", lastId).Order("id").Limit(batch).Rows()
+		if err != nil {
+			return result, err
+		}
+		var list []row
+		for rows.Next() {
+			values := make([]sql.NullString, len(selects)-1)
+			r := row{values: make(map[string]sql.NullString, len(values))}
+			dest := []any{&r.id}
+			for i := range values {
+				dest = append(dest, &values[i])
+			}
+			if err = rows.Scan(dest...); err != nil {
+				_ = rows.Close()
+				return result, err
+			}
+			for i, name := range selects[1:] {
+				r.values[name] = values[i]
+			}
+			list = append(list, r)
+		}
+		_ = rows.Close()
+		if err = rows.Err(); err != nil {
+			return result, err
+		}
+
+		for _, r := range list {
+			updates := map[string]any{}
+			for i, column := range columns {
+				raw := r.values[column].String
+				plain, err := crypt.Decrypt(raw)
+				if err != nil {
+					return result, fmt.Errorf("%s id=%d 的 %s 解密失败: %w", result.Table, r.id, column, err)
+				}
+				if !indexOnly && raw != "" && crypt.Version(raw) != global.CONFIG.Encryption.Current {
+					if updates[column], err = crypt.Encrypt(plain); err != nil {
+						return result, err
+					}
+				}
+				if index := indexes[i]; index != "" {
+					if value := crypt.BlindIndex(plain); r.values[index].String != value {
+						updates[index] = value
+					}
+				}
+			}
+			result.Scanned++
+			if len(updates) == 0 {
+				continue
+			}
+			if err = global.DB.Table(result.Table).Where("id = ?", r.id).Updates(updates).Error; err != nil {
+				return result, err
+			}
+			result.Updated++
+		}
+		if len(list) < batch {
+			return result, nil
+		}
+		lastId = list[len(list)-1].id
+	}
+}
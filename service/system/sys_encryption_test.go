@@ -0,0 +1,100 @@
+package system
+
+import (
+	"testing"
+
+	"server/config"
+	global "server/model"
+	"server/model/example"
+	"server/model/mobile"
+	"server/model/system"
+	"server/utils/crypt"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptData(t *testing.T) {
+	setupTestDB(t, &system.SysUser{}, &mobile.MobileUser{}, &example.ExaCustomer{})
+	old := global.CONFIG.Encryption
+	t.Cleanup(func() { global.CONFIG.Encryption = old })
+	global.CONFIG.Encryption = config.Encryption{
+		Keys: []config.EncryptionKey{
+			{Version: "v1", Key: "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="},
+			{Version: "v2", Key: "ZmVkY2JhOTg3NjU0MzIxMGZlZGNiYTk4NzY1NDMyMTA="},
+		},
+		IndexKey: "index",
+	}
+	raw := func(column string, id uint) string {
+		var v string
+		require.NoError(t, global.DB.Table("sys_users").Select(column).Where("id = ?", id).Scan(&v).Error)
+		return v
+	}
+
+	// 开启加密前写入的明文数据, 其中一条已软删除
+	users := []system.SysUser{{Username: "a", Phone: "13800008000", Email: "A@example.com"}, {Username: "b", Phone: "13900009000"}}
+	require.NoError(t, global.DB.Create(&users).Error)
+	require.NoError(t, global.DB.Delete(&users[1]).Error)
+	require.NoError(t, global.DB.Table("sys_users").Where("id = ?", users[0].ID).Update("email_bidx", "").Error)
+	assert.Equal(t, "13800008000", raw("phone", users[0].ID))
+
+	global.CONFIG.Encryption.Current = "v1"
+	results, err := EncryptionServiceApp.EncryptData(1)
+	require.NoError(t, err)
+	assert.Equal(t, EncryptResult{Table: "sys_users", Scanned: 2, Updated: 2}, results[0])
+	assert.Equal(t, "v1", crypt.Version(raw("phone", users[0].ID)))
+	assert.Equal(t, "v1", crypt.Version(raw("phone", users[1].ID)))
+	assert.Empty(t, raw("email", users[1].ID))
+
+	// 读取时自动解密, 按盲索引精确查询
+	var user system.SysUser
+	require.NoError(t, global.DB.Where("email_bidx = ?", crypt.BlindIndex("a@example.com")).First(&user).Error)
+	assert.Equal(t, "13800008000", user.Phone)
+	assert.Equal(t, "A@example.com", user.Email)
+
+	// 重复执行不再修改
+	results, err = EncryptionServiceApp.EncryptData(0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, results[0].Updated)
+
+	// 轮换密钥后重新加密
+	global.CONFIG.Encryption.Current = "v2"
+	_, err = EncryptionServiceApp.EncryptData(0)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", crypt.Version(raw("email", users[0].ID)))
+
+	// 新写入的数据直接加密
+	customer := example.ExaCustomer{CustomerName: "c", CustomerPhoneData: "13700007000"}
+	require.NoError(t, global.DB.Create(&customer).Error)
+	var phone string
+	global.DB.Table("exa_customers").Select("customer_phone_data").Scan(&phone)
+	assert.Equal(t, "v2", crypt.Version(phone))
+}
+
+func TestBackfillIndexes(t *testing.T) {
+	setupTestDB(t, &system.SysUser{}, &mobile.MobileUser{}, &example.ExaCustomer{})
+	old := global.CONFIG.Encryption
+	t.Cleanup(func() { global.CONFIG.Encryption = old })
+	global.CONFIG.Encryption = config.Encryption{IndexKey: "index"}
+
+	// 升级前写入的记录没有盲索引
+	users := []system.SysUser{{Username: "a", Phone: "13800008000", Email: "a@example.com"}, {Username: "b"}}
+	require.NoError(t, global.DB.Create(&users).Error)
+	require.NoError(t, global.DB.Exec("UPDATE sys_users SET phone_bidx = NULL, email_bidx = ''").Error)
+
+	results, err := EncryptionServiceApp.BackfillIndexes(0)
+	require.NoError(t, err)
+	assert.Equal(t, EncryptResult{Table: "sys_users", Scanned: 1, Updated: 1}, results[0])
+
+	// 只补盲索引, 不加密明文
+	var user system.SysUser
+	require.NoError(t, global.DB.Where("email_bidx = ?", crypt.BlindIndex("A@example.com")).First(&user).Error)
+	assert.Equal(t, users[0].ID, user.ID)
+	var phone string
+	global.DB.Table("sys_users").Select("phone").Where("id = ?", user.ID).Scan(&phone)
+	assert.Equal(t, "13800008000", phone)
+
+	results, err = EncryptionServiceApp.BackfillIndexes(0)
+	require.NoError(t, err)
+	assert.Zero(t, results[0].Scanned)
+}
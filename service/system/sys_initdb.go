@@ -16,6 +16,7 @@ import (
 const (
 	Mysql           = "mysql"
 	Pgsql           = "pgsql"
+	Sqlite          = "sqlite"
 	InitSuccess     = "\n[%v] --> 初始数据成功!\n"
 	InitDataExist   = "\n[%v] --> %v 的初始数据已存在!\n"
 	InitDataFailed  = "\n[%v] --> %v 初始数据失败! \nerr: %+v\n"
@@ -97,17 +98,19 @@ func (initDBService *InitDBService) InitDB(conf request.InitDB) (err error) {
 	// 若存在多个依赖，可以写为 C=A+B, D=A+B+C, E=A+1;
 	// C必然>A|B，因此在AB之后执行，D必然>A|B|C，因此在ABC后执行，而E只依赖A，顺序与CD无关，因此E与CD哪个先执行并不影响
 	var initHandler TypedDBInitHandler
-	type myString string
 	switch conf.DBType {
 	case "mysql":
 		initHandler = NewMysqlInitHandler()
-		ctx = context.WithValue(ctx, myString("dbtype"), "mysql")
+		ctx = context.WithValue(ctx, "dbtype", "mysql")
 	case "pgsql":
 		initHandler = NewPgsqlInitHandler()
-		ctx = context.WithValue(ctx, myString("dbtype"), "pgsql")
+		ctx = context.WithValue(ctx, "dbtype", "pgsql")
+	case "sqlite":
+		initHandler = NewSqliteInitHandler()
+		ctx = context.WithValue(ctx, "dbtype", "sqlite")
 	default:
 		initHandler = NewMysqlInitHandler()
-		ctx = context.WithValue(ctx, myString("dbtype"), "mysql")
+		ctx = context.WithValue(ctx, "dbtype", "mysql")
 	}
 	ctx, err = initHandler.EnsureDB(ctx, &conf)
 	if err != nil {
@@ -50,8 +50,7 @@ func (h *MysqlInitHandler) EnsureDB(ctx context.Context, conf *request.InitDB) (
 	} // 创建数据库
 
 	c := conf.ToMysqlConfig()
-	type myString string
-	next = context.WithValue(ctx, myString("config"), c)
+	next = context.WithValue(ctx, "config", c)
 	if c.Dbname == "" {
 		return ctx, nil
 	} // 如果没有数据库名, 则跳出初始化数据
@@ -65,7 +64,7 @@ func (h *MysqlInitHandler) EnsureDB(ctx context.Context, conf *request.InitDB) (
 	}
 	global.CONFIG.AutoCode.Root, _ = filepath.Abs("..")
 
-	next = context.WithValue(next, myString("db"), db)
+	next = context.WithValue(next, "db", db)
 	return next, err
 }
 
@@ -50,8 +50,7 @@ func (h PgsqlInitHandler) EnsureDB(ctx context.Context, conf *request.InitDB) (n
 	} // 创建数据库
 
 	c := conf.ToPgsqlConfig()
-	type myString string
-	next = context.WithValue(ctx, myString("config"), c)
+	next = context.WithValue(ctx, "config", c)
 	if c.Dbname == "" {
 		return ctx, nil
 	} // 如果没有数据库名, 则跳出初始化数据
@@ -63,7 +62,7 @@ func (h PgsqlInitHandler) EnsureDB(ctx context.Context, conf *request.InitDB) (n
 		return ctx, err
 	}
 	global.CONFIG.AutoCode.Root, _ = filepath.Abs("..")
-	next = context.WithValue(next, myString("db"), db)
+	next = context.WithValue(next, "db", db)
 	return next, err
 }
 
@@ -0,0 +1,93 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"server/config"
+	"server/model/system/request"
+	"server/utils"
+
+	"github.com/gookit/color"
+
+	global "server/model"
+
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func NewSqliteInitHandler() *SqliteInitHandler {
+	return &SqliteInitHandler{}
+}
+
+// WriteConfig sqlite 回写配置
+func (h SqliteInitHandler) WriteConfig(ctx context.Context) error {
+	c, ok := ctx.Value("config").(config.Sqlite)
+	if !ok {
+		return errors.New("sqlite config invalid")
+	}
+	global.CONFIG.System.DbType = "sqlite"
+	global.CONFIG.Sqlite = c
+	cs := utils.StructToMap(global.CONFIG)
+	for k, v := range cs {
+		global.VIPER.Set(k, v)
+	}
+	global.VIPER.Set("jwt.signing-key", uuid.New().String())
+	return global.VIPER.WriteConfig()
+}
+
+// EnsureDB 创建数据库并初始化 sqlite, 数据库文件不存在时由驱动自动创建
+func (h SqliteInitHandler) EnsureDB(ctx context.Context, conf *request.InitDB) (next context.Context, err error) {
+	if s, ok := ctx.Value("dbtype").(string); !ok || s != "sqlite" {
+		return ctx, ErrDBTypeMismatch
+	}
+
+	c := conf.ToSqliteConfig()
+	next = context.WithValue(ctx, "config", c)
+	if c.Dbname == "" {
+		return ctx, nil
+	} // 如果没有数据库名, 则跳出初始化数据
+	if c.Path != "" {
+		if err = os.MkdirAll(c.Path, os.ModePerm); err != nil {
+			return ctx, err
+		}
+	}
+	var db *gorm.DB
+	if db, err = gorm.Open(sqlite.Open(c.Dsn()), &gorm.Config{DisableForeignKeyConstraintWhenMigrating: true}); err != nil {
+		return ctx, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return ctx, err
+	}
+	sqlDB.SetMaxOpenConns(c.MaxOpenConns)
+	global.CONFIG.AutoCode.Root, _ = filepath.Abs("..")
+	next = context.WithValue(next, "db", db)
+	return next, err
+}
+
+func (h SqliteInitHandler) InitTables(ctx context.Context, inits initSlice) error {
+	return createTables(ctx, inits)
+}
+
+func (h SqliteInitHandler) InitData(ctx context.Context, inits initSlice) error {
+	next, cancel := context.WithCancel(ctx)
+	defer func(c func()) { c() }(cancel)
+	for i := range len(inits) {
+		if inits[i].DataInserted(next) {
+			color.Info.Printf(InitDataExist, Sqlite, inits[i].InitializerName())
+			continue
+		}
+		if n, err := inits[i].InitializeData(next); err != nil {
+			color.Info.Printf(InitDataFailed, Sqlite, Sqlite, err)
+			return err
+		} else {
+			next = n
+			color.Info.Printf(InitDataSuccess, Sqlite, inits[i].InitializerName())
+		}
+	}
+	color.Info.Printf(InitSuccess, Sqlite)
+	return nil
+}
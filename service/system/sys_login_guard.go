@@ -0,0 +1,278 @@
+package system
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"server/config"
+	global "server/model"
+	"server/model/system"
+	systemReq "server/model/system/request"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrLoginLocked          = errors.New("登录失败次数过多, 请稍后再试")
+	ErrLoginCaptchaRequired = errors.New("请输入验证码")
+)
+
+var (
+	loginAccountCounter = newAttemptCounter("login:account:")
+	loginIpCounter      = newAttemptCounter("login:ip:")
+)
+
+// LoginAttempt 一次登录请求
+type LoginAttempt struct {
+	Channel   string // 所属端 backend/frontend/mobile
+	Username  string
+	Ip        string
+	UserAgent string
+}
+
+// accountKey 账号失败次数按用户名和 ip 一起统计, 避免从一个 ip 猜密码就把账号锁死
+func (a LoginAttempt) accountKey() string {
+	return a.Channel + ":" + a.Ip + ":" + a.subject()
+}
+
+func (a LoginAttempt) subject() string {
+	return strings.ToLower(strings.TrimSpace(a.Username))
+}
+
+// LoginGuardState 登录前检查或登录失败后的状态
+type LoginGuardState struct {
+	NeedCaptcha bool          // 下次登录需要验证码
+	LockedUntil time.Time     // 锁定时返回到期时间
+	Delay       time.Duration // 登录失败后响应前的等待时间
+}
+
+//@function: Check
+//@description: 登录前检查账号在该 ip 上和 ip 本身是否锁定, 以及是否需要验证码
+//@param: a LoginAttempt
+//@return: state LoginGuardState, err error 锁定时返回 ErrLoginLocked
+
+func (g *LoginGuardService) Check(a LoginAttempt) (state LoginGuardState, err error) {
+	var lock system.SysLoginLock
+	err = global.DB.Where("locked_until > ?", time.Now()).
+		Where(global.DB.Where("kind = ? AND channel = ? AND subject = ? AND last_ip = ?", system.LoginLockAccount, a.Channel, a.subject(), a.Ip).
+			Or("kind = ? AND subject = ?", system.LoginLockIp, a.Ip)).
+		Order("locked_until desc").First(&lock).Error
+	if err == nil {
+		state.LockedUntil = lock.LockedUntil
+		return state, ErrLoginLocked
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return state, err
+	}
+	state.NeedCaptcha, err = g.needCaptcha(a)
+	return state, err
+}
+
+//@function: Failed
+//@description: 记录一次登录失败, 达到上限时锁定账号或 ip; 返回的 Delay 随连续失败次数翻倍
+//@param: a LoginAttempt, userId uint 账号不存在或未确认时为0, 不写入登录记录, reason string
+//@return: state LoginGuardState, err error
+
+func (g *LoginGuardService) Failed(a LoginAttempt, userId uint, reason string) (state LoginGuardState, err error) {
+	g.record(a, userId, false, reason)
+	conf := g.config()
+	window := time.Duration(conf.Window) * time.Second
+	accountFailures, err := loginAccountCounter.Incr(a.accountKey(), window)
+	if err != nil {
+		return state, err
+	}
+	ipFailures, err := loginIpCounter.Incr(a.Ip, window)
+	if err != nil {
+		return state, err
+	}
+
+	if accountFailures >= int64(conf.AccountLimit) {
+		if state.LockedUntil, err = g.lock(system.LoginLockAccount, a.Channel, a.subject(), a.Ip, accountFailures); err != nil {
+			return state, err
+		}
+		err = loginAccountCounter.Reset(a.accountKey())
+	}
+	if err == nil && ipFailures >= int64(conf.IpLimit) {
+		if state.LockedUntil, err = g.lock(system.LoginLockIp, "", a.Ip, a.Ip, ipFailures); err != nil {
+			return state, err
+		}
+		err = loginIpCounter.Reset(a.Ip)
+	}
+	if err != nil {
+		return state, err
+	}
+
+	state.NeedCaptcha = accountFailures >= int64(conf.CaptchaAfter) || ipFailures >= int64(conf.CaptchaAfter)
+	state.Delay = time.Duration(conf.DelayBase) * time.Millisecond
+	for i := int64(1); i < accountFailures && state.Delay < time.Duration(conf.DelayMax)*time.Millisecond; i++ {
+		state.Delay *= 2
+	}
+	state.Delay = min(state.Delay, time.Duration(conf.DelayMax)*time.Millisecond)
+	return state, nil
+}
+
+//@function: Succeeded
+//@description: 登录成功后清除该账号的失败次数并记录, ip 的失败次数不清除
+//@param: a LoginAttempt, userId uint
+//@return: error
+
+func (g *LoginGuardService) Succeeded(a LoginAttempt, userId uint) error {
+	g.record(a, userId, true, "")
+	return loginAccountCounter.Reset(a.accountKey())
+}
+
+//@function: GetLockList
+//@description: 分页获取当前生效的锁定
+//@param: info systemReq.SysLoginLockSearch
+//@return: list []system.SysLoginLock, total int64, err error
+
+func (g *LoginGuardService) GetLockList(info systemReq.SysLoginLockSearch) (list []system.SysLoginLock, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.DB.Model(&system.SysLoginLock{}).Where("locked_until > ?", time.Now())
+	if info.Kind != "" {
+		db = db.Where("kind = ?", info.Kind)
+	}
+	if info.Channel != "" {
+		db = db.Where("channel = ?", info.Channel)
+	}
+	if info.Subject != "" {
+		db = db.Where("subject LIKE ?", "%"+info.Subject+"%")
+	}
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Order("locked_until desc").Limit(limit).Offset(offset).Find(&list).Error
+	return list, total, err
+}
+
+//@function: Unlock
+//@description: 解除锁定并清除对应的失败次数
+//@param: id uint
+//@return: error
+
+func (g *LoginGuardService) Unlock(id uint) error {
+	var lock system.SysLoginLock
+	if err := global.DB.Where("id = ?", id).First(&lock).Error; err != nil {
+		return err
+	}
+	if err := global.DB.Unscoped().Delete(&lock).Error; err != nil {
+		return err
+	}
+	if lock.Kind == system.LoginLockIp {
+		return loginIpCounter.Reset(lock.Subject)
+	}
+	return loginAccountCounter.Reset(LoginAttempt{Channel: lock.Channel, Username: lock.Subject, Ip: lock.LastIp}.accountKey())
+}
+
+//@function: GetHistoryList
+//@description: 分页获取登录记录
+//@param: info systemReq.SysLoginHistorySearch
+//@return: list []system.SysLoginHistory, total int64, err error
+
+func (g *LoginGuardService) GetHistoryList(info systemReq.SysLoginHistorySearch) (list []system.SysLoginHistory, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.DB.Model(&system.SysLoginHistory{})
+	if info.Channel != "" {
+		db = db.Where("channel = ?", info.Channel)
+	}
+	if info.Username != "" {
+		db = db.Where("username LIKE ?", "%"+info.Username+"%")
+	}
+	if info.Ip != "" {
+		db = db.Where("ip = ?", info.Ip)
+	}
+	if info.Success != nil {
+		db = db.Where("success = ?", *info.Success)
+	}
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Order("id desc").Limit(limit).Offset(offset).Find(&list).Error
+	return list, total, err
+}
+
+func (g *LoginGuardService) needCaptcha(a LoginAttempt) (bool, error) {
+	limit := int64(g.config().CaptchaAfter)
+	n, err := loginAccountCounter.Count(a.accountKey())
+	if err != nil || n >= limit {
+		return n >= limit, err
+	}
+	n, err = loginIpCounter.Count(a.Ip)
+	return n >= limit, err
+}
+
+// lock 新建或延长锁定
+func (g *LoginGuardService) lock(kind, channel, subject, ip string, failures int64) (time.Time, error) {
+	lock := system.SysLoginLock{
+		Kind:        kind,
+		Channel:     channel,
+		Subject:     subject,
+		Failures:    failures,
+		LastIp:      ip,
+		LockedUntil: time.Now().Add(time.Duration(g.config().LockTime) * time.Second),
+	}
+	err := global.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "kind"}, {Name: "channel"}, {Name: "subject"}, {Name: "last_ip"}},
+		DoUpdates: clause.AssignmentColumns([]string{"failures", "locked_until", "updated_at", "deleted_at"}),
+	}).Create(&lock).Error
+	if err == nil {
+		global.LOG.Warn("登录失败次数过多, 已锁定", zap.String("kind", kind), zap.String("channel", channel), zap.String("subject", subject), zap.String("ip", ip))
+	}
+	return lock.LockedUntil, err
+}
+
+// record 写入登录记录, 失败只记日志不影响登录
+func (g *LoginGuardService) record(a LoginAttempt, userId uint, success bool, reason string) {
+	history := system.SysLoginHistory{
+		Channel:   a.Channel,
+		Username:  a.Username,
+		Ip:        a.Ip,
+		UserAgent: a.UserAgent,
+		Success:   success,
+		Reason:    reason,
+	}
+	if userId != 0 {
+		history.UserId = &userId
+	}
+	if len(history.Username) > 191 {
+		history.Username = history.Username[:191]
+	}
+	if len(history.UserAgent) > 255 {
+		history.UserAgent = history.UserAgent[:255]
+	}
+	if err := global.DB.Create(&history).Error; err != nil {
+		global.LOG.Warn("写入登录记录失败", zap.String("username", a.Username), zap.Error(err))
+	}
+}
+
+// config 未配置的项使用默认值
+func (g *LoginGuardService) config() (conf config.LoginGuard) {
+	conf = global.CONFIG.LoginGuard
+	if conf.AccountLimit <= 0 {
+		conf.AccountLimit = 5
+	}
+	if conf.IpLimit <= 0 {
+		conf.IpLimit = 20
+	}
+	if conf.CaptchaAfter <= 0 {
+		conf.CaptchaAfter = 3
+	}
+	if conf.Window <= 0 {
+		conf.Window = 900
+	}
+	if conf.LockTime <= 0 {
+		conf.LockTime = 900
+	}
+	if conf.DelayMax < 0 {
+		conf.DelayMax = 0
+	}
+	if conf.DelayBase < 0 {
+		conf.DelayBase = 0
+	}
+	return conf
+}
@@ -0,0 +1,127 @@
+package system
+
+import (
+	"testing"
+	"time"
+
+	"server/config"
+	global "server/model"
+	"server/model/common/request"
+	"server/model/system"
+	systemReq "server/model/system/request"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var loginGuardPage = request.PageInfo{Page: 1, PageSize: 10}
+
+func setupLoginGuardTest(t *testing.T) *LoginGuardService {
+	setupTestDB(t, &system.SysLoginLock{}, &system.SysLoginHistory{})
+	global.CONFIG.LoginGuard = config.LoginGuard{AccountLimit: 3, IpLimit: 5, CaptchaAfter: 2, DelayBase: 100, DelayMax: 300}
+	loginAccountCounter, loginIpCounter = newAttemptCounter("login:account:"), newAttemptCounter("login:ip:")
+	t.Cleanup(func() { global.CONFIG.LoginGuard = config.LoginGuard{} })
+	return &LoginGuardService{}
+}
+
+func TestLoginGuardAccountLock(t *testing.T) {
+	g := setupLoginGuardTest(t)
+	a := LoginAttempt{Channel: system.RefreshChannelBackend, Username: "Admin", Ip: "1.1.1.1"}
+
+	state, err := g.Failed(a, 0, "password")
+	require.NoError(t, err)
+	assert.False(t, state.NeedCaptcha)
+	assert.Equal(t, 100*time.Millisecond, state.Delay)
+
+	state, err = g.Failed(a, 0, "password")
+	require.NoError(t, err)
+	assert.True(t, state.NeedCaptcha)
+	assert.Equal(t, 200*time.Millisecond, state.Delay)
+	state, err = g.Check(a)
+	require.NoError(t, err)
+	assert.True(t, state.NeedCaptcha)
+
+	state, err = g.Failed(a, 0, "password")
+	require.NoError(t, err)
+	assert.Equal(t, 300*time.Millisecond, state.Delay)
+	assert.False(t, state.LockedUntil.IsZero())
+
+	// 用户名不区分大小写, 其他 ip 和其他端不受影响
+	_, err = g.Check(LoginAttempt{Channel: system.RefreshChannelBackend, Username: "admin", Ip: "1.1.1.1"})
+	assert.ErrorIs(t, err, ErrLoginLocked)
+	_, err = g.Check(LoginAttempt{Channel: system.RefreshChannelBackend, Username: "admin", Ip: "2.2.2.2"})
+	assert.NoError(t, err)
+	_, err = g.Check(LoginAttempt{Channel: system.RefreshChannelFrontend, Username: "admin", Ip: "1.1.1.1"})
+	assert.NoError(t, err)
+
+	locks, total, err := g.GetLockList(systemReq.SysLoginLockSearch{Kind: system.LoginLockAccount, PageInfo: loginGuardPage})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, total)
+	require.NoError(t, g.Unlock(locks[0].ID))
+	_, err = g.Check(a)
+	assert.NoError(t, err)
+
+	// 解锁后可以再次锁定
+	for range 3 {
+		_, err = g.Failed(a, 0, "password")
+		require.NoError(t, err)
+	}
+	_, err = g.Check(a)
+	assert.ErrorIs(t, err, ErrLoginLocked)
+}
+
+func TestLoginGuardIpLock(t *testing.T) {
+	g := setupLoginGuardTest(t)
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		_, err := g.Failed(LoginAttempt{Channel: system.RefreshChannelMobile, Username: name, Ip: "3.3.3.3"}, 0, "password")
+		require.NoError(t, err)
+	}
+	_, err := g.Check(LoginAttempt{Channel: system.RefreshChannelFrontend, Username: "f", Ip: "3.3.3.3"})
+	assert.ErrorIs(t, err, ErrLoginLocked)
+	_, err = g.Check(LoginAttempt{Channel: system.RefreshChannelFrontend, Username: "f", Ip: "4.4.4.4"})
+	assert.NoError(t, err)
+}
+
+func TestLoginGuardHistory(t *testing.T) {
+	g := setupLoginGuardTest(t)
+	a := LoginAttempt{Channel: system.RefreshChannelFrontend, Username: "user", Ip: "5.5.5.5", UserAgent: "test"}
+	_, err := g.Failed(a, 0, "password")
+	require.NoError(t, err)
+	require.NoError(t, g.Succeeded(a, 7))
+
+	// 登录成功清除账号的失败次数
+	n, err := loginAccountCounter.Count(a.accountKey())
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	success := true
+	list, total, err := g.GetHistoryList(systemReq.SysLoginHistorySearch{Username: "user", Success: &success, PageInfo: loginGuardPage})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, total)
+	require.NotNil(t, list[0].UserId)
+	assert.EqualValues(t, 7, *list[0].UserId)
+	_, total, err = g.GetHistoryList(systemReq.SysLoginHistorySearch{Channel: system.RefreshChannelFrontend, PageInfo: loginGuardPage})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, total)
+
+	// 登录失败不写 user_id 为 0 的记录
+	var zero int64
+	require.NoError(t, global.DB.Model(&system.SysLoginHistory{}).Where("user_id = 0").Count(&zero).Error)
+	assert.Zero(t, zero)
+}
+
+// 不存在的用户名各自按用户名和 ip 计数, 不会共用一个计数
+func TestLoginGuardUnknownUsers(t *testing.T) {
+	g := setupLoginGuardTest(t)
+	for _, name := range []string{"ghost1", "ghost2", "ghost3"} {
+		state, err := g.Failed(LoginAttempt{Channel: system.RefreshChannelBackend, Username: name, Ip: "6.6.6.6"}, 0, "password")
+		require.NoError(t, err)
+		assert.True(t, state.LockedUntil.IsZero(), name)
+	}
+	n, err := loginAccountCounter.Count(LoginAttempt{Channel: system.RefreshChannelBackend, Username: "ghost1", Ip: "6.6.6.6"}.accountKey())
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, n)
+	n, err = loginAccountCounter.Count(LoginAttempt{Channel: system.RefreshChannelBackend, Username: "ghost1", Ip: "7.7.7.7"}.accountKey())
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
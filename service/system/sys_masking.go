@@ -0,0 +1,55 @@
+package system
+
+import (
+	global "server/model"
+	"server/model/system"
+	"server/utils/mask"
+)
+
+// 查看完整数据的权限在 casbin 中的 obj 和 act
+const (
+	MaskingRevealPath   = "/masking/reveal"
+	MaskingRevealMethod = "REVEAL"
+)
+
+//@function: Policy
+//@description: 获取角色的脱敏策略, 未配置规则时返回 nil
+//@param: authorityId string
+//@return: mask.Policy
+
+func (m *MaskingService) Policy(authorityId string) mask.Policy {
+	for _, a := range global.CONFIG.Masking.Authorities {
+		if a.AuthorityId != authorityId || len(a.Fields) == 0 {
+			continue
+		}
+		policy := make(mask.Policy, len(a.Fields))
+		for _, f := range a.Fields {
+			policy[f.Field] = f.Mode
+		}
+		return policy
+	}
+	return nil
+}
+
+//@function: CanReveal
+//@description: 角色是否拥有查看完整数据的权限
+//@param: authorityId string
+//@return: bool, error
+
+func (m *MaskingService) CanReveal(authorityId string) (bool, error) {
+	return CasbinServiceApp.Casbin().Enforce(authorityId, MaskingRevealPath, MaskingRevealMethod)
+}
+
+//@function: EnsureRevealApi
+//@description: 补充查看完整数据权限的 api 记录, 便于在角色的 api 权限中分配
+//@return: error
+
+func (m *MaskingService) EnsureRevealApi() error {
+	api := system.SysApi{
+		Path:        MaskingRevealPath,
+		Method:      MaskingRevealMethod,
+		ApiGroup:    "masking",
+		Description: "查看未脱敏的敏感字段",
+	}
+	return global.DB.Where("path = ? AND method = ?", api.Path, api.Method).FirstOrCreate(&api).Error
+}
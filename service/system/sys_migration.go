@@ -0,0 +1,392 @@
+package system
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	global "server/model"
+	"server/model/system"
+	systemRes "server/model/system/response"
+	"server/utils"
+
+	"go.uber.org/zap"
+)
+
+var (
+	// {version}_{name}.up.sql 所有数据库通用, {version}_{name}.{dialect}.up.sql 只在对应数据库执行, 同一版本优先使用对应数据库的文件
+	migrationFileName = regexp.MustCompile(`^(\d+)_(.+?)(?:\.(mysql|postgres|sqlite))?\.(up|down)\.sql$`)
+	migrationLock     sync.Mutex
+)
+
+const (
+	migrationLockName    = "server_migration" // mysql GET_LOCK 的锁名
+	migrationLockKey     = 1727331608         // pgsql advisory lock 的 key
+	migrationLockTimeout = 10 * time.Minute   // 等待其他实例执行完迁移的最长时间
+)
+
+// 迁移文件自带的事务控制语句, 由迁移器统一放到一个事务中执行, 文件里的直接跳过
+var migrationTxStatements = map[string]bool{
+	"BEGIN":             true,
+	"START TRANSACTION": true,
+	"COMMIT":            true,
+}
+
+type migrationFile struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Dialects []string // 只提供了部分数据库的文件时, 有文件的数据库
+}
+
+//@function: Status
+//@description: 列出迁移目录下所有版本及执行状态
+//@return: []systemRes.MigrationStatus, error
+
+func (m *MigrationService) Status() ([]systemRes.MigrationStatus, error) {
+	files, applied, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]systemRes.MigrationStatus, 0, len(files))
+	for _, f := range files {
+		status := systemRes.MigrationStatus{Version: f.Version, Name: f.Name, HasDown: f.Down != ""}
+		if record, ok := applied[f.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &record.AppliedAt
+		}
+		list = append(list, status)
+	}
+	return list, nil
+}
+
+//@function: Up
+//@description: 按版本顺序执行未执行的 up 文件, 每个版本一个事务, steps<=0 时执行全部
+//@param: steps int
+//@return: []int64, error
+
+func (m *MigrationService) Up(steps int) ([]int64, error) {
+	unlock, err := m.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	files, applied, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+	var done []int64
+	for _, f := range files {
+		if steps > 0 && len(done) >= steps {
+			break
+		}
+		if _, ok := applied[f.Version]; ok {
+			continue
+		}
+		if f.Up == "" {
+			return done, m.missingFile(f, "up")
+		}
+		if err = m.exec(f, f.Up, true); err != nil {
+			return done, err
+		}
+		global.LOG.Info("执行迁移成功", zap.Int64("version", f.Version), zap.String("name", f.Name))
+		done = append(done, f.Version)
+	}
+	return done, nil
+}
+
+//@function: Down
+//@description: 按版本倒序回滚最近执行的迁移, steps<=0 时回滚 1 个
+//@param: steps int
+//@return: []int64, error
+
+func (m *MigrationService) Down(steps int) ([]int64, error) {
+	unlock, err := m.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if steps <= 0 {
+		steps = 1
+	}
+	files, applied, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int64]migrationFile, len(files))
+	for _, f := range files {
+		byVersion[f.Version] = f
+	}
+	versions := make([]int64, 0, len(applied))
+	for v := range applied {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+
+	var done []int64
+	for _, v := range versions {
+		if len(done) >= steps {
+			break
+		}
+		f, ok := byVersion[v]
+		if !ok {
+			return done, fmt.Errorf("迁移 %d_%s 缺少 down 文件, 无法回滚", v, applied[v].Name)
+		}
+		if f.Down == "" {
+			return done, m.missingFile(f, "down")
+		}
+		if err = m.exec(f, f.Down, false); err != nil {
+			return done, err
+		}
+		global.LOG.Info("回滚迁移成功", zap.Int64("version", f.Version), zap.String("name", f.Name))
+		done = append(done, v)
+	}
+	return done, nil
+}
+
+//@function: Baseline
+//@description: 把 version 及之前未执行的迁移记为已执行但不执行文件, 用于表结构已经由其他方式建好的数据库, version<=0 时为全部
+//@param: version int64
+//@return: []int64, error
+
+func (m *MigrationService) Baseline(version int64) ([]int64, error) {
+	unlock, err := m.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	files, applied, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+	var done []int64
+	for _, f := range files {
+		if version > 0 && f.Version > version {
+			break
+		}
+		if _, ok := applied[f.Version]; ok {
+			continue
+		}
+		record := system.SysMigration{Version: f.Version, Name: f.Name, AppliedAt: time.Now()}
+		if err = global.DB.Create(&record).Error; err != nil {
+			return done, err
+		}
+		global.LOG.Info("标记迁移为已执行", zap.Int64("version", f.Version), zap.String("name", f.Name))
+		done = append(done, f.Version)
+	}
+	return done, nil
+}
+
+// lock 多个实例同时启动时只有一个执行迁移, mysql/pgsql 使用数据库的会话锁, 进程退出时自动释放
+// sqlite 只能单机使用, 由文件锁保证写入互斥, 这里只需要进程内的锁
+func (m *MigrationService) lock() (unlock func(), err error) {
+	if global.DB == nil {
+		return nil, errors.New("数据库未初始化")
+	}
+	migrationLock.Lock()
+	var acquire, release string
+	switch global.DB.Dialector.Name() {
+	case "mysql":
+		acquire = fmt.Sprintf("SELECT GET_LOCK('%s', %d)", migrationLockName, int(migrationLockTimeout.Seconds()))
+		release = fmt.Sprintf("SELECT RELEASE_LOCK('%s')", migrationLockName)
+	case "postgres":
+		acquire = fmt.Sprintf("SELECT 1 FROM pg_advisory_lock(%d)", migrationLockKey) // pg_advisory_lock 返回 void, 能返回即已加锁
+		release = fmt.Sprintf("SELECT pg_advisory_unlock(%d)", migrationLockKey)
+	default:
+		return migrationLock.Unlock, nil
+	}
+	sqlDB, err := global.DB.DB()
+	if err != nil {
+		migrationLock.Unlock()
+		return nil, err
+	}
+	// 会话锁属于连接, 加锁和解锁必须使用同一个连接
+	ctx, cancel := context.WithTimeout(context.Background(), migrationLockTimeout)
+	defer cancel()
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		migrationLock.Unlock()
+		return nil, err
+	}
+	var ok sql.NullInt64 // GET_LOCK 超时返回 0, 出错返回 NULL
+	if err = conn.QueryRowContext(ctx, acquire).Scan(&ok); err == nil && ok.Int64 != 1 {
+		err = errors.New("等待其他实例执行迁移超时")
+	}
+	if err != nil {
+		_ = conn.Close()
+		migrationLock.Unlock()
+		return nil, fmt.Errorf("获取迁移锁失败: %w", err)
+	}
+	return func() {
+		if _, err := conn.ExecContext(context.Background(), release); err != nil {
+			global.LOG.Error("释放迁移锁失败", zap.Error(err))
+		}
+		_ = conn.Close()
+		migrationLock.Unlock()
+	}, nil
+}
+
+// missingFile 只提供了其他数据库的迁移文件时拒绝执行, 避免把 mysql 的语句用在 pgsql 上
+func (m *MigrationService) missingFile(f migrationFile, direction string) error {
+	if len(f.Dialects) > 0 {
+		return fmt.Errorf("迁移 %d_%s 只提供了 %s 的 %s 文件, 不支持 %s", f.Version, f.Name, strings.Join(f.Dialects, "/"), direction, global.DB.Dialector.Name())
+	}
+	return fmt.Errorf("迁移 %d_%s 缺少 %s 文件", f.Version, f.Name, direction)
+}
+
+// load 读取迁移目录与已执行记录
+func (m *MigrationService) load() ([]migrationFile, map[int64]system.SysMigration, error) {
+	if global.DB == nil {
+		return nil, nil, errors.New("数据库未初始化")
+	}
+	if err := global.DB.AutoMigrate(&system.SysMigration{}); err != nil {
+		return nil, nil, err
+	}
+	files, err := m.scan()
+	if err != nil {
+		return nil, nil, err
+	}
+	var records []system.SysMigration
+	if err = global.DB.Find(&records).Error; err != nil {
+		return nil, nil, err
+	}
+	applied := make(map[int64]system.SysMigration, len(records))
+	for _, r := range records {
+		applied[r.Version] = r
+	}
+	return files, applied, nil
+}
+
+// scan 解析迁移目录, 按当前数据库选择文件, 按版本升序返回
+func (m *MigrationService) scan() ([]migrationFile, error) {
+	dir := global.CONFIG.System.MigrationDir
+	if dir == "" {
+		dir = "./migration"
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	dialect := global.DB.Dialector.Name()
+	type candidate struct {
+		migrationFile
+		dialectUp, dialectDown string
+	}
+	byVersion := make(map[int64]*candidate)
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		f, ok := byVersion[version]
+		if !ok {
+			f = &candidate{migrationFile: migrationFile{Version: version, Name: match[2]}}
+			byVersion[version] = f
+		} else if f.Name != match[2] {
+			return nil, fmt.Errorf("迁移版本 %d 重复: %s, %s", version, f.Name, match[2])
+		}
+		path := filepath.Join(dir, entry.Name())
+		switch {
+		case match[3] == "":
+			if match[4] == "up" {
+				f.Up = path
+			} else {
+				f.Down = path
+			}
+		case match[3] == dialect:
+			if match[4] == "up" {
+				f.dialectUp = path
+			} else {
+				f.dialectDown = path
+			}
+		default:
+			if !slices.Contains(f.Dialects, match[3]) {
+				f.Dialects = append(f.Dialects, match[3])
+			}
+		}
+	}
+	files := make([]migrationFile, 0, len(byVersion))
+	for _, f := range byVersion {
+		if f.dialectUp != "" || f.dialectDown != "" {
+			f.Up, f.Down, f.Dialects = f.dialectUp, f.dialectDown, nil
+		} else if f.Up != "" || f.Down != "" {
+			f.Dialects = nil
+		} else {
+			sort.Strings(f.Dialects)
+		}
+		files = append(files, f.migrationFile)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Version < files[j].Version })
+	return files, nil
+}
+
+// exec 在一个事务中执行迁移文件并写入/删除版本记录
+// 使用底层 *sql.DB, 避免 PrepareStmt 对 DDL 语句做预编译; 注意 mysql 的 DDL 会隐式提交, 无法随事务回滚
+func (m *MigrationService) exec(f migrationFile, path string, up bool) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	sqlDB, err := global.DB.DB()
+	if err != nil {
+		return err
+	}
+	tx, err := sqlDB.Begin()
+	if err != nil {
+		return err
+	}
+	for _, statement := range utils.SplitSQLStatements(string(content)) {
+		if migrationTxStatements[strings.ToUpper(statement)] {
+			continue
+		}
+		if _, err = tx.Exec(statement); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("执行迁移 %s 失败: %w", filepath.Base(path), err)
+		}
+	}
+	if up {
+		_, err = tx.Exec(m.bind("INSERT INTO sys_migrations (version, name, applied_at) VALUES (?, ?, ?)"), f.Version, f.Name, time.Now())
+	} else {
+		_, err = tx.Exec(m.bind("DELETE FROM sys_migrations WHERE version = ?"), f.Version)
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// bind pgsql 使用 $n 占位符
+func (m *MigrationService) bind(query string) string {
+	if global.DB.Dialector.Name() != "postgres" {
+		return query
+	}
+	var (
+		b strings.Builder
+		n int
+	)
+	for _, ch := range query {
+		if ch == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(ch)
+	}
+	return b.String()
+}
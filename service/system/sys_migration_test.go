@@ -0,0 +1,109 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	global "server/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupMigrationTest(t *testing.T) string {
+	global.CONFIG.System.MigrationDir = filepath.Join(setupTestDB(t), "migration")
+	require.NoError(t, os.MkdirAll(global.CONFIG.System.MigrationDir, 0o755))
+	return global.CONFIG.System.MigrationDir
+}
+
+func writeMigration(t *testing.T, dir, name, content string) {
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+}
+
+func TestMigrationUpDown(t *testing.T) {
+	dir := setupMigrationTest(t)
+	writeMigration(t, dir, "1_create_a.up.sql", "BEGIN;\nCREATE TABLE a (id int);\nINSERT INTO a VALUES (1);\nCOMMIT;")
+	writeMigration(t, dir, "1_create_a.down.sql", "DROP TABLE a;")
+	writeMigration(t, dir, "2_create_b.up.sql", "CREATE TABLE b (id int);")
+	svc := &MigrationService{}
+
+	t.Run("执行全部迁移", func(t *testing.T) {
+		versions, err := svc.Up(0)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 2}, versions)
+		assert.True(t, global.DB.Migrator().HasTable("a"))
+
+		status, err := svc.Status()
+		require.NoError(t, err)
+		require.Len(t, status, 2)
+		assert.True(t, status[0].Applied)
+		assert.False(t, status[1].HasDown)
+	})
+
+	t.Run("缺少down文件时不能回滚", func(t *testing.T) {
+		_, err := svc.Down(1)
+		assert.Error(t, err)
+	})
+
+	t.Run("失败的迁移整体回滚", func(t *testing.T) {
+		writeMigration(t, dir, "3_broken.up.sql", "CREATE TABLE c (id int);\nINSERT INTO missing VALUES (1);")
+		_, err := svc.Up(0)
+		assert.Error(t, err)
+		assert.False(t, global.DB.Migrator().HasTable("c"))
+
+		status, err := svc.Status()
+		require.NoError(t, err)
+		assert.False(t, status[2].Applied)
+	})
+}
+
+func TestMigrationDuplicateVersion(t *testing.T) {
+	dir := setupMigrationTest(t)
+	writeMigration(t, dir, "1_a.up.sql", "")
+	writeMigration(t, dir, "1_b.up.sql", "")
+	_, err := (&MigrationService{}).Status()
+	assert.Error(t, err)
+}
+
+func TestMigrationDialectFiles(t *testing.T) {
+	dir := setupMigrationTest(t)
+	writeMigration(t, dir, "1_init.mysql.up.sql", "CREATE TABLE `a` (`id` int) ENGINE=InnoDB;")
+	writeMigration(t, dir, "1_init.sqlite.up.sql", "CREATE TABLE a (id int);")
+	writeMigration(t, dir, "1_init.sqlite.down.sql", "DROP TABLE a;")
+	writeMigration(t, dir, "2_add_b.up.sql", "CREATE TABLE b (id int);")
+	svc := &MigrationService{}
+
+	t.Run("优先使用当前数据库的文件", func(t *testing.T) {
+		versions, err := svc.Up(0)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 2}, versions)
+		assert.True(t, global.DB.Migrator().HasTable("a"))
+		_, err = svc.Down(2)
+		assert.Error(t, err, "2 没有 down 文件")
+	})
+
+	t.Run("只有其他数据库的文件时拒绝执行", func(t *testing.T) {
+		writeMigration(t, dir, "3_mysql_only.mysql.up.sql", "ALTER TABLE `a` MODIFY `id` bigint;")
+		_, err := svc.Up(0)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "只提供了 mysql")
+	})
+}
+
+func TestMigrationBaseline(t *testing.T) {
+	dir := setupMigrationTest(t)
+	writeMigration(t, dir, "1_init.mysql.up.sql", "CREATE TABLE `a` (`id` int) ENGINE=InnoDB;")
+	writeMigration(t, dir, "2_add_b.up.sql", "CREATE TABLE b (id int);")
+	svc := &MigrationService{}
+
+	// 表结构已由其他方式建好, 记为已执行后从下一个版本继续
+	versions, err := svc.Baseline(1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, versions)
+	versions, err = svc.Up(0)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, versions)
+	assert.False(t, global.DB.Migrator().HasTable("a"))
+	assert.True(t, global.DB.Migrator().HasTable("b"))
+}
@@ -0,0 +1,298 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"server/config"
+	global "server/model"
+	"server/model/system"
+	"server/utils"
+	"server/utils/crypt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrOidcProvider  = errors.New("未配置该身份提供方")
+	ErrOidcState     = errors.New("登录已失效, 请重新发起")
+	ErrOidcNotLinked = errors.New("该外部账号未关联本系统用户")
+	ErrOidcLinked    = errors.New("该外部账号已关联其他用户")
+)
+
+// oidcClients 按身份提供方缓存客户端, 配置变化后重新创建
+var oidcClients sync.Map
+
+type oidcClientEntry struct {
+	conf   config.OidcProvider
+	client *utils.OidcClient
+}
+
+var oidcUsernameInvalid = regexp.MustCompile(`[^a-zA-Z0-9_.@-]+`)
+
+//@function: Providers
+//@description: 已配置的身份提供方名称
+//@return: []string
+
+func (o *OidcService) Providers() []string {
+	names := make([]string, 0, len(global.CONFIG.Oidc.Providers))
+	for _, p := range global.CONFIG.Oidc.Providers {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+//@function: AuthURL
+//@description: 发起 OIDC 登录, 保存 state、nonce 和 PKCE code_verifier 后返回身份提供方的授权地址
+//@param: provider string, channel string 发起端 backend/frontend, linkUserId uint 不为0时回调只关联账号
+//@return: string, error
+
+func (o *OidcService) AuthURL(ctx context.Context, provider, channel string, linkUserId uint) (string, error) {
+	conf, client, err := o.client(provider)
+	if err != nil {
+		return "", err
+	}
+	redirectURL, err := o.redirectURL(conf, channel)
+	if err != nil {
+		return "", err
+	}
+	var state, nonce, verifier string
+	for _, v := range []*string{&state, &nonce, &verifier} {
+		if *v, err = utils.RandomToken(32); err != nil {
+			return "", err
+		}
+	}
+	authURL, err := client.AuthCodeURL(ctx, redirectURL, state, nonce, verifier)
+	if err != nil {
+		return "", err
+	}
+	seconds := global.CONFIG.Oidc.StateTime
+	if seconds <= 0 {
+		seconds = 600
+	}
+	err = global.DB.Create(&system.SysOidcState{
+		StateHash:    utils.Sha256V(state),
+		Provider:     provider,
+		Channel:      channel,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		LinkUserId:   linkUserId,
+		ExpiresAt:    time.Now().Add(time.Duration(seconds) * time.Second),
+	}).Error
+	return authURL, err
+}
+
+//@function: Callback
+//@description: 处理回调, 用授权码换取 id_token 后找到或创建对应用户; 关联账号时返回 linked 为 true, 不返回用户
+//@param: channel string, code string, state string
+//@return: user *system.SysUser, linked bool, err error
+
+func (o *OidcService) Callback(ctx context.Context, channel, code, state string) (user *system.SysUser, linked bool, err error) {
+	record, err := o.consumeState(channel, state)
+	if err != nil {
+		return nil, false, err
+	}
+	conf, client, err := o.client(record.Provider)
+	if err != nil {
+		return nil, false, err
+	}
+	redirectURL, err := o.redirectURL(conf, channel)
+	if err != nil {
+		return nil, false, err
+	}
+	identity, err := client.Exchange(ctx, redirectURL, code, record.CodeVerifier, record.Nonce)
+	if err != nil {
+		return nil, false, err
+	}
+	if record.LinkUserId != 0 {
+		return nil, true, o.link(record.Provider, identity, record.LinkUserId)
+	}
+	userId, err := o.resolve(conf, identity, channel)
+	if err != nil {
+		return nil, false, err
+	}
+	now := time.Now()
+	global.DB.Model(&system.SysUserIdentity{}).Where("provider = ? AND subject = ?", record.Provider, identity.Subject).Update("last_login_at", &now)
+	var u system.SysUser
+	err = global.DB.Where("id = ?", userId).Preload("Authorities").Preload("Authority").First(&u).Error
+	return &u, false, err
+}
+
+//@function: GetIdentities
+//@description: 用户已关联的外部身份
+//@param: userId uint
+//@return: list []system.SysUserIdentity, err error
+
+func (o *OidcService) GetIdentities(userId uint) (list []system.SysUserIdentity, err error) {
+	err = global.DB.Where("user_id = ?", userId).Order("id").Find(&list).Error
+	return list, err
+}
+
+//@function: Unlink
+//@description: 解除外部身份关联
+//@param: userId uint, id uint
+//@return: error
+
+func (o *OidcService) Unlink(userId, id uint) error {
+	result := global.DB.Unscoped().Where("id = ? AND user_id = ?", id, userId).Delete(&system.SysUserIdentity{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (o *OidcService) client(name string) (config.OidcProvider, *utils.OidcClient, error) {
+	for _, p := range global.CONFIG.Oidc.Providers {
+		if p.Name != name {
+			continue
+		}
+		if v, ok := oidcClients.Load(name); ok && reflect.DeepEqual(v.(*oidcClientEntry).conf, p) {
+			return p, v.(*oidcClientEntry).client, nil
+		}
+		entry := &oidcClientEntry{conf: p, client: utils.NewOidcClient(p)}
+		oidcClients.Store(name, entry)
+		return p, entry.client, nil
+	}
+	return config.OidcProvider{}, nil, ErrOidcProvider
+}
+
+func (o *OidcService) redirectURL(p config.OidcProvider, channel string) (string, error) {
+	redirectURL := p.BackendRedirectURL
+	if channel == system.RefreshChannelFrontend {
+		redirectURL = p.FrontendRedirectURL
+	}
+	if redirectURL == "" {
+		return "", fmt.Errorf("%w: %s 未配置 %s 回调地址", ErrOidcProvider, p.Name, channel)
+	}
+	return redirectURL, nil
+}
+
+// consumeState 校验并作废 state, 只能使用一次
+func (o *OidcService) consumeState(channel, state string) (record system.SysOidcState, err error) {
+	err = global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("state_hash = ?", utils.Sha256V(state)).First(&record).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrOidcState
+			}
+			return err
+		}
+		if record.UsedAt != nil || record.Channel != channel || time.Now().After(record.ExpiresAt) {
+			return ErrOidcState
+		}
+		result := tx.Model(&system.SysOidcState{}).Where("id = ? AND used_at IS NULL", record.ID).Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrOidcState
+		}
+		return nil
+	})
+	return record, err
+}
+
+// link 把外部身份关联到已登录的用户
+func (o *OidcService) link(provider string, identity utils.OidcIdentity, userId uint) error {
+	var existing system.SysUserIdentity
+	err := global.DB.Where("provider = ? AND subject = ?", provider, identity.Subject).First(&existing).Error
+	if err == nil {
+		if existing.UserId != userId {
+			return ErrOidcLinked
+		}
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return global.DB.Create(&system.SysUserIdentity{UserId: userId, Provider: provider, Subject: identity.Subject, Email: identity.Email}).Error
+}
+
+// resolve 按已关联的身份、已验证的邮箱、自动创建的顺序找到登录的用户
+func (o *OidcService) resolve(p config.OidcProvider, identity utils.OidcIdentity, channel string) (uint, error) {
+	var existing system.SysUserIdentity
+	err := global.DB.Where("provider = ? AND subject = ?", p.Name, identity.Subject).First(&existing).Error
+	if err == nil {
+		return existing.UserId, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+
+	if p.LinkByEmail && identity.EmailVerified && identity.Email != "" {
+		var users []system.SysUser
+		if err = global.DB.Select("id").Where("email_bidx = ?", crypt.BlindIndex(identity.Email)).Limit(2).Find(&users).Error; err != nil {
+			return 0, err
+		}
+		// 多个用户使用同一邮箱时无法确定关联哪一个
+		if len(users) == 1 {
+			return users[0].ID, o.link(p.Name, identity, users[0].ID)
+		}
+	}
+
+	authorityId := p.AuthorityId
+	if channel == system.RefreshChannelFrontend {
+		authorityId = p.FrontendAuthorityId
+	}
+	if !p.AutoCreate || authorityId == "" {
+		return 0, ErrOidcNotLinked
+	}
+	password, err := utils.RandomToken(32)
+	if err != nil {
+		return 0, err
+	}
+	user := &system.SysUser{
+		Username:    o.username(p, identity),
+		NickName:    identity.Name,
+		Password:    password,
+		AuthorityId: authorityId,
+		Authorities: []system.SysAuthority{{AuthorityId: authorityId}},
+	}
+	if user.NickName == "" {
+		user.NickName = user.Username
+	}
+	if identity.EmailVerified {
+		user.Email = identity.Email
+	}
+	if user, err = UserServiceApp.Register(user); err != nil {
+		return 0, err
+	}
+	global.LOG.Info("OIDC 登录自动创建用户", zap.String("provider", p.Name), zap.String("username", user.Username))
+	return user.ID, o.link(p.Name, identity, user.ID)
+}
+
+// username 自动创建用户时的用户名, 已被占用时追加序号
+func (o *OidcService) username(p config.OidcProvider, identity utils.OidcIdentity) string {
+	claim := p.UsernameClaim
+	if claim == "" {
+		claim = "preferred_username"
+	}
+	base := identity.Claim(claim)
+	if base == "" && identity.Email != "" {
+		base, _, _ = strings.Cut(identity.Email, "@")
+	}
+	base = oidcUsernameInvalid.ReplaceAllString(base, "")
+	if base == "" {
+		base = p.Name + "_" + identity.Subject
+	}
+	if len(base) > 64 {
+		base = base[:64]
+	}
+	name := base
+	for i := 1; ; i++ {
+		var count int64
+		if global.DB.Model(&system.SysUser{}).Where("username = ?", name).Count(&count); count == 0 {
+			return name
+		}
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+}
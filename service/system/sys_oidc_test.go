@@ -0,0 +1,143 @@
+package system
+
+import (
+	"context"
+	"testing"
+
+	"server/config"
+	global "server/model"
+	"server/model/system"
+	"server/utils/oidctest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupOidcTest(t *testing.T, p config.OidcProvider) (*OidcService, *oidctest.Provider) {
+	setupTestDB(t, &system.SysUser{}, &system.SysAuthority{}, &system.SysUserIdentity{}, &system.SysOidcState{})
+	idp := oidctest.NewProvider("server")
+	t.Cleanup(idp.Close)
+	p.Name, p.Issuer, p.ClientId = "corp", idp.Issuer(), "server"
+	p.BackendRedirectURL, p.FrontendRedirectURL = "http://admin.local/oidc", "http://www.local/oidc"
+	global.CONFIG.Oidc = config.Oidc{Providers: []config.OidcProvider{p}}
+	t.Cleanup(func() {
+		global.CONFIG.Oidc = config.Oidc{}
+		oidcClients.Delete("corp")
+	})
+	return &OidcService{}, idp
+}
+
+// oidcLogin 模拟浏览器完成一次授权, 返回回调参数
+func oidcLogin(t *testing.T, o *OidcService, idp *oidctest.Provider, channel string, linkUserId uint) (code, state string) {
+	authURL, err := o.AuthURL(context.Background(), "corp", channel, linkUserId)
+	require.NoError(t, err)
+	code, state, err = idp.Authorize(authURL)
+	require.NoError(t, err)
+	return code, state
+}
+
+func TestOidcAutoCreate(t *testing.T) {
+	o, idp := setupOidcTest(t, config.OidcProvider{AutoCreate: true, FrontendAuthorityId: "2"})
+	require.NoError(t, global.DB.Create(&system.SysUser{Username: "alice", Password: "x"}).Error)
+	idp.Login(map[string]any{"sub": "s-1", "preferred_username": "alice", "email": "alice@corp.io", "email_verified": true})
+
+	// 后台未配置自动创建的角色
+	code, state := oidcLogin(t, o, idp, system.RefreshChannelBackend, 0)
+	_, _, err := o.Callback(context.Background(), system.RefreshChannelBackend, code, state)
+	assert.ErrorIs(t, err, ErrOidcNotLinked)
+
+	code, state = oidcLogin(t, o, idp, system.RefreshChannelFrontend, 0)
+	user, linked, err := o.Callback(context.Background(), system.RefreshChannelFrontend, code, state)
+	require.NoError(t, err)
+	assert.False(t, linked)
+	assert.Equal(t, "alice_1", user.Username)
+	assert.Equal(t, "2", user.AuthorityId)
+	assert.Equal(t, "alice@corp.io", user.Email)
+
+	// 再次登录使用已关联的用户
+	code, state = oidcLogin(t, o, idp, system.RefreshChannelFrontend, 0)
+	again, _, err := o.Callback(context.Background(), system.RefreshChannelFrontend, code, state)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, again.ID)
+
+	list, err := o.GetIdentities(user.ID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "s-1", list[0].Subject)
+	assert.NotNil(t, list[0].LastLoginAt)
+}
+
+func TestOidcLinkByEmail(t *testing.T) {
+	o, idp := setupOidcTest(t, config.OidcProvider{LinkByEmail: true})
+	bob := system.SysUser{Username: "bob", Password: "x", Email: "Bob@corp.io"}
+	require.NoError(t, global.DB.Create(&bob).Error)
+
+	// 未验证的邮箱不关联
+	idp.Login(map[string]any{"sub": "s-2", "email": "bob@corp.io", "email_verified": false})
+	code, state := oidcLogin(t, o, idp, system.RefreshChannelBackend, 0)
+	_, _, err := o.Callback(context.Background(), system.RefreshChannelBackend, code, state)
+	assert.ErrorIs(t, err, ErrOidcNotLinked)
+
+	idp.Login(map[string]any{"sub": "s-2", "email": "bob@corp.io", "email_verified": true})
+	code, state = oidcLogin(t, o, idp, system.RefreshChannelBackend, 0)
+	user, _, err := o.Callback(context.Background(), system.RefreshChannelBackend, code, state)
+	require.NoError(t, err)
+	assert.Equal(t, bob.ID, user.ID)
+}
+
+func TestOidcExplicitLink(t *testing.T) {
+	o, idp := setupOidcTest(t, config.OidcProvider{})
+	carol := system.SysUser{Username: "carol", Password: "x"}
+	dave := system.SysUser{Username: "dave", Password: "x"}
+	require.NoError(t, global.DB.Create(&carol).Error)
+	require.NoError(t, global.DB.Create(&dave).Error)
+	idp.Login(map[string]any{"sub": "s-3"})
+
+	code, state := oidcLogin(t, o, idp, system.RefreshChannelBackend, carol.ID)
+	user, linked, err := o.Callback(context.Background(), system.RefreshChannelBackend, code, state)
+	require.NoError(t, err)
+	assert.True(t, linked)
+	assert.Nil(t, user)
+
+	// 同一外部账号不能再关联到其他用户
+	code, state = oidcLogin(t, o, idp, system.RefreshChannelBackend, dave.ID)
+	_, _, err = o.Callback(context.Background(), system.RefreshChannelBackend, code, state)
+	assert.ErrorIs(t, err, ErrOidcLinked)
+
+	code, state = oidcLogin(t, o, idp, system.RefreshChannelBackend, 0)
+	user, _, err = o.Callback(context.Background(), system.RefreshChannelBackend, code, state)
+	require.NoError(t, err)
+	assert.Equal(t, carol.ID, user.ID)
+
+	list, err := o.GetIdentities(carol.ID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Error(t, o.Unlink(dave.ID, list[0].ID))
+	require.NoError(t, o.Unlink(carol.ID, list[0].ID))
+	code, state = oidcLogin(t, o, idp, system.RefreshChannelBackend, 0)
+	_, _, err = o.Callback(context.Background(), system.RefreshChannelBackend, code, state)
+	assert.ErrorIs(t, err, ErrOidcNotLinked)
+}
+
+func TestOidcState(t *testing.T) {
+	o, idp := setupOidcTest(t, config.OidcProvider{LinkByEmail: true})
+	require.NoError(t, global.DB.Create(&system.SysUser{Username: "erin", Password: "x", Email: "erin@corp.io"}).Error)
+	idp.Login(map[string]any{"sub": "s-4", "email": "erin@corp.io", "email_verified": true})
+
+	_, err := o.AuthURL(context.Background(), "other", system.RefreshChannelBackend, 0)
+	assert.ErrorIs(t, err, ErrOidcProvider)
+
+	// 后台发起的登录不能在前台完成
+	code, state := oidcLogin(t, o, idp, system.RefreshChannelBackend, 0)
+	_, _, err = o.Callback(context.Background(), system.RefreshChannelFrontend, code, state)
+	assert.ErrorIs(t, err, ErrOidcState)
+
+	code, state = oidcLogin(t, o, idp, system.RefreshChannelBackend, 0)
+	_, _, err = o.Callback(context.Background(), system.RefreshChannelBackend, code, state)
+	require.NoError(t, err)
+	_, _, err = o.Callback(context.Background(), system.RefreshChannelBackend, code, state)
+	assert.ErrorIs(t, err, ErrOidcState)
+
+	_, _, err = o.Callback(context.Background(), system.RefreshChannelBackend, code, "forged")
+	assert.ErrorIs(t, err, ErrOidcState)
+}
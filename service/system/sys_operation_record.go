@@ -5,6 +5,8 @@ import (
 	"server/model/common/request"
 	"server/model/system"
 	systemReq "server/model/system/request"
+
+	"gorm.io/gorm"
 )
 
 //@function: CreateSysOperationRecord
@@ -52,12 +54,12 @@ func (operationRecordService *OperationRecordService) GetSysOperationRecord(id u
 //@param: info systemReq.SysOperationRecordSearch
 //@return: err error, list any, total int64
 
-func (operationRecordService *OperationRecordService) GetSysOperationRecordInfoList(info *systemReq.SysOperationRecordSearch) (list []system.SysOperationRecord, total int64, err error) {
+func (operationRecordService *OperationRecordService) GetSysOperationRecordInfoList(info *systemReq.SysOperationRecordSearch, scopes ...func(*gorm.DB) *gorm.DB) (list []system.SysOperationRecord, total int64, err error) {
 	// time.Sleep(3 * time.Second)
 	limit := info.PageSize
 	offset := info.PageSize * (info.Page - 1)
 	// 创建db
-	db := global.DB.Model(&system.SysOperationRecord{})
+	db := global.DB.Model(&system.SysOperationRecord{}).Scopes(scopes...)
 	var sysOperationRecords []system.SysOperationRecord
 	db = db.Where("type_port = ?", info.TypePort)
 	// 如果有条件搜索 下方会自动创建搜索语句
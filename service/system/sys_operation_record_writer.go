@@ -0,0 +1,174 @@
+package system
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"server/config"
+	global "server/model"
+	"server/model/system"
+	systemRes "server/model/system/response"
+
+	"go.uber.org/zap"
+)
+
+// OperationRecordWriter 操作记录的异步批量写入, 由 core.RunServer 启动和关闭, 未启动时同步写入
+type OperationRecordWriter struct {
+	mu      sync.RWMutex
+	records chan system.SysOperationRecord
+	done    chan struct{}
+
+	pending atomic.Int64 // 已提交未写入, 包括队列中和后台已取出的
+	written atomic.Int64
+	dropped atomic.Int64
+	failed  atomic.Int64
+}
+
+//@function: Start
+//@description: 启动后台写入, 队列满一批或到达间隔时写入数据库, 重复调用无效
+//@param: conf config.OperationRecord
+
+func (w *OperationRecordWriter) Start(conf config.OperationRecord) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.records != nil {
+		return
+	}
+	if conf.BufferSize <= 0 {
+		conf.BufferSize = 4096
+	}
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = 100
+	}
+	if conf.FlushInterval <= 0 {
+		conf.FlushInterval = 1000
+	}
+	w.records = make(chan system.SysOperationRecord, conf.BufferSize)
+	w.done = make(chan struct{})
+	go w.run(w.records, w.done, conf.BatchSize, time.Duration(conf.FlushInterval)*time.Millisecond)
+}
+
+//@function: Write
+//@description: 提交操作记录, 不阻塞请求; 队列已满时丢弃并计数
+//@param: record system.SysOperationRecord
+
+func (w *OperationRecordWriter) Write(record system.SysOperationRecord) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.records == nil {
+		w.flush([]system.SysOperationRecord{record})
+		return
+	}
+	// 先计数, 避免后台写入后才计数出现负数
+	w.pending.Add(1)
+	select {
+	case w.records <- record:
+	default:
+		w.pending.Add(-1)
+		// 每丢弃 1000 条记一次日志, 避免积压时刷屏
+		if n := w.dropped.Add(1); n%1000 == 1 {
+			global.LOG.Warn("操作记录队列已满, 丢弃记录", zap.Int64("dropped", n))
+		}
+	}
+}
+
+//@function: Close
+//@description: 停止接收新记录并写入队列中剩余的记录, 之后提交的记录同步写入
+//@param: ctx context.Context 等待写入完成的超时
+//@return: error
+
+func (w *OperationRecordWriter) Close(ctx context.Context) error {
+	w.mu.Lock()
+	records, done := w.records, w.done
+	w.records = nil
+	w.mu.Unlock()
+	if records == nil {
+		return nil
+	}
+	close(records)
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+//@function: Stats
+//@description: 获取写入计数
+//@return: systemRes.SysOperationRecordStats
+
+func (w *OperationRecordWriter) Stats() systemRes.SysOperationRecordStats {
+	return systemRes.SysOperationRecordStats{
+		Queued:  int(w.pending.Load()),
+		Written: w.written.Load(),
+		Dropped: w.dropped.Load(),
+		Failed:  w.failed.Load(),
+	}
+}
+
+func (w *OperationRecordWriter) run(records <-chan system.SysOperationRecord, done chan<- struct{}, batchSize int, interval time.Duration) {
+	defer close(done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	batch := make([]system.SysOperationRecord, 0, batchSize)
+	for {
+		select {
+		case record, ok := <-records:
+			if !ok {
+				w.flushPending(batch)
+				return
+			}
+			batch = append(batch, record)
+			if len(batch) >= batchSize {
+				w.flushPending(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			w.flushPending(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+func (w *OperationRecordWriter) flushPending(batch []system.SysOperationRecord) {
+	w.flush(batch)
+	w.pending.Add(-int64(len(batch)))
+}
+
+func (w *OperationRecordWriter) flush(batch []system.SysOperationRecord) {
+	if len(batch) == 0 {
+		return
+	}
+	err := global.DB.Create(&batch).Error
+	if err == nil {
+		w.written.Add(int64(len(batch)))
+		return
+	}
+	if len(batch) > 1 {
+		// 一条异常数据会让整批失败, 改为逐条写入, 只丢弃写不进去的记录
+		global.LOG.Warn("批量写入操作记录失败, 改为逐条写入", zap.Int("count", len(batch)), zap.Error(err))
+		w.flushEach(batch)
+		return
+	}
+	w.failed.Add(1)
+	global.LOG.Error("写入操作记录失败, 丢弃记录", zap.Int("dropped", 1), zap.Error(err))
+}
+
+func (w *OperationRecordWriter) flushEach(batch []system.SysOperationRecord) {
+	var dropped int
+	var lastErr error
+	for i := range batch {
+		if err := global.DB.Create(&batch[i]).Error; err != nil {
+			dropped, lastErr = dropped+1, err
+			continue
+		}
+		w.written.Add(1)
+	}
+	if dropped > 0 {
+		w.failed.Add(int64(dropped))
+		global.LOG.Error("写入操作记录失败, 丢弃记录", zap.Int("dropped", dropped), zap.Int("count", len(batch)), zap.Error(lastErr))
+	}
+}
@@ -0,0 +1,83 @@
+package system
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"server/config"
+	global "server/model"
+	"server/model/system"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationRecordWriter(t *testing.T) {
+	setupTestDB(t, &system.SysOperationRecord{})
+	count := func() int64 {
+		var n int64
+		global.DB.Model(&system.SysOperationRecord{}).Count(&n)
+		return n
+	}
+	w := new(OperationRecordWriter)
+
+	// 未启动时同步写入
+	w.Write(system.SysOperationRecord{Path: "/sync"})
+	assert.EqualValues(t, 1, count())
+
+	// 满一批立即写入
+	w.Start(config.OperationRecord{BufferSize: 10, BatchSize: 3, FlushInterval: 3600000})
+	for i := 0; i < 4; i++ {
+		w.Write(system.SysOperationRecord{Path: "/batch", Latency: time.Second})
+	}
+	assert.Eventually(t, func() bool { return count() == 4 }, time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool { return w.Stats().Queued == 1 }, time.Second, 10*time.Millisecond)
+
+	// 关闭时写入剩余的记录, 之后恢复同步写入
+	require.NoError(t, w.Close(context.Background()))
+	assert.EqualValues(t, 5, count())
+	assert.Zero(t, w.Stats().Queued)
+	w.Write(system.SysOperationRecord{Path: "/sync"})
+	assert.EqualValues(t, 6, count())
+	assert.EqualValues(t, 6, w.Stats().Written)
+
+	var record system.SysOperationRecord
+	require.NoError(t, global.DB.Where("path = ?", "/batch").First(&record).Error)
+	assert.Equal(t, time.Second, record.Latency)
+
+	// 未满一批时按间隔写入
+	w.Start(config.OperationRecord{BatchSize: 100, FlushInterval: 10})
+	w.Write(system.SysOperationRecord{Path: "/interval"})
+	assert.Eventually(t, func() bool { return count() == 7 }, time.Second, 10*time.Millisecond)
+	require.NoError(t, w.Close(context.Background()))
+}
+
+func TestOperationRecordWriterDrop(t *testing.T) {
+	setupTestDB(t, &system.SysOperationRecord{})
+	// 不启动后台写入, 模拟数据库写入跟不上时队列被占满
+	w := &OperationRecordWriter{records: make(chan system.SysOperationRecord, 2)}
+	for i := 0; i < 5; i++ {
+		w.Write(system.SysOperationRecord{})
+	}
+	stats := w.Stats()
+	assert.Equal(t, 2, stats.Queued)
+	assert.EqualValues(t, 3, stats.Dropped)
+}
+
+func TestOperationRecordWriterBatchFailure(t *testing.T) {
+	setupTestDB(t, &system.SysOperationRecord{})
+	w := new(OperationRecordWriter)
+	w.Write(system.SysOperationRecord{Path: "/exist"})
+
+	// 主键冲突的记录让整批失败, 逐条写入其余的记录
+	batch := []system.SysOperationRecord{{Path: "/a"}, {Path: "/dup"}, {Path: "/b"}}
+	batch[1].ID = 1
+	w.flush(batch)
+	var paths []string
+	global.DB.Model(&system.SysOperationRecord{}).Order("id").Pluck("path", &paths)
+	assert.Equal(t, []string{"/exist", "/a", "/b"}, paths)
+	stats := w.Stats()
+	assert.EqualValues(t, 3, stats.Written)
+	assert.EqualValues(t, 1, stats.Failed)
+}
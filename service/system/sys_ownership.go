@@ -0,0 +1,106 @@
+package system
+
+import (
+	"errors"
+	"sync"
+
+	global "server/model"
+	"server/model/system"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// OwnershipOverrideMethod 越权权限在 casbin 中使用的 act, obj 为 OwnershipRule.OverridePath
+const OwnershipOverrideMethod = "OVERRIDE"
+
+var ErrNotOwner = errors.New("只能操作自己创建的记录")
+
+// OwnershipRule 路由声明的记录归属规则
+type OwnershipRule struct {
+	Model  schema.Tabler // 记录所属模型
+	Column string        // 记录创建人的用户id列
+}
+
+// OverridePath 越权权限对应的 casbin obj, 拥有该权限的角色可以操作他人的记录
+func (r OwnershipRule) OverridePath() string {
+	return "/ownership/" + r.Model.TableName()
+}
+
+var (
+	ownershipMu    sync.RWMutex
+	ownershipRules = map[string]OwnershipRule{}
+)
+
+//@function: Register
+//@description: 注册记录归属规则, 同一张表只保留一条
+//@param: model schema.Tabler, column string
+//@return: OwnershipRule
+
+func (o *OwnershipService) Register(model schema.Tabler, column string) OwnershipRule {
+	rule := OwnershipRule{Model: model, Column: column}
+	ownershipMu.Lock()
+	ownershipRules[model.TableName()] = rule
+	ownershipMu.Unlock()
+	return rule
+}
+
+//@function: CheckOwnership
+//@description: 校验记录是否全部属于当前用户, 不属于时检查角色是否拥有越权权限, override 表示使用了越权权限
+//@param: rule OwnershipRule, userId uint, authorityId string, ids []uint
+//@return: override bool, err error
+
+func (o *OwnershipService) CheckOwnership(rule OwnershipRule, userId uint, authorityId string, ids []uint) (override bool, err error) {
+	var rows []struct {
+		ID    uint
+		Owner uint
+	}
+	err = global.DB.Model(rule.Model).Select("id, "+rule.Column+" AS owner").Where("id IN ?", ids).Scan(&rows).Error
+	if err != nil {
+		return false, err
+	}
+	found := make(map[uint]bool, len(rows))
+	owned := true
+	for _, row := range rows {
+		found[row.ID] = true
+		owned = owned && row.Owner == userId
+	}
+	for _, id := range ids {
+		if !found[id] {
+			return false, gorm.ErrRecordNotFound
+		}
+	}
+	if owned {
+		return false, nil
+	}
+	ok, err := CasbinServiceApp.Casbin().Enforce(authorityId, rule.OverridePath(), OwnershipOverrideMethod)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, ErrNotOwner
+	}
+	return true, nil
+}
+
+//@function: EnsureOverrideApis
+//@description: 为已注册的规则补充越权权限的 api 记录, 便于在角色的 api 权限中分配
+//@return: error
+
+func (o *OwnershipService) EnsureOverrideApis() error {
+	ownershipMu.RLock()
+	defer ownershipMu.RUnlock()
+	for table, rule := range ownershipRules {
+		api := system.SysApi{
+			Path:        rule.OverridePath(),
+			Method:      OwnershipOverrideMethod,
+			ApiGroup:    "ownership",
+			Description: "操作他人的" + table + "记录",
+		}
+		err := global.DB.Where("path = ? AND method = ?", api.Path, api.Method).FirstOrCreate(&api).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
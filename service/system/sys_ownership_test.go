@@ -0,0 +1,49 @@
+package system
+
+import (
+	"testing"
+
+	global "server/model"
+	"server/model/system"
+	"server/model/system/request"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestCheckOwnership(t *testing.T) {
+	setupCasbinTest(t, false)
+	require.NoError(t, global.DB.AutoMigrate(&system.SysOperationRecord{}, &system.SysApi{}))
+	s := OwnershipServiceApp
+	rule := s.Register(system.SysOperationRecord{}, "user_id")
+	records := []system.SysOperationRecord{{UserID: 1}, {UserID: 1}, {UserID: 2}}
+	require.NoError(t, global.DB.Create(&records).Error)
+
+	override, err := s.CheckOwnership(rule, 1, "9528", []uint{records[0].ID, records[1].ID})
+	require.NoError(t, err)
+	assert.False(t, override)
+
+	_, err = s.CheckOwnership(rule, 1, "9528", []uint{records[0].ID, records[2].ID})
+	assert.ErrorIs(t, err, ErrNotOwner)
+
+	_, err = s.CheckOwnership(rule, 1, "9528", []uint{records[0].ID, 999})
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	// 已删除的记录视为不存在
+	require.NoError(t, global.DB.Delete(&records[1]).Error)
+	_, err = s.CheckOwnership(rule, 1, "9528", []uint{records[1].ID})
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	// 分配越权权限后可以操作他人的记录
+	require.NoError(t, CasbinServiceApp.UpdateCasbin("888", []request.CasbinInfo{{Path: rule.OverridePath(), Method: OwnershipOverrideMethod}}))
+	override, err = s.CheckOwnership(rule, 1, "888", []uint{records[2].ID})
+	require.NoError(t, err)
+	assert.True(t, override)
+
+	require.NoError(t, s.EnsureOverrideApis())
+	require.NoError(t, s.EnsureOverrideApis())
+	var count int64
+	global.DB.Model(&system.SysApi{}).Where("path = ? AND method = ?", rule.OverridePath(), OwnershipOverrideMethod).Count(&count)
+	assert.EqualValues(t, 1, count)
+}
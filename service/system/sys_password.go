@@ -0,0 +1,29 @@
+package system
+
+import (
+	global "server/model"
+	"server/utils"
+
+	"go.uber.org/zap"
+)
+
+//@function: VerifyAndUpgradePassword
+//@description: 校验密码, 通过后把旧版 sha512/明文或参数过期的哈希就地升级为当前算法; 升级失败只记日志, 不影响本次登录
+//@param: model any 用户表模型, id uint, hashed string 库中的密码, password string 用户输入
+//@return: bool
+
+func VerifyAndUpgradePassword(model any, id uint, hashed, password string) bool {
+	ok, needsRehash := utils.VerifyPassword(hashed, password)
+	if !ok || !needsRehash {
+		return ok
+	}
+	newHash, err := utils.HashPassword(password)
+	if err == nil {
+		// 带上旧哈希做条件, 避免覆盖并发修改的新密码; 不更新 updated_at
+		err = global.DB.Model(model).Where("id = ? AND password = ?", id, hashed).UpdateColumn("password", newHash).Error
+	}
+	if err != nil {
+		global.LOG.Warn("升级密码哈希失败", zap.Uint("id", id), zap.Error(err))
+	}
+	return true
+}
@@ -0,0 +1,178 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+	"time"
+
+	global "server/model"
+	"server/model/system"
+	emailService "server/plugin/email/service"
+	"server/utils"
+	"server/utils/crypt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPasswordResetLimited = errors.New("请求太过频繁, 请稍后再试")
+	ErrPasswordResetInvalid = errors.New("重置链接无效或已过期")
+)
+
+var passwordResetCounter = newAttemptCounter("password_reset:")
+
+// sendPasswordResetEmail 发送重置邮件, 测试中替换
+var sendPasswordResetEmail = func(to, subject, body string) error {
+	return emailService.ServiceGroupApp.SendEmail(to, subject, body)
+}
+
+//@function: RequestReset
+//@description: 找回密码, 按邮箱和 ip 限流; 邮箱未注册时同样返回成功, 邮件在后台发送避免通过耗时判断账号是否存在
+//@param: channel string 发起端 backend/frontend, email string, ip string
+//@return: error
+
+func (p *PasswordResetService) RequestReset(channel, email, ip string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	conf := global.CONFIG.PasswordReset
+	window := time.Duration(conf.LimitWindow) * time.Second
+	if window <= 0 {
+		window = time.Hour
+	}
+	if limited, err := p.limited("email:"+email, conf.EmailLimit, 3, window); err != nil || limited {
+		return errors.Join(ErrPasswordResetLimited, err)
+	}
+	if limited, err := p.limited("ip:"+ip, conf.IpLimit, 10, window); err != nil || limited {
+		return errors.Join(ErrPasswordResetLimited, err)
+	}
+
+	var user system.SysUser
+	err := global.DB.Where("email_bidx = ?", crypt.BlindIndex(email)).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	minutes := conf.TokenTime
+	if minutes <= 0 {
+		minutes = 30
+	}
+	token, expiresAt, err := p.issue(channel, user.ID, ip, time.Duration(minutes)*time.Minute)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := sendPasswordResetEmail(user.Email, "重置密码", p.emailBody(channel, token, expiresAt)); err != nil {
+			global.LOG.Error("发送重置密码邮件失败", zap.Uint("userId", user.ID), zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+//@function: ConfirmReset
+//@description: 使用重置令牌设置新密码, 令牌只能使用一次; 成功后作废该用户的全部刷新令牌和两步验证登录凭证
+//@param: token string, password string
+//@return: error
+
+func (p *PasswordResetService) ConfirmReset(token, password string) error {
+	hashed, err := utils.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	var record system.SysPasswordReset
+	err = global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("token_hash = ?", utils.Sha256V(token)).First(&record).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrPasswordResetInvalid
+			}
+			return err
+		}
+		if record.UsedAt != nil || time.Now().After(record.ExpiresAt) {
+			return ErrPasswordResetInvalid
+		}
+		result := tx.Model(&system.SysPasswordReset{}).Where("id = ? AND used_at IS NULL", record.ID).Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPasswordResetInvalid
+		}
+		return tx.Model(&system.SysUser{}).Where("id = ?", record.UserId).Update("password", hashed).Error
+	})
+	if err != nil {
+		return err
+	}
+	return p.revokeSessions(record.UserId)
+}
+
+// limited 计数并判断是否超过限制, limit 未配置时使用默认值
+func (p *PasswordResetService) limited(key string, limit, defaultLimit int, window time.Duration) (bool, error) {
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	n, err := passwordResetCounter.Incr(key, window)
+	if err != nil {
+		return false, err
+	}
+	return n > int64(limit), nil
+}
+
+// issue 作废用户之前未使用的令牌并签发新令牌
+func (p *PasswordResetService) issue(channel string, userId uint, ip string, ttl time.Duration) (token string, expiresAt time.Time, err error) {
+	if token, err = utils.RandomToken(32); err != nil {
+		return
+	}
+	record := system.SysPasswordReset{
+		TokenHash: utils.Sha256V(token),
+		UserId:    userId,
+		Channel:   channel,
+		RequestIp: ip,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	err = global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&system.SysPasswordReset{}).Where("user_id = ? AND used_at IS NULL", userId).Update("used_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Create(&record).Error
+	})
+	return token, record.ExpiresAt, err
+}
+
+func (p *PasswordResetService) emailBody(channel, token string, expiresAt time.Time) string {
+	link := global.CONFIG.PasswordReset.BackendURL
+	if channel == system.RefreshChannelFrontend {
+		link = global.CONFIG.PasswordReset.FrontendURL
+	}
+	link = html.EscapeString(strings.ReplaceAll(link, "{token}", url.QueryEscape(token)))
+	return fmt.Sprintf(`<p>您正在重置密码, 请在 %s 前点击下面的链接设置新密码:</p><p><a href="%s">%s</a></p><p>如果不是您本人操作, 请忽略这封邮件。</p>`,
+		expiresAt.Format("2006-01-02 15:04"), link, link)
+}
+
+// revokeSessions 密码重置后作废已有的登录
+func (p *PasswordResetService) revokeSessions(userId uint) error {
+	for _, channel := range []string{system.RefreshChannelBackend, system.RefreshChannelFrontend} {
+		if err := RefreshTokenServiceApp.RevokeUser(channel, userId); err != nil {
+			return err
+		}
+	}
+	if err := global.DB.Model(&system.SysTotpChallenge{}).Where("user_id = ? AND used_at IS NULL", userId).Update("used_at", time.Now()).Error; err != nil {
+		return err
+	}
+	// 多点登录拦截时 redis 中记录了当前有效的 token, 一并拉黑
+	if !global.CONFIG.System.UseMultipoint || global.REDIS == nil {
+		return nil
+	}
+	var user system.SysUser
+	if err := global.DB.Select("username").Where("id = ?", userId).First(&user).Error; err != nil {
+		return err
+	}
+	jwtStr, err := JwtServiceApp.GetRedisJWT(user.Username)
+	if err != nil || jwtStr == "" {
+		return nil
+	}
+	return JwtServiceApp.JsonInBlacklist(system.JwtBlacklist{Jwt: jwtStr})
+}
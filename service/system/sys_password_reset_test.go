@@ -0,0 +1,100 @@
+package system
+
+import (
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"server/config"
+	global "server/model"
+	"server/model/system"
+	"server/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sentEmail struct{ to, body string }
+
+func setupPasswordResetTest(t *testing.T) chan sentEmail {
+	setupTestDB(t, &system.SysUser{}, &system.SysPasswordReset{}, &system.SysRefreshToken{}, &system.SysSession{}, &system.SysTotpChallenge{})
+	global.CONFIG.PasswordReset = config.PasswordReset{BackendURL: "http://admin/reset?token={token}", EmailLimit: 2, IpLimit: 100}
+	global.CONFIG.Password = config.Password{Algorithm: utils.PasswordBcrypt, BcryptCost: 4}
+	sent := make(chan sentEmail, 10)
+	send := sendPasswordResetEmail
+	sendPasswordResetEmail = func(to, subject, body string) error {
+		sent <- sentEmail{to, body}
+		return nil
+	}
+	t.Cleanup(func() {
+		sendPasswordResetEmail = send
+		global.CONFIG.PasswordReset = config.PasswordReset{}
+		global.CONFIG.Password = config.Password{}
+	})
+	return sent
+}
+
+func resetTokenFrom(t *testing.T, body string) string {
+	m := regexp.MustCompile(`token=([^"&]+)`).FindStringSubmatch(body)
+	require.Len(t, m, 2)
+	token, err := url.QueryUnescape(m[1])
+	require.NoError(t, err)
+	return token
+}
+
+func TestPasswordReset(t *testing.T) {
+	sent := setupPasswordResetTest(t)
+	svc := &PasswordResetService{}
+	user := system.SysUser{Username: "reset", Email: "Reset@Example.com", Password: "old"}
+	require.NoError(t, global.DB.Create(&user).Error)
+	_, _, err := RefreshTokenServiceApp.Issue(system.RefreshChannelBackend, user.ID, "")
+	require.NoError(t, err)
+
+	require.NoError(t, svc.RequestReset(system.RefreshChannelBackend, " reset@example.com ", "1.1.1.1"))
+	var mail sentEmail
+	select {
+	case mail = <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("没有发送邮件")
+	}
+	assert.Equal(t, user.Email, mail.to)
+	token := resetTokenFrom(t, mail.body)
+
+	require.NoError(t, svc.ConfirmReset(token, "new-password"))
+	var stored system.SysUser
+	require.NoError(t, global.DB.First(&stored, user.ID).Error)
+	ok, _ := utils.VerifyPassword(stored.Password, "new-password")
+	assert.True(t, ok)
+
+	var active int64
+	require.NoError(t, global.DB.Model(&system.SysRefreshToken{}).Where("user_id = ? AND revoked_at IS NULL", user.ID).Count(&active).Error)
+	assert.Zero(t, active, "重置后作废已有的登录")
+
+	assert.ErrorIs(t, svc.ConfirmReset(token, "another"), ErrPasswordResetInvalid, "令牌只能使用一次")
+	assert.ErrorIs(t, svc.ConfirmReset("unknown", "another"), ErrPasswordResetInvalid)
+}
+
+func TestPasswordResetUnknownEmailAndLimit(t *testing.T) {
+	sent := setupPasswordResetTest(t)
+	svc := &PasswordResetService{}
+
+	// 未注册的邮箱同样返回成功, 但不发送邮件
+	require.NoError(t, svc.RequestReset(system.RefreshChannelBackend, "nobody@example.com", "2.2.2.2"))
+	require.NoError(t, svc.RequestReset(system.RefreshChannelBackend, "nobody@example.com", "2.2.2.2"))
+	assert.ErrorIs(t, svc.RequestReset(system.RefreshChannelBackend, "nobody@example.com", "2.2.2.2"), ErrPasswordResetLimited)
+	select {
+	case <-sent:
+		t.Fatal("未注册的邮箱不应发送邮件")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestPasswordResetExpired(t *testing.T) {
+	setupPasswordResetTest(t)
+	svc := &PasswordResetService{}
+	token, _, err := svc.issue(system.RefreshChannelBackend, 1, "", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, global.DB.Model(&system.SysPasswordReset{}).Where("user_id = ?", 1).Update("expires_at", time.Now().Add(-time.Minute)).Error)
+	assert.ErrorIs(t, svc.ConfirmReset(token, "new-password"), ErrPasswordResetInvalid)
+}
@@ -0,0 +1,42 @@
+package system
+
+import (
+	"testing"
+
+	"server/config"
+	global "server/model"
+	"server/model/system"
+	"server/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyAndUpgradePassword(t *testing.T) {
+	setupTestDB(t, &system.SysUser{})
+	global.CONFIG.Password = config.Password{Algorithm: utils.PasswordArgon2id, Argon2Memory: 1024, Argon2Time: 1, Argon2Threads: 1}
+	t.Cleanup(func() { global.CONFIG.Password = config.Password{} })
+
+	user := system.SysUser{Username: "legacy", Password: utils.Sha512V("123456")}
+	require.NoError(t, global.DB.Create(&user).Error)
+
+	assert.False(t, VerifyAndUpgradePassword(&system.SysUser{}, user.ID, user.Password, "654321"))
+	var stored system.SysUser
+	require.NoError(t, global.DB.First(&stored, user.ID).Error)
+	assert.Equal(t, user.Password, stored.Password, "密码错误时不升级")
+
+	assert.True(t, VerifyAndUpgradePassword(&system.SysUser{}, user.ID, user.Password, "123456"))
+	require.NoError(t, global.DB.First(&stored, user.ID).Error)
+	assert.True(t, utils.IsPasswordHash(stored.Password))
+
+	ok, rehash := utils.VerifyPassword(stored.Password, "123456")
+	assert.True(t, ok)
+	assert.False(t, rehash)
+
+	// 升级后用新哈希登录
+	logged, err := UserServiceApp.Login("legacy", "123456")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, logged.ID)
+	_, err = UserServiceApp.Login("legacy", "654321")
+	assert.Error(t, err)
+}
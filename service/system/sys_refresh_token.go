@@ -0,0 +1,178 @@
+package system
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	global "server/model"
+	"server/model/system"
+	systemReq "server/model/system/request"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrRefreshTokenInvalid = errors.New("刷新令牌无效或已过期")
+	ErrRefreshTokenReused  = errors.New("刷新令牌已被使用, 该登录的所有令牌已作废")
+)
+
+//@function: Issue
+//@description: 签发刷新令牌, familyId 为空时开启新的令牌族(一次新的登录)
+//@param: channel string, userId uint, familyId string
+//@return: token string, expiresAt time.Time, err error
+
+func (r *RefreshTokenService) Issue(channel string, userId uint, familyId string) (token string, expiresAt time.Time, err error) {
+	return r.issue(global.DB, channel, userId, familyId)
+}
+
+//@function: Rotate
+//@description: 使用刷新令牌换新, 旧令牌立即失效; 已失效的令牌再次出现时作废整个令牌族
+//@param: channel string, token string
+//@return: record *system.SysRefreshToken 旧令牌记录, newToken string, expiresAt time.Time, err error
+
+func (r *RefreshTokenService) Rotate(channel, token string) (record *system.SysRefreshToken, newToken string, expiresAt time.Time, err error) {
+	var reused bool
+	err = global.DB.Transaction(func(tx *gorm.DB) error {
+		var old system.SysRefreshToken
+		if err := tx.Where("token_hash = ? AND channel = ?", hashRefreshToken(token), channel).First(&old).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrRefreshTokenInvalid
+			}
+			return err
+		}
+		record = &old
+		if old.RevokedAt != nil || time.Now().After(old.ExpiresAt) {
+			return ErrRefreshTokenInvalid
+		}
+		now := time.Now()
+		// 条件更新保证并发请求中只有一个能完成轮换, 另一个按重复使用处理
+		result := tx.Model(&system.SysRefreshToken{}).Where("id = ? AND used_at IS NULL", old.ID).Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			reused = true
+			return ErrRefreshTokenReused
+		}
+		newToken, expiresAt, err = r.issue(tx, channel, old.UserId, old.FamilyId)
+		return err
+	})
+	if reused {
+		if revokeErr := r.revokeFamily(record.FamilyId); revokeErr != nil {
+			return record, "", expiresAt, revokeErr
+		}
+	}
+	return record, newToken, expiresAt, err
+}
+
+//@function: Revoke
+//@description: 退出登录, 作废刷新令牌所在的令牌族
+//@param: channel string, token string
+//@return: error
+
+func (r *RefreshTokenService) Revoke(channel, token string) error {
+	var record system.SysRefreshToken
+	err := global.DB.Where("token_hash = ? AND channel = ?", hashRefreshToken(token), channel).First(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return r.revokeFamily(record.FamilyId)
+}
+
+//@function: Logout
+//@description: 退出登录, 作废当前访问令牌 jti 对应的令牌族和会话, 以及请求中的刷新令牌; req.All 为 true 时作废该端全部登录
+//@param: channel string, userId uint, jti string, req systemReq.Logout
+//@return: error
+
+func (r *RefreshTokenService) Logout(channel string, userId uint, jti string, req systemReq.Logout) error {
+	if req.All {
+		return r.RevokeUser(channel, userId)
+	}
+	if req.RefreshToken != "" {
+		if err := r.Revoke(channel, req.RefreshToken); err != nil {
+			return err
+		}
+	}
+	return r.RevokeCurrent(channel, userId, jti)
+}
+
+//@function: RevokeCurrent
+//@description: 作废用户在某个端 jti 对应的令牌族和会话, 访问令牌的 jti 即刷新令牌族id
+//@param: channel string, userId uint, jti string
+//@return: error
+
+func (r *RefreshTokenService) RevokeCurrent(channel string, userId uint, jti string) error {
+	if jti == "" {
+		return nil
+	}
+	err := global.DB.Model(&system.SysRefreshToken{}).
+		Where("channel = ? AND user_id = ? AND family_id = ? AND revoked_at IS NULL", channel, userId, jti).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return err
+	}
+	return revokeSessions(global.DB.Where("channel = ? AND user_id = ? AND jti = ?", channel, userId, jti))
+}
+
+//@function: RevokeUser
+//@description: 作废用户在某个端的全部刷新令牌
+//@param: channel string, userId uint
+//@return: error
+
+func (r *RefreshTokenService) RevokeUser(channel string, userId uint) error {
+	return r.revokeUser(channel, userId, "")
+}
+
+// revokeUser 作废用户在某个端除 exceptFamily 以外的刷新令牌和会话
+func (r *RefreshTokenService) revokeUser(channel string, userId uint, exceptFamily string) error {
+	err := global.DB.Model(&system.SysRefreshToken{}).
+		Where("channel = ? AND user_id = ? AND family_id <> ? AND revoked_at IS NULL", channel, userId, exceptFamily).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return err
+	}
+	return revokeSessions(global.DB.Where("channel = ? AND user_id = ? AND jti <> ?", channel, userId, exceptFamily))
+}
+
+// revokeFamily 作废令牌族, 同时作废以它为 jti 的会话
+func (r *RefreshTokenService) revokeFamily(familyId string) error {
+	err := global.DB.Model(&system.SysRefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyId).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return err
+	}
+	return revokeSessions(global.DB.Where("jti = ?", familyId))
+}
+
+func (r *RefreshTokenService) issue(db *gorm.DB, channel string, userId uint, familyId string) (string, time.Time, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", time.Time{}, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	if familyId == "" {
+		familyId = uuid.NewString()
+	}
+	record := system.SysRefreshToken{
+		TokenHash: hashRefreshToken(token),
+		FamilyId:  familyId,
+		Channel:   channel,
+		UserId:    userId,
+		ExpiresAt: time.Now().Add(global.CONFIG.JWT.RefreshExpires()),
+	}
+	return token, record.ExpiresAt, db.Create(&record).Error
+}
+
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,101 @@
+package system
+
+import (
+	"testing"
+	"time"
+
+	"server/model/system"
+	systemReq "server/model/system/request"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshTokenRotate(t *testing.T) {
+	setupTestDB(t, &system.SysRefreshToken{}, &system.SysSession{})
+	svc := &RefreshTokenService{}
+
+	first, _, err := svc.Issue(system.RefreshChannelBackend, 1, "")
+	require.NoError(t, err)
+
+	t.Run("轮换签发新令牌", func(t *testing.T) {
+		record, second, _, err := svc.Rotate(system.RefreshChannelBackend, first)
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), record.UserId)
+		assert.NotEqual(t, first, second)
+
+		t.Run("其他端不能使用", func(t *testing.T) {
+			_, _, _, err := svc.Rotate(system.RefreshChannelMobile, second)
+			assert.ErrorIs(t, err, ErrRefreshTokenInvalid)
+		})
+
+		t.Run("重复使用作废整个令牌族", func(t *testing.T) {
+			_, _, _, err := svc.Rotate(system.RefreshChannelBackend, first)
+			assert.ErrorIs(t, err, ErrRefreshTokenReused)
+			_, _, _, err = svc.Rotate(system.RefreshChannelBackend, second)
+			assert.ErrorIs(t, err, ErrRefreshTokenInvalid)
+		})
+	})
+
+	t.Run("退出登录后失效", func(t *testing.T) {
+		token, _, err := svc.Issue(system.RefreshChannelBackend, 2, "")
+		require.NoError(t, err)
+		require.NoError(t, svc.Revoke(system.RefreshChannelBackend, token))
+		_, _, _, err = svc.Rotate(system.RefreshChannelBackend, token)
+		assert.ErrorIs(t, err, ErrRefreshTokenInvalid)
+	})
+
+	t.Run("作废用户全部令牌", func(t *testing.T) {
+		token, _, err := svc.Issue(system.RefreshChannelFrontend, 3, "")
+		require.NoError(t, err)
+		require.NoError(t, svc.RevokeUser(system.RefreshChannelFrontend, 3))
+		_, _, _, err = svc.Rotate(system.RefreshChannelFrontend, token)
+		assert.ErrorIs(t, err, ErrRefreshTokenInvalid)
+	})
+
+	t.Run("未知令牌", func(t *testing.T) {
+		_, _, _, err := svc.Rotate(system.RefreshChannelBackend, "unknown")
+		assert.ErrorIs(t, err, ErrRefreshTokenInvalid)
+		assert.NoError(t, svc.Revoke(system.RefreshChannelBackend, "unknown"))
+	})
+}
+
+func TestRefreshTokenLogout(t *testing.T) {
+	setupTestDB(t, &system.SysRefreshToken{}, &system.SysSession{})
+	svc := &RefreshTokenService{}
+	login := func(familyId string) string {
+		token, _, err := svc.Issue(system.RefreshChannelBackend, 1, familyId)
+		require.NoError(t, err)
+		require.NoError(t, SessionServiceApp.Record(system.RefreshChannelBackend, 1, familyId, SessionClient{}, time.Now().Add(time.Hour)))
+		return token
+	}
+	active := func(token string) bool {
+		_, _, _, err := svc.Rotate(system.RefreshChannelBackend, token)
+		return err == nil
+	}
+
+	t.Run("未传刷新令牌只退出当前登录", func(t *testing.T) {
+		current, other := login("current"), login("other")
+		require.NoError(t, svc.Logout(system.RefreshChannelBackend, 1, "current", systemReq.Logout{}))
+		assert.False(t, active(current))
+		assert.True(t, active(other))
+
+		sessions, err := SessionServiceApp.GetSessions(1, []string{system.RefreshChannelBackend}, "")
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		assert.Equal(t, "other", sessions[0].Jti)
+	})
+
+	t.Run("jti 属于其他用户时不作废", func(t *testing.T) {
+		token := login("someone")
+		require.NoError(t, svc.Logout(system.RefreshChannelBackend, 2, "someone", systemReq.Logout{}))
+		assert.True(t, active(token))
+	})
+
+	t.Run("all 退出全部设备", func(t *testing.T) {
+		first, second := login("device-a"), login("device-b")
+		require.NoError(t, svc.Logout(system.RefreshChannelBackend, 1, "device-a", systemReq.Logout{All: true}))
+		assert.False(t, active(first))
+		assert.False(t, active(second))
+	})
+}
@@ -0,0 +1,140 @@
+package system
+
+import (
+	"time"
+
+	global "server/model"
+	"server/model/system"
+
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SessionClient 发起登录的客户端信息
+type SessionClient struct {
+	Ip        string
+	UserAgent string
+}
+
+// 最后活动时间的更新间隔, 避免每个请求都写库
+const sessionTouchInterval = time.Minute
+
+var sessionTouched = local_cache.NewCache(local_cache.SetDefaultExpire(sessionTouchInterval), local_cache.SetInternal(10*sessionTouchInterval))
+
+//@function: Record
+//@description: 登录或刷新令牌后记录会话, jti 为刷新令牌族id
+//@param: channel string, userId uint, jti string, client SessionClient, expiresAt time.Time
+//@return: error
+
+func (s *SessionService) Record(channel string, userId uint, jti string, client SessionClient, expiresAt time.Time) error {
+	now := time.Now()
+	if len(client.UserAgent) > 512 {
+		client.UserAgent = client.UserAgent[:512]
+	}
+	session := system.SysSession{
+		Jti:          jti,
+		Channel:      channel,
+		UserId:       userId,
+		Ip:           client.Ip,
+		UserAgent:    client.UserAgent,
+		LastActiveAt: now,
+		ExpiresAt:    expiresAt,
+	}
+	return global.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "jti"}},
+		DoUpdates: clause.AssignmentColumns([]string{"ip", "user_agent", "last_active_at", "expires_at", "updated_at"}),
+	}).Create(&session).Error
+}
+
+//@function: Touch
+//@description: 记录会话的最后活动时间和 ip, 同一会话每分钟最多写一次库
+//@param: jti string, ip string
+
+func (s *SessionService) Touch(jti, ip string) {
+	if jti == "" || sessionTouched.Add(jti, struct{}{}, sessionTouchInterval) != nil {
+		return
+	}
+	err := global.DB.Model(&system.SysSession{}).Where("jti = ? AND revoked_at IS NULL", jti).
+		Updates(map[string]any{"last_active_at": time.Now(), "ip": ip}).Error
+	if err != nil {
+		global.LOG.Warn("更新会话活动时间失败", zap.Error(err))
+	}
+}
+
+//@function: GetSessions
+//@description: 用户在指定端未过期且未作废的会话, currentJti 对应的会话标记为当前会话
+//@param: userId uint, channels []string, currentJti string
+//@return: list []system.SysSession, err error
+
+func (s *SessionService) GetSessions(userId uint, channels []string, currentJti string) (list []system.SysSession, err error) {
+	err = global.DB.Where("user_id = ? AND channel IN ? AND revoked_at IS NULL AND expires_at > ?", userId, channels, time.Now()).
+		Order("last_active_at desc").Find(&list).Error
+	for i := range list {
+		list[i].Current = currentJti != "" && list[i].Jti == currentJti
+	}
+	return list, err
+}
+
+//@function: Revoke
+//@description: 作废用户自己的一个会话
+//@param: userId uint, channels []string, id uint
+//@return: error
+
+func (s *SessionService) Revoke(userId uint, channels []string, id uint) error {
+	var session system.SysSession
+	err := global.DB.Where("id = ? AND user_id = ? AND channel IN ? AND revoked_at IS NULL", id, userId, channels).First(&session).Error
+	if err != nil {
+		return err
+	}
+	return RefreshTokenServiceApp.revokeFamily(session.Jti)
+}
+
+//@function: RevokeAll
+//@description: 作废用户在指定端的全部会话, exceptJti 不为空时保留该会话(用于"退出其他设备")
+//@param: userId uint, channels []string, exceptJti string
+//@return: error
+
+func (s *SessionService) RevokeAll(userId uint, channels []string, exceptJti string) error {
+	for _, channel := range channels {
+		if err := RefreshTokenServiceApp.revokeUser(channel, userId, exceptJti); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//@function: RevokeJti
+//@description: 退出登录时作废当前会话
+//@param: jti string
+//@return: error
+
+func (s *SessionService) RevokeJti(jti string) error {
+	if jti == "" {
+		return nil
+	}
+	return RefreshTokenServiceApp.revokeFamily(jti)
+}
+
+// revokeSessions 作废会话并把 jti 加入黑名单, 刷新令牌族作废时调用
+func revokeSessions(db *gorm.DB) error {
+	var list []system.SysSession
+	if err := db.Where("revoked_at IS NULL").Find(&list).Error; err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return nil
+	}
+	ids := make([]uint, len(list))
+	for i, session := range list {
+		ids[i] = session.ID
+	}
+	if err := global.DB.Model(&system.SysSession{}).Where("id IN ?", ids).Update("revoked_at", time.Now()).Error; err != nil {
+		return err
+	}
+	for _, session := range list {
+		JwtServiceApp.JtiInBlacklist(session.Jti, session.ExpiresAt)
+	}
+	return nil
+}
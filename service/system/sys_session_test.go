@@ -0,0 +1,115 @@
+package system
+
+import (
+	"testing"
+	"time"
+
+	global "server/model"
+	"server/model/system"
+
+	"github.com/google/uuid"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sessionLogin 模拟一次登录, 返回会话的 jti 和刷新令牌
+func sessionLogin(t *testing.T, channel string, userId uint, ip string) (string, string) {
+	jti := uuid.NewString()
+	token, expiresAt, err := RefreshTokenServiceApp.Issue(channel, userId, jti)
+	require.NoError(t, err)
+	require.NoError(t, SessionServiceApp.Record(channel, userId, jti, SessionClient{Ip: ip, UserAgent: "test"}, expiresAt))
+	return jti, token
+}
+
+func TestSessionRecordAndList(t *testing.T) {
+	setupTestDB(t, &system.SysRefreshToken{}, &system.SysSession{})
+	s := SessionServiceApp
+	pc, refresh := sessionLogin(t, system.RefreshChannelBackend, 1, "1.1.1.1")
+	phone, _ := sessionLogin(t, system.RefreshChannelFrontend, 1, "2.2.2.2")
+	sessionLogin(t, system.RefreshChannelMobile, 1, "3.3.3.3")
+	sessionLogin(t, system.RefreshChannelBackend, 2, "4.4.4.4")
+
+	list, err := s.GetSessions(1, []string{system.RefreshChannelBackend, system.RefreshChannelFrontend}, phone)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	for _, session := range list {
+		assert.Equal(t, session.Jti == phone, session.Current)
+	}
+
+	// 刷新后沿用同一个会话
+	record, _, expiresAt, err := RefreshTokenServiceApp.Rotate(system.RefreshChannelBackend, refresh)
+	require.NoError(t, err)
+	require.Equal(t, pc, record.FamilyId)
+	require.NoError(t, s.Record(system.RefreshChannelBackend, 1, record.FamilyId, SessionClient{Ip: "5.5.5.5"}, expiresAt))
+	var count int64
+	global.DB.Model(&system.SysSession{}).Where("user_id = ?", 1).Count(&count)
+	assert.EqualValues(t, 3, count)
+	var session system.SysSession
+	require.NoError(t, global.DB.Where("jti = ?", pc).First(&session).Error)
+	assert.Equal(t, "5.5.5.5", session.Ip)
+}
+
+func TestSessionRevoke(t *testing.T) {
+	setupTestDB(t, &system.SysRefreshToken{}, &system.SysSession{})
+	s := SessionServiceApp
+	channels := []string{system.RefreshChannelBackend, system.RefreshChannelFrontend}
+	pc, refresh := sessionLogin(t, system.RefreshChannelBackend, 1, "1.1.1.1")
+	phone, _ := sessionLogin(t, system.RefreshChannelFrontend, 1, "2.2.2.2")
+	other, _ := sessionLogin(t, system.RefreshChannelBackend, 2, "3.3.3.3")
+
+	list, err := s.GetSessions(1, channels, "")
+	require.NoError(t, err)
+	var pcId uint
+	for _, session := range list {
+		if session.Jti == pc {
+			pcId = session.ID
+		}
+	}
+	// 不能作废别人的会话
+	assert.Error(t, s.Revoke(2, channels, pcId))
+	require.NoError(t, s.Revoke(1, channels, pcId))
+	assert.True(t, JwtServiceApp.IsJtiBlacklist(pc))
+	assert.False(t, JwtServiceApp.IsJtiBlacklist(phone))
+	_, _, _, err = RefreshTokenServiceApp.Rotate(system.RefreshChannelBackend, refresh)
+	assert.ErrorIs(t, err, ErrRefreshTokenInvalid)
+
+	// 退出其他设备时保留当前会话
+	pc2, _ := sessionLogin(t, system.RefreshChannelBackend, 1, "1.1.1.1")
+	require.NoError(t, s.RevokeAll(1, channels, pc2))
+	assert.True(t, JwtServiceApp.IsJtiBlacklist(phone))
+	assert.False(t, JwtServiceApp.IsJtiBlacklist(pc2))
+	list, err = s.GetSessions(1, channels, "")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, pc2, list[0].Jti)
+
+	// 管理员强制下线
+	require.NoError(t, s.RevokeAll(1, channels, ""))
+	assert.True(t, JwtServiceApp.IsJtiBlacklist(pc2))
+	assert.False(t, JwtServiceApp.IsJtiBlacklist(other))
+}
+
+func TestSessionRevokedWithRefreshToken(t *testing.T) {
+	setupTestDB(t, &system.SysRefreshToken{}, &system.SysSession{}, &system.JwtBlacklist{})
+	jti, refresh := sessionLogin(t, system.RefreshChannelMobile, 7, "1.1.1.1")
+	_, _, _, err := RefreshTokenServiceApp.Rotate(system.RefreshChannelMobile, refresh)
+	require.NoError(t, err)
+	assert.False(t, JwtServiceApp.IsJtiBlacklist(jti))
+
+	// 刷新令牌被重复使用时作废整个会话
+	_, _, _, err = RefreshTokenServiceApp.Rotate(system.RefreshChannelMobile, refresh)
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+	assert.True(t, JwtServiceApp.IsJtiBlacklist(jti))
+
+	// 重启后从数据库恢复黑名单
+	global.BlackCache = local_cache.NewCache()
+	assert.False(t, JwtServiceApp.IsJtiBlacklist(jti))
+	LoadAll()
+	assert.True(t, JwtServiceApp.IsJtiBlacklist(jti))
+
+	var session system.SysSession
+	require.NoError(t, global.DB.Where("jti = ?", jti).First(&session).Error)
+	require.NotNil(t, session.RevokedAt)
+	assert.True(t, session.ExpiresAt.After(time.Now()))
+}
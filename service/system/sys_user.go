@@ -23,8 +23,10 @@ func (userService *UserService) Register(u *system.SysUser) (userInter *system.S
 	if !errors.Is(global.DB.Where("username = ?", u.Username).First(&user).Error, gorm.ErrRecordNotFound) { // 判断用户名是否注册
 		return userInter, errors.New("用户名已注册")
 	}
-	// 否则 附加uuid 密码sha512简单加密 注册
-	u.Password = utils.Sha512V(u.Password)
+	// 否则 附加uuid 密码加盐哈希 注册
+	if u.Password, err = utils.HashPassword(u.Password); err != nil {
+		return u, err
+	}
 	u.UUID = uuid.New()
 	// u.ExpirationDate = time.Now().Format("2006-01-02 15:04:05")
 	err = global.DB.Create(&u).Error
@@ -44,7 +46,10 @@ func (userService *UserService) Login(username, password string) (*system.SysUse
 
 	var user system.SysUser
 	// u.Password = utils.MD5V([]byte(u.Password))
-	err := global.DB.Where("username = ? AND password = ?", username, utils.Sha512V(password)).Preload("Authorities").Preload("Authority").First(&user).Error
+	err := global.DB.Where("username = ?", username).Preload("Authorities").Preload("Authority").First(&user).Error
+	if err == nil && !VerifyAndUpgradePassword(&system.SysUser{}, user.ID, user.Password, password) {
+		err = gorm.ErrRecordNotFound
+	}
 	if err == nil {
 		var am system.SysMenu
 		ferr := global.DB.First(&am, "name = ? AND authority_id = ?", user.Authority.DefaultRouter, user.AuthorityId).Error
@@ -84,7 +89,10 @@ func (userService *UserService) LoginToken(u *system.SysUser) (userInter *system
 	var user system.SysUser
 	// log.Println("user: ", u.Username, u.Password)
 	// u.Password = utils.MD5V([]byte(u.Password))
-	err = global.DB.Where("username = ? AND password = ?", u.Username, utils.Sha512V(u.Password)).Preload("Authorities").Preload("Authority").First(&user).Error
+	err = global.DB.Where("username = ?", u.Username).Preload("Authorities").Preload("Authority").First(&user).Error
+	if err == nil && !VerifyAndUpgradePassword(&system.SysUser{}, user.ID, user.Password, u.Password) {
+		err = gorm.ErrRecordNotFound
+	}
 	if err == nil {
 		var am system.SysMenu
 		ferry := global.DB.First(&am, "name = ? AND authority_id = ?", user.Authority.DefaultRouter, user.AuthorityId).Error
@@ -103,8 +111,17 @@ func (userService *UserService) LoginToken(u *system.SysUser) (userInter *system
 
 func (userService *UserService) ChangePassword(u *system.SysUser, newPassword string) (userInter *system.SysUser, err error) {
 	var user system.SysUser
-	// u.Password = utils.MD5V([]byte(u.Password))
-	err = global.DB.Where("username = ? AND password = ?", u.Username, u.Password).First(&user).Update("password", newPassword).Error
+	if err = global.DB.Where("username = ?", u.Username).First(&user).Error; err != nil {
+		return u, err
+	}
+	if ok, _ := utils.VerifyPassword(user.Password, u.Password); !ok {
+		return u, gorm.ErrRecordNotFound
+	}
+	hashed, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return u, err
+	}
+	err = global.DB.Model(&user).Update("password", hashed).Error
 	return u, err
 }
 
@@ -256,7 +273,11 @@ func (userService *UserService) FindUserByUuid(uuid string) (user *system.SysUse
 //@return: err error
 
 func (userService *UserService) ResetPassword(ID uint) (err error) {
-	err = global.DB.Model(&system.SysUser{}).Where("id = ?", ID).Update("password", utils.Sha512V("123456")).Error
+	hashed, err := utils.HashPassword("123456")
+	if err != nil {
+		return err
+	}
+	err = global.DB.Model(&system.SysUser{}).Where("id = ?", ID).Update("password", hashed).Error
 	return err
 }
 
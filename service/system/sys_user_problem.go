@@ -1,11 +1,17 @@
 package system
 
 import (
+	"crypto/subtle"
+	"errors"
+	"strconv"
+	"strings"
+
 	global "server/model"
 	"server/model/system"
 	"server/model/system/response"
-	"strconv"
-	"strings"
+	"server/utils"
+
+	"go.uber.org/zap"
 )
 
 func (*Problem) GetUserProblemList(info *system.SysUserProblem) (list any, err error) {
@@ -30,8 +36,12 @@ func (*Problem) SetUserProblemSetting(problem []system.SysUserProblem) (string,
 	var messageString strings.Builder
 	for index, item := range problem {
 		if item.ID == 0 {
+			var err error
+			if item.Answer, err = hashAnswer(item.Answer); err != nil {
+				return "", err
+			}
 			db := global.DB.Model(&system.SysUserProblem{})
-			err := db.Create(&item).Error
+			err = db.Create(&item).Error
 			if err != nil {
 				return "", err
 			}
@@ -43,10 +53,15 @@ func (*Problem) SetUserProblemSetting(problem []system.SysUserProblem) (string,
 			if err != nil {
 				return "", err
 			}
-			dataProblemFirst.Answer = item.Answer
+			// 答案为空时保留原答案
+			if normalizeAnswer(item.Answer) != "" {
+				if dataProblemFirst.Answer, err = hashAnswer(item.Answer); err != nil {
+					return "", err
+				}
+			}
 			dataProblemFirst.Problem = item.Problem
 			dataProblemFirst.SysUserId = item.SysUserId
-			err = db.Save(&dataProblemFirst).Error
+			err = global.DB.Save(&dataProblemFirst).Error
 			if err != nil {
 				return "", err
 			}
@@ -70,13 +85,59 @@ func (*Problem) HasSetting(uid int) (bool, error) {
 	return total != 0, nil
 }
 
-func (*Problem) VerifyAnswer(info *system.SysUserProblem) (bool, error) {
+// VerifyAnswer 校验调用者自己的单个问题答案, 答错计入失败次数, 锁定期间直接拒绝;
+// 答对不清除失败次数, 只有完整的找回流程通过后才清除
+func (p *Problem) VerifyAnswer(userId uint, info *system.SysUserProblem) (bool, error) {
 	db := global.DB.Model(&system.SysUserProblem{})
 	var findProblem system.SysUserProblem
-	err := db.Where("id = ?", info.ID).First(&findProblem).Error
+	err := db.Where("id = ? AND sys_user_id = ?", info.ID, userId).First(&findProblem).Error
 	if err != nil {
 		return false, err
 	}
-	isAnswer := findProblem.Answer == info.Answer
-	return isAnswer, nil
+	if locked, err := p.recoveryLocked(userId); err != nil || locked {
+		return false, errors.Join(ErrRecoveryLocked, err)
+	}
+	if !checkAnswer(&findProblem, info.Answer) {
+		return false, p.recoveryFailed(userId)
+	}
+	return true, nil
+}
+
+// normalizeAnswer 去掉首尾空白、合并中间空白并转小写, 避免大小写和空格导致答错
+func normalizeAnswer(answer string) string {
+	return strings.ToLower(strings.Join(strings.Fields(answer), " "))
+}
+
+func hashAnswer(answer string) (string, error) {
+	return utils.HashPassword(normalizeAnswer(answer))
+}
+
+// checkAnswer 校验答案, 历史明文答案校验通过后就地改为哈希
+func checkAnswer(problem *system.SysUserProblem, answer string) bool {
+	answer = normalizeAnswer(answer)
+	if answer == "" {
+		return false
+	}
+	if utils.IsPasswordHash(problem.Answer) {
+		ok, needsRehash := utils.VerifyPassword(problem.Answer, answer)
+		if ok && needsRehash {
+			upgradeAnswer(problem, answer)
+		}
+		return ok
+	}
+	if subtle.ConstantTimeCompare([]byte(normalizeAnswer(problem.Answer)), []byte(answer)) != 1 {
+		return false
+	}
+	upgradeAnswer(problem, answer)
+	return true
+}
+
+func upgradeAnswer(problem *system.SysUserProblem, answer string) {
+	hashed, err := utils.HashPassword(answer)
+	if err == nil {
+		err = global.DB.Model(&system.SysUserProblem{}).Where("id = ? AND answer = ?", problem.ID, problem.Answer).UpdateColumn("answer", hashed).Error
+	}
+	if err != nil {
+		global.LOG.Warn("升级安全问题答案哈希失败", zap.Uint("id", problem.ID), zap.Error(err))
+	}
 }
@@ -0,0 +1,202 @@
+package system
+
+import (
+	"errors"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+
+	global "server/model"
+	"server/model/system"
+	systemReq "server/model/system/request"
+	systemRes "server/model/system/response"
+	"server/utils"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrRecoveryLocked           = errors.New("答错次数过多, 请稍后再试")
+	ErrRecoveryUnavailable      = errors.New("该账号无法通过安全问题找回")
+	ErrRecoveryChallengeInvalid = errors.New("问题已失效, 请重新获取")
+	ErrRecoveryAnswerWrong      = errors.New("答案错误")
+)
+
+var (
+	// recoveryCounter 按用户统计答错次数
+	recoveryCounter = newAttemptCounter("recovery:")
+	// recoveryIpCounter 按 ip 统计发起找回的次数
+	recoveryIpCounter = newAttemptCounter("recovery_ip:")
+)
+
+//@function: RecoveryQuestions
+//@description: 安全问题找回第一步, 随机抽取用户设置的部分问题; 用户不存在和未设置问题返回同样的错误
+//@param: username string, ip string
+//@return: res systemRes.RecoveryQuestionsResponse, err error
+
+func (p *Problem) RecoveryQuestions(username, ip string) (res systemRes.RecoveryQuestionsResponse, err error) {
+	conf := global.CONFIG.SecurityQuestion
+	limit := conf.IpLimit
+	if limit <= 0 {
+		limit = 20
+	}
+	n, err := recoveryIpCounter.Incr(ip, time.Hour)
+	if err != nil || n > int64(limit) {
+		return res, errors.Join(ErrPasswordResetLimited, err)
+	}
+
+	var user system.SysUser
+	if err = global.DB.Select("id").Where("username = ?", username).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return res, ErrRecoveryUnavailable
+		}
+		return res, err
+	}
+	if locked, err := p.recoveryLocked(user.ID); err != nil || locked {
+		return res, errors.Join(ErrRecoveryLocked, err)
+	}
+	var problems []system.SysUserProblem
+	if err = global.DB.Where("sys_user_id = ?", user.ID).Find(&problems).Error; err != nil {
+		return res, err
+	}
+	if len(problems) == 0 {
+		return res, ErrRecoveryUnavailable
+	}
+
+	ask := conf.Ask
+	if ask <= 0 {
+		ask = 2
+	}
+	rand.Shuffle(len(problems), func(i, j int) { problems[i], problems[j] = problems[j], problems[i] })
+	problems = problems[:min(ask, len(problems))]
+	ids := make([]string, 0, len(problems))
+	for _, item := range problems {
+		ids = append(ids, strconv.Itoa(int(item.ID)))
+		res.Questions = append(res.Questions, systemRes.RecoveryQuestion{ID: item.ID, Problem: item.Problem})
+	}
+
+	token, err := utils.RandomToken(32)
+	if err != nil {
+		return res, err
+	}
+	seconds := conf.ChallengeTime
+	if seconds <= 0 {
+		seconds = 300
+	}
+	challenge := system.SysRecoveryChallenge{
+		TokenHash:  utils.Sha256V(token),
+		UserId:     user.ID,
+		ProblemIds: strings.Join(ids, ","),
+		ExpiresAt:  time.Now().Add(time.Duration(seconds) * time.Second),
+	}
+	if err = global.DB.Create(&challenge).Error; err != nil {
+		return res, err
+	}
+	res.Challenge = token
+	res.ExpiresAt = challenge.ExpiresAt.Unix()
+	return res, nil
+}
+
+//@function: VerifyRecovery
+//@description: 安全问题找回第二步, 每次抽取的问题只能作答一次, 全部答对后签发只能使用一次的重置令牌
+//@param: req systemReq.RecoveryAnswers, ip string
+//@return: res systemRes.RecoveryTokenResponse, err error
+
+func (p *Problem) VerifyRecovery(req systemReq.RecoveryAnswers, ip string) (res systemRes.RecoveryTokenResponse, err error) {
+	var challenge system.SysRecoveryChallenge
+	err = global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("token_hash = ?", utils.Sha256V(req.Challenge)).First(&challenge).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrRecoveryChallengeInvalid
+			}
+			return err
+		}
+		if challenge.UsedAt != nil || time.Now().After(challenge.ExpiresAt) {
+			return ErrRecoveryChallengeInvalid
+		}
+		result := tx.Model(&system.SysRecoveryChallenge{}).Where("id = ? AND used_at IS NULL", challenge.ID).Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRecoveryChallengeInvalid
+		}
+		return nil
+	})
+	if err != nil {
+		return res, err
+	}
+	if locked, err := p.recoveryLocked(challenge.UserId); err != nil || locked {
+		return res, errors.Join(ErrRecoveryLocked, err)
+	}
+
+	answers := make(map[uint]string, len(req.Answers))
+	for _, item := range req.Answers {
+		answers[item.ID] = item.Answer
+	}
+	var problems []system.SysUserProblem
+	ids := strings.Split(challenge.ProblemIds, ",")
+	if err = global.DB.Where("sys_user_id = ? AND id IN ?", challenge.UserId, ids).Find(&problems).Error; err != nil {
+		return res, err
+	}
+	// 问题在作答期间被删除也按答错处理
+	passed := len(problems) == len(ids)
+	for i := range problems {
+		// 每个问题都参与校验, 不提前返回
+		if !checkAnswer(&problems[i], answers[problems[i].ID]) {
+			passed = false
+		}
+	}
+	if !passed {
+		if err = p.recoveryFailed(challenge.UserId); err != nil {
+			return res, err
+		}
+		return res, ErrRecoveryAnswerWrong
+	}
+	if err = recoveryCounter.Reset(strconv.Itoa(int(challenge.UserId))); err != nil {
+		return res, err
+	}
+
+	seconds := global.CONFIG.SecurityQuestion.TokenTime
+	if seconds <= 0 {
+		seconds = 600
+	}
+	token, expiresAt, err := PasswordResetServiceApp.issue(system.PasswordResetChannelQuestion, challenge.UserId, ip, time.Duration(seconds)*time.Second)
+	if err != nil {
+		return res, err
+	}
+	return systemRes.RecoveryTokenResponse{Token: token, ExpiresAt: expiresAt.Unix()}, nil
+}
+
+// recoveryLocked 答错次数达到上限后在统计窗口内锁定
+func (p *Problem) recoveryLocked(userId uint) (bool, error) {
+	n, err := recoveryCounter.Count(strconv.Itoa(int(userId)))
+	if err != nil {
+		return false, err
+	}
+	return n >= int64(p.maxFailures()), nil
+}
+
+// recoveryFailed 记录一次答错, 达到上限时返回锁定错误
+func (p *Problem) recoveryFailed(userId uint) error {
+	lockTime := global.CONFIG.SecurityQuestion.LockTime
+	if lockTime <= 0 {
+		lockTime = 1800
+	}
+	n, err := recoveryCounter.Incr(strconv.Itoa(int(userId)), time.Duration(lockTime)*time.Second)
+	if err != nil {
+		return err
+	}
+	if n >= int64(p.maxFailures()) {
+		return ErrRecoveryLocked
+	}
+	return nil
+}
+
+func (p *Problem) maxFailures() int {
+	if n := global.CONFIG.SecurityQuestion.MaxFailures; n > 0 {
+		return n
+	}
+	return 5
+}
@@ -0,0 +1,144 @@
+package system
+
+import (
+	"strconv"
+	"testing"
+
+	"server/config"
+	global "server/model"
+	"server/model/system"
+	systemReq "server/model/system/request"
+	"server/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupRecoveryTest(t *testing.T) (*Problem, system.SysUser) {
+	setupTestDB(t, &system.SysUser{}, &system.SysUserProblem{}, &system.SysRecoveryChallenge{},
+		&system.SysPasswordReset{}, &system.SysRefreshToken{}, &system.SysSession{}, &system.SysTotpChallenge{})
+	global.CONFIG.SecurityQuestion = config.SecurityQuestion{Ask: 2, MaxFailures: 2}
+	global.CONFIG.Password = config.Password{Algorithm: utils.PasswordBcrypt, BcryptCost: 4}
+	recoveryCounter, recoveryIpCounter = newAttemptCounter("recovery:"), newAttemptCounter("recovery_ip:")
+	t.Cleanup(func() {
+		global.CONFIG.SecurityQuestion = config.SecurityQuestion{}
+		global.CONFIG.Password = config.Password{}
+	})
+	user := system.SysUser{Username: "recover", Password: "old"}
+	require.NoError(t, global.DB.Create(&user).Error)
+	p := &Problem{}
+	_, err := p.SetUserProblemSetting([]system.SysUserProblem{
+		{SysUserId: int(user.ID), Problem: "q1", Answer: " Blue  Sky "},
+		{SysUserId: int(user.ID), Problem: "q2", Answer: "cat"},
+		{SysUserId: int(user.ID), Problem: "q3", Answer: "Paris"},
+	})
+	require.NoError(t, err)
+	return p, user
+}
+
+var recoveryAnswers = map[string]string{"q1": "blue sky", "q2": "CAT", "q3": " paris"}
+
+func answerAll(t *testing.T, p *Problem, username string, wrong bool) systemReq.RecoveryAnswers {
+	res, err := p.RecoveryQuestions(username, "1.1.1.1")
+	require.NoError(t, err)
+	require.Len(t, res.Questions, 2)
+	req := systemReq.RecoveryAnswers{Challenge: res.Challenge}
+	for _, q := range res.Questions {
+		answer := recoveryAnswers[q.Problem]
+		if wrong {
+			answer = "nope"
+		}
+		req.Answers = append(req.Answers, systemReq.RecoveryAnswer{ID: q.ID, Answer: answer})
+	}
+	return req
+}
+
+func TestSetUserProblemSettingHashesAnswers(t *testing.T) {
+	p, user := setupRecoveryTest(t)
+	var problems []system.SysUserProblem
+	require.NoError(t, global.DB.Where("sys_user_id = ?", user.ID).Find(&problems).Error)
+	require.Len(t, problems, 3)
+	for _, item := range problems {
+		assert.True(t, utils.IsPasswordHash(item.Answer))
+	}
+
+	// 更新时答案为空保留原答案
+	old := problems[0].Answer
+	_, err := p.SetUserProblemSetting([]system.SysUserProblem{{MODEL: problems[0].MODEL, SysUserId: int(user.ID), Problem: "q1"}})
+	require.NoError(t, err)
+	var updated system.SysUserProblem
+	require.NoError(t, global.DB.First(&updated, problems[0].ID).Error)
+	assert.Equal(t, old, updated.Answer)
+
+	ok, err := p.VerifyAnswer(user.ID, &system.SysUserProblem{MODEL: problems[0].MODEL, Answer: "BLUE sky"})
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestVerifyAnswerOwnProblemsOnly(t *testing.T) {
+	p, user := setupRecoveryTest(t)
+	var problem system.SysUserProblem
+	require.NoError(t, global.DB.Where("sys_user_id = ?", user.ID).First(&problem).Error)
+
+	// 不能校验其他用户的问题
+	_, err := p.VerifyAnswer(user.ID+1, &system.SysUserProblem{MODEL: problem.MODEL, Answer: "blue sky"})
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	// 答对不清除失败次数
+	ok, err := p.VerifyAnswer(user.ID, &system.SysUserProblem{MODEL: problem.MODEL, Answer: "wrong"})
+	require.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = p.VerifyAnswer(user.ID, &system.SysUserProblem{MODEL: problem.MODEL, Answer: "blue sky"})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	n, err := recoveryCounter.Count(strconv.Itoa(int(user.ID)))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, n)
+}
+
+func TestVerifyAnswerUpgradesPlaintext(t *testing.T) {
+	p, user := setupRecoveryTest(t)
+	legacy := system.SysUserProblem{SysUserId: int(user.ID), Problem: "old", Answer: "Plain Text"}
+	require.NoError(t, global.DB.Create(&legacy).Error)
+
+	ok, err := p.VerifyAnswer(user.ID, &system.SysUserProblem{MODEL: legacy.MODEL, Answer: "plain text"})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	require.NoError(t, global.DB.First(&legacy, legacy.ID).Error)
+	assert.True(t, utils.IsPasswordHash(legacy.Answer))
+}
+
+func TestRecovery(t *testing.T) {
+	p, user := setupRecoveryTest(t)
+
+	_, err := p.RecoveryQuestions("missing", "1.1.1.1")
+	assert.ErrorIs(t, err, ErrRecoveryUnavailable)
+
+	req := answerAll(t, p, user.Username, false)
+	res, err := p.VerifyRecovery(req, "1.1.1.1")
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Token)
+
+	// 同一批问题只能作答一次
+	_, err = p.VerifyRecovery(req, "1.1.1.1")
+	assert.ErrorIs(t, err, ErrRecoveryChallengeInvalid)
+
+	require.NoError(t, PasswordResetServiceApp.ConfirmReset(res.Token, "new-password"))
+	assert.ErrorIs(t, PasswordResetServiceApp.ConfirmReset(res.Token, "again-password"), ErrPasswordResetInvalid)
+	require.NoError(t, global.DB.First(&user, user.ID).Error)
+	ok, _ := utils.VerifyPassword(user.Password, "new-password")
+	assert.True(t, ok)
+}
+
+func TestRecoveryLockout(t *testing.T) {
+	p, user := setupRecoveryTest(t)
+
+	_, err := p.VerifyRecovery(answerAll(t, p, user.Username, true), "1.1.1.1")
+	assert.ErrorIs(t, err, ErrRecoveryAnswerWrong)
+	_, err = p.VerifyRecovery(answerAll(t, p, user.Username, true), "1.1.1.1")
+	assert.ErrorIs(t, err, ErrRecoveryLocked)
+
+	_, err = p.RecoveryQuestions(user.Username, "1.1.1.1")
+	assert.ErrorIs(t, err, ErrRecoveryLocked)
+}
@@ -0,0 +1,364 @@
+package system
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	global "server/model"
+	"server/model/system"
+	"server/utils"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTotpInvalidCode      = errors.New("验证码错误")
+	ErrTotpNotEnabled       = errors.New("未开启两步验证")
+	ErrTotpAlreadyEnabled   = errors.New("已开启两步验证, 如需更换验证器请先关闭")
+	ErrTotpNotSetup         = errors.New("请先生成验证器密钥")
+	ErrTotpRequired         = errors.New("当前角色必须开启两步验证")
+	ErrTotpChallengeInvalid = errors.New("登录凭证无效或已过期, 请重新登录")
+)
+
+//@function: Required
+//@description: 用户当前角色或所属任一角色在 totp.required-authorities 中时必须开启两步验证
+//@param: user *system.SysUser
+//@return: bool
+
+func (t *TotpService) Required(user *system.SysUser) bool {
+	required := global.CONFIG.Totp.RequiredAuthorities
+	if len(required) == 0 {
+		return false
+	}
+	if slices.Contains(required, user.AuthorityId) {
+		return true
+	}
+	for _, authority := range user.Authorities {
+		if slices.Contains(required, authority.AuthorityId) {
+			return true
+		}
+	}
+	return false
+}
+
+//@function: Enabled
+//@description: 用户是否已开启两步验证
+//@param: userId uint
+//@return: bool, error
+
+func (t *TotpService) Enabled(userId uint) (bool, error) {
+	var count int64
+	err := global.DB.Model(&system.SysUserTotp{}).Where("user_id = ? AND enabled = ?", userId, true).Count(&count).Error
+	return count > 0, err
+}
+
+//@function: RecoveryCodesLeft
+//@description: 剩余可用的恢复码数量
+//@param: userId uint
+//@return: int64, error
+
+func (t *TotpService) RecoveryCodesLeft(userId uint) (count int64, err error) {
+	err = global.DB.Model(&system.SysUserRecoveryCode{}).Where("user_id = ? AND used_at IS NULL", userId).Count(&count).Error
+	return
+}
+
+//@function: Setup
+//@description: 生成新的验证器密钥, 需要调用 Enable 校验一次验证码后才生效
+//@param: user *system.SysUser
+//@return: secret string, uri string, err error
+
+func (t *TotpService) Setup(user *system.SysUser) (secret, uri string, err error) {
+	var record system.SysUserTotp
+	err = global.DB.Where("user_id = ?", user.ID).First(&record).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return
+	}
+	if record.Enabled {
+		return "", "", ErrTotpAlreadyEnabled
+	}
+	if secret, err = utils.GenerateTotpSecret(); err != nil {
+		return
+	}
+	record.UserId = user.ID
+	record.Secret = secret
+	record.LastStep = 0
+	if err = global.DB.Save(&record).Error; err != nil {
+		return
+	}
+	issuer := global.CONFIG.Totp.Issuer
+	if issuer == "" {
+		issuer = "server"
+	}
+	return secret, utils.TotpURI(issuer, user.Username, secret), nil
+}
+
+//@function: Enable
+//@description: 校验验证器生成的验证码后开启两步验证, 同时生成一组新的恢复码
+//@param: userId uint, code string
+//@return: recoveryCodes []string, err error
+
+func (t *TotpService) Enable(userId uint, code string) (recoveryCodes []string, err error) {
+	err = global.DB.Transaction(func(tx *gorm.DB) error {
+		var record system.SysUserTotp
+		if err := tx.Where("user_id = ?", userId).First(&record).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTotpNotSetup
+			}
+			return err
+		}
+		if record.Enabled {
+			return ErrTotpAlreadyEnabled
+		}
+		if err := t.verifyTotp(tx, &record, code); err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := tx.Model(&record).Updates(map[string]any{"enabled": true, "enabled_at": now}).Error; err != nil {
+			return err
+		}
+		var err error
+		recoveryCodes, err = t.resetRecoveryCodes(tx, userId)
+		return err
+	})
+	return
+}
+
+//@function: Disable
+//@description: 关闭两步验证, 需要验证码或恢复码; 角色要求开启时不允许关闭
+//@param: user *system.SysUser, code string
+//@return: error
+
+func (t *TotpService) Disable(user *system.SysUser, code string) error {
+	if t.Required(user) {
+		return ErrTotpRequired
+	}
+	if err := t.Verify(user.ID, code); err != nil {
+		return err
+	}
+	return t.Reset(user.ID)
+}
+
+//@function: RegenerateRecoveryCodes
+//@description: 作废旧的恢复码并生成新的一组, 需要验证码或恢复码
+//@param: userId uint, code string
+//@return: recoveryCodes []string, err error
+
+func (t *TotpService) RegenerateRecoveryCodes(userId uint, code string) (recoveryCodes []string, err error) {
+	if err = t.Verify(userId, code); err != nil {
+		return
+	}
+	err = global.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		recoveryCodes, err = t.resetRecoveryCodes(tx, userId)
+		return err
+	})
+	return
+}
+
+//@function: Reset
+//@description: 删除用户的验证器和恢复码, 用于关闭或管理员帮助丢失设备的用户重置
+//@param: userId uint
+//@return: error
+
+func (t *TotpService) Reset(userId uint) error {
+	return global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("user_id = ?", userId).Delete(&system.SysUserTotp{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("user_id = ?", userId).Delete(&system.SysUserRecoveryCode{}).Error
+	})
+}
+
+//@function: Verify
+//@description: 校验 6 位验证码或一次性恢复码, 同一个验证码和恢复码只能使用一次
+//@param: userId uint, code string
+//@return: error
+
+func (t *TotpService) Verify(userId uint, code string) error {
+	var record system.SysUserTotp
+	if err := global.DB.Where("user_id = ? AND enabled = ?", userId, true).First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTotpNotEnabled
+		}
+		return err
+	}
+	code = normalizeRecoveryCode(code)
+	if len(code) == utils.TotpDigits {
+		return t.verifyTotp(global.DB, &record, code)
+	}
+	result := global.DB.Model(&system.SysUserRecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userId, utils.Sha256V(code)).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTotpInvalidCode
+	}
+	return nil
+}
+
+//@function: CreateChallenge
+//@description: 密码校验通过后签发第二步登录凭证
+//@param: userId uint
+//@return: token string, expiresAt time.Time, err error
+
+func (t *TotpService) CreateChallenge(userId uint) (token string, expiresAt time.Time, err error) {
+	if token, err = utils.RandomToken(32); err != nil {
+		return
+	}
+	seconds := global.CONFIG.Totp.ChallengeTime
+	if seconds <= 0 {
+		seconds = 300
+	}
+	record := system.SysTotpChallenge{
+		TokenHash: utils.Sha256V(token),
+		UserId:    userId,
+		ExpiresAt: time.Now().Add(time.Duration(seconds) * time.Second),
+	}
+	return token, record.ExpiresAt, global.DB.Create(&record).Error
+}
+
+//@function: ChallengeUser
+//@description: 取凭证对应的用户, 凭证不会被消耗, 用于登录前绑定验证器
+//@param: token string
+//@return: userId uint, err error
+
+func (t *TotpService) ChallengeUser(token string) (uint, error) {
+	record, err := t.challenge(token)
+	if err != nil {
+		return 0, err
+	}
+	return record.UserId, nil
+}
+
+//@function: CompleteChallenge
+//@description: 第二步登录, 校验通过后凭证作废; 输错次数超过 totp.max-attempts 后凭证失效
+//@param: token string, code string
+//@return: userId uint, err error
+
+func (t *TotpService) CompleteChallenge(token, code string) (uint, error) {
+	record, err := t.challenge(token)
+	if err != nil {
+		return 0, err
+	}
+	if err = t.Verify(record.UserId, code); err != nil {
+		return 0, t.challengeFailed(record, err)
+	}
+	return record.UserId, t.consumeChallenge(record)
+}
+
+//@function: ActivateChallenge
+//@description: 角色要求两步验证但尚未绑定时, 用登录凭证完成绑定并直接登录
+//@param: token string, code string
+//@return: userId uint, recoveryCodes []string, err error
+
+func (t *TotpService) ActivateChallenge(token, code string) (uint, []string, error) {
+	record, err := t.challenge(token)
+	if err != nil {
+		return 0, nil, err
+	}
+	recoveryCodes, err := t.Enable(record.UserId, code)
+	if err != nil {
+		return 0, nil, t.challengeFailed(record, err)
+	}
+	return record.UserId, recoveryCodes, t.consumeChallenge(record)
+}
+
+func (t *TotpService) challenge(token string) (*system.SysTotpChallenge, error) {
+	var record system.SysTotpChallenge
+	err := global.DB.Where("token_hash = ?", utils.Sha256V(token)).First(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTotpChallengeInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	if record.UsedAt != nil || time.Now().After(record.ExpiresAt) || record.Attempts >= t.maxAttempts() {
+		return nil, ErrTotpChallengeInvalid
+	}
+	return &record, nil
+}
+
+// challengeFailed 验证码错误时累计失败次数, 其他错误原样返回
+func (t *TotpService) challengeFailed(record *system.SysTotpChallenge, err error) error {
+	if !errors.Is(err, ErrTotpInvalidCode) {
+		return err
+	}
+	if updateErr := global.DB.Model(record).UpdateColumn("attempts", gorm.Expr("attempts + 1")).Error; updateErr != nil {
+		return updateErr
+	}
+	return err
+}
+
+// consumeChallenge 条件更新保证凭证只能成功使用一次
+func (t *TotpService) consumeChallenge(record *system.SysTotpChallenge) error {
+	result := global.DB.Model(&system.SysTotpChallenge{}).Where("id = ? AND used_at IS NULL", record.ID).Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTotpChallengeInvalid
+	}
+	return nil
+}
+
+func (t *TotpService) maxAttempts() int {
+	if global.CONFIG.Totp.MaxAttempts > 0 {
+		return global.CONFIG.Totp.MaxAttempts
+	}
+	return 5
+}
+
+// verifyTotp 校验验证码并记录使用过的时间步, 同一时间步的验证码不能重复使用
+func (t *TotpService) verifyTotp(db *gorm.DB, record *system.SysUserTotp, code string) error {
+	skew := global.CONFIG.Totp.Skew
+	if skew < 0 {
+		skew = 0
+	}
+	step, ok := utils.ValidateTotp(record.Secret, code, time.Now(), skew)
+	if !ok || step <= record.LastStep {
+		return ErrTotpInvalidCode
+	}
+	result := db.Model(&system.SysUserTotp{}).Where("id = ? AND last_step < ?", record.ID, step).UpdateColumn("last_step", step)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTotpInvalidCode
+	}
+	record.LastStep = step
+	return nil
+}
+
+func (t *TotpService) resetRecoveryCodes(db *gorm.DB, userId uint) ([]string, error) {
+	if err := db.Unscoped().Where("user_id = ?", userId).Delete(&system.SysUserRecoveryCode{}).Error; err != nil {
+		return nil, err
+	}
+	n := global.CONFIG.Totp.RecoveryCodes
+	if n <= 0 {
+		n = 10
+	}
+	codes := make([]string, 0, n)
+	records := make([]system.SysUserRecoveryCode, 0, n)
+	for range n {
+		buf := make([]byte, 5)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+		code := strings.ToLower(base32.StdEncoding.EncodeToString(buf)) // 8 个字符
+		codes = append(codes, code[:4]+"-"+code[4:])
+		records = append(records, system.SysUserRecoveryCode{UserId: userId, CodeHash: utils.Sha256V(code)})
+	}
+	return codes, db.Create(&records).Error
+}
+
+// normalizeRecoveryCode 忽略大小写、空格和连字符
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -0,0 +1,116 @@
+package system
+
+import (
+	"testing"
+	"time"
+
+	"server/config"
+	global "server/model"
+	"server/model/system"
+	"server/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTotpTest(t *testing.T) (*TotpService, *system.SysUser) {
+	setupTestDB(t, &system.SysUser{}, &system.SysUserTotp{}, &system.SysUserRecoveryCode{}, &system.SysTotpChallenge{})
+	global.CONFIG.Totp = config.Totp{Issuer: "test", MaxAttempts: 3, Skew: 1, RecoveryCodes: 4}
+	t.Cleanup(func() { global.CONFIG.Totp = config.Totp{} })
+	user := &system.SysUser{Username: "admin", AuthorityId: "888"}
+	require.NoError(t, global.DB.Create(user).Error)
+	return &TotpService{}, user
+}
+
+// 用上一个时间步的验证码, 避免和测试中后续使用的当前验证码冲突
+func totpCode(t *testing.T, secret string, offset int64) string {
+	code, err := utils.TotpCode(secret, utils.TotpStep(time.Now())+offset)
+	require.NoError(t, err)
+	return code
+}
+
+func TestTotpEnableAndVerify(t *testing.T) {
+	svc, user := setupTotpTest(t)
+
+	_, err := svc.Enable(user.ID, "000000")
+	assert.ErrorIs(t, err, ErrTotpNotSetup)
+
+	secret, uri, err := svc.Setup(user)
+	require.NoError(t, err)
+	assert.Contains(t, uri, "secret="+secret)
+
+	_, err = svc.Enable(user.ID, "000000")
+	assert.ErrorIs(t, err, ErrTotpInvalidCode)
+
+	codes, err := svc.Enable(user.ID, totpCode(t, secret, -1))
+	require.NoError(t, err)
+	assert.Len(t, codes, 4)
+	enabled, err := svc.Enabled(user.ID)
+	require.NoError(t, err)
+	assert.True(t, enabled)
+
+	_, _, err = svc.Setup(user)
+	assert.ErrorIs(t, err, ErrTotpAlreadyEnabled)
+
+	assert.ErrorIs(t, svc.Verify(user.ID, totpCode(t, secret, -1)), ErrTotpInvalidCode, "同一时间步的验证码不能重复使用")
+	assert.NoError(t, svc.Verify(user.ID, totpCode(t, secret, 0)))
+
+	assert.NoError(t, svc.Verify(user.ID, codes[0]))
+	assert.ErrorIs(t, svc.Verify(user.ID, codes[0]), ErrTotpInvalidCode, "恢复码只能使用一次")
+	left, err := svc.RecoveryCodesLeft(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), left)
+
+	global.CONFIG.Totp.RequiredAuthorities = []string{"888"}
+	assert.ErrorIs(t, svc.Disable(user, codes[1]), ErrTotpRequired)
+	global.CONFIG.Totp.RequiredAuthorities = nil
+	require.NoError(t, svc.Disable(user, codes[1]))
+	assert.ErrorIs(t, svc.Verify(user.ID, codes[2]), ErrTotpNotEnabled)
+}
+
+func TestTotpChallenge(t *testing.T) {
+	svc, user := setupTotpTest(t)
+	secret, _, err := svc.Setup(user)
+	require.NoError(t, err)
+
+	// 角色要求开启但尚未绑定: 用凭证完成绑定并登录
+	challenge, _, err := svc.CreateChallenge(user.ID)
+	require.NoError(t, err)
+	userId, err := svc.ChallengeUser(challenge)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, userId)
+	userId, codes, err := svc.ActivateChallenge(challenge, totpCode(t, secret, -1))
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, userId)
+	assert.NotEmpty(t, codes)
+	_, err = svc.CompleteChallenge(challenge, totpCode(t, secret, 0))
+	assert.ErrorIs(t, err, ErrTotpChallengeInvalid, "凭证只能使用一次")
+
+	// 输错次数达到上限后凭证失效
+	challenge, _, err = svc.CreateChallenge(user.ID)
+	require.NoError(t, err)
+	for range 3 {
+		_, err = svc.CompleteChallenge(challenge, "000000")
+		assert.ErrorIs(t, err, ErrTotpInvalidCode)
+	}
+	_, err = svc.CompleteChallenge(challenge, totpCode(t, secret, 0))
+	assert.ErrorIs(t, err, ErrTotpChallengeInvalid)
+
+	challenge, _, err = svc.CreateChallenge(user.ID)
+	require.NoError(t, err)
+	userId, err = svc.CompleteChallenge(challenge, codes[0])
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, userId)
+
+	_, err = svc.CompleteChallenge("unknown", "000000")
+	assert.ErrorIs(t, err, ErrTotpChallengeInvalid)
+}
+
+func TestTotpRequired(t *testing.T) {
+	svc := &TotpService{}
+	global.CONFIG.Totp.RequiredAuthorities = []string{"888"}
+	t.Cleanup(func() { global.CONFIG.Totp = config.Totp{} })
+	assert.True(t, svc.Required(&system.SysUser{AuthorityId: "888"}))
+	assert.True(t, svc.Required(&system.SysUser{AuthorityId: "9528", Authorities: []system.SysAuthority{{AuthorityId: "888"}}}))
+	assert.False(t, svc.Required(&system.SysUser{AuthorityId: "9528"}))
+}
@@ -28,6 +28,10 @@ type autoCodePgsql struct{}
 
 var AutoCodePgsql = new(autoCodePgsql)
 
+type autoCodeSqlite struct{}
+
+var AutoCodeSqlite = new(autoCodeSqlite)
+
 type AutoCodeHistoryService struct{}
 
 var AutoCodeHistoryServiceApp = new(AutoCodeHistoryService)
@@ -54,6 +58,7 @@ var GithubServiceApp = new(GithubService)
 
 type MysqlInitHandler struct{}
 type PgsqlInitHandler struct{}
+type SqliteInitHandler struct{}
 type MenuService struct{}
 
 var MenuServiceApp = new(MenuService)
@@ -62,6 +67,8 @@ type OperationRecordService struct{}
 
 var OperationRecordServiceApp = new(OperationRecordService)
 
+var OperationRecordWriterApp = new(OperationRecordWriter)
+
 type SystemConfigService struct{}
 
 var SystemConfigServiceApp = new(SystemConfigService)
@@ -74,3 +81,51 @@ type UserService struct{}
 
 var UserServiceApp = new(UserService)
 var InitDBServiceApp = new(InitDBService)
+
+type MigrationService struct{}
+
+var MigrationServiceApp = new(MigrationService)
+
+type RefreshTokenService struct{}
+
+var RefreshTokenServiceApp = new(RefreshTokenService)
+
+type TotpService struct{}
+
+var TotpServiceApp = new(TotpService)
+
+type PasswordResetService struct{}
+
+var PasswordResetServiceApp = new(PasswordResetService)
+
+type LoginGuardService struct{}
+
+var LoginGuardServiceApp = new(LoginGuardService)
+
+type OidcService struct{}
+
+var OidcServiceApp = new(OidcService)
+
+type ApiKeyService struct{}
+
+var ApiKeyServiceApp = new(ApiKeyService)
+
+type SessionService struct{}
+
+var SessionServiceApp = new(SessionService)
+
+type AuditLogService struct{}
+
+var AuditLogServiceApp = new(AuditLogService)
+
+type OwnershipService struct{}
+
+var OwnershipServiceApp = new(OwnershipService)
+
+type MaskingService struct{}
+
+var MaskingServiceApp = new(MaskingService)
+
+type EncryptionService struct{}
+
+var EncryptionServiceApp = new(EncryptionService)
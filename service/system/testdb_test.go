@@ -0,0 +1,26 @@
+package system
+
+import (
+	"path/filepath"
+	"testing"
+
+	global "server/model"
+
+	"github.com/glebarez/sqlite"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// setupTestDB 使用临时 sqlite 库替换 global.DB, 并迁移给定的表
+func setupTestDB(t *testing.T, tables ...any) string {
+	dir := t.TempDir()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(dir, "test.db")), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(tables...))
+	global.DB, global.LOG, global.BlackCache = db, zap.NewNop(), local_cache.NewCache()
+	t.Cleanup(func() { global.DB = nil })
+	return dir
+}
@@ -41,6 +41,13 @@ var SystemServiceSet = wire.NewSet(
 	ProvideProblemService,
 	ProvideSystemUserService,
 	ProvideInitDBService,
+	ProvideMigrationService,
+	ProvideTotpService,
+	ProvideLoginGuardService,
+	ProvideOidcService,
+	ProvideApiKeyService,
+	ProvideSessionService,
+	ProvideAuditLogService,
 )
 
 // FrontendServiceSet Frontend 服务集合
@@ -176,6 +183,34 @@ func ProvideInitDBService() *system.InitDBService {
 	return &system.InitDBService{}
 }
 
+func ProvideMigrationService() *system.MigrationService {
+	return &system.MigrationService{}
+}
+
+func ProvideTotpService() *system.TotpService {
+	return &system.TotpService{}
+}
+
+func ProvideLoginGuardService() *system.LoginGuardService {
+	return &system.LoginGuardService{}
+}
+
+func ProvideOidcService() *system.OidcService {
+	return &system.OidcService{}
+}
+
+func ProvideApiKeyService() *system.ApiKeyService {
+	return &system.ApiKeyService{}
+}
+
+func ProvideSessionService() *system.SessionService {
+	return &system.SessionService{}
+}
+
+func ProvideAuditLogService() *system.AuditLogService {
+	return &system.AuditLogService{}
+}
+
 // ========== Frontend Services ==========
 
 func ProvideFrontendArticleService() *frontend.Article {
@@ -225,4 +260,3 @@ func ProvideCustomerService() *example.CustomerService {
 func ProvideExcelService() *example.ExcelService {
 	return &example.ExcelService{}
 }
-
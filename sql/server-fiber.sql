@@ -535,7 +535,7 @@ INSERT INTO `server-fiber`.`articles` (`id`,`title`,`desc`,`content`,`created_at
 -- server-fiber.base_messages DML
 INSERT INTO `server-fiber`.`base_messages` (`id`,`created_at`,`updated_at`,`deleted_at`,`title`,`introduction`,`head_img`,`copyright`,`link`,`record_info`,`user_id`) VALUES (1,'2022-10-17 14:11:22.000','2022-10-17 14:11:31.000',NULL,'fu','护士','/upload/image/3452345.png','sdfasdf','dfasdfa','asdfasdfas',1),(2,'2022-10-17 14:25:00.370','2023-01-05 19:37:36.808',NULL,'漂亮战斗','完美、漂亮','uploads/file/c4c64d58519e9da37804679ebc58244a_20221117104843.jpg','作死4444','http://localhost:8080','嘿嘿',2),(3,'2023-01-06 10:27:57.161','2023-01-06 10:27:57.161',NULL,'功夫','功夫的神','uploads/file/4441cd7e6b665842bd3d8b390dd9c3aa_20230106102715.png','对战功夫','http://localhost:8080','搞笑',3) ON DUPLICATE KEY UPDATE `id` = VALUES(`id`),`created_at` = VALUES(`created_at`),`updated_at` = VALUES(`updated_at`),`deleted_at` = VALUES(`deleted_at`),`title` = VALUES(`title`),`introduction` = VALUES(`introduction`),`head_img` = VALUES(`head_img`),`copyright` = VALUES(`copyright`),`link` = VALUES(`link`),`record_info` = VALUES(`record_info`),`user_id` = VALUES(`user_id`);
 -- server-fiber.casbin_rule DML
-INSERT INTO `server-fiber`.`casbin_rule` (`p_type`,`v0`,`v1`,`v2`,`v3`,`v4`,`v5`,`id`,`ptype`) VALUES ('p','998','/backend/tag/findTag/:id','GET','','','',1,'p'),('p','998','/backend/tag/getTagList','GET','','','',2,'p'),('p','9528','/backend/backend/user/setUserAuthority','POST','','','',3,'p'),('p','9528','/backend/base/login','POST','','','',4,'p'),('p','998','/backend/article/createArticle','POST','','','',5,'p'),('p','9528','/backend/jwt/jsonInBlacklist','POST','','','',6,'p'),('p','998','/backend/article/deleteArticle/:id','DELETE','','','',7,'p'),('p','9528','/backend/user/getUserInfo','GET','','','',8,'p'),('p','998','/backend/article/deleteArticleByIds','DELETE','','','',9,'p'),('p','9528','/backend/user/getUserCount','GET','','','',10,'p'),('p','998','/backend/article/updateArticle/:id','PUT','','','',11,'p'),('p','9528','/backend/menu/getMenu','GET','','','',12,'p'),('p','998','/backend/article/findArticle/:id','GET','','','',13,'p'),('p','8881','/backend/base/login','POST','','','',14,'p'),('p','8881','/backend/jwt/jsonInBlacklist','POST','','','',15,'p'),('p','8881','/backend/user/setSelfInfo','PUT','','','',16,'p'),('p','8881','/backend/user/getUserInfo','GET','','','',17,'p'),('p','8881','/backend/user/changePassword','POST','','','',18,'p'),('p','8881','/backend/user/setUserAuthority','POST','','','',19,'p'),('p','66','/backend/base/login','POST','','','',20,'p'),('p','66','/backend/fileUploadAndDownload/findFile','GET','','','',21,'p'),('p','66','/backend/fileUploadAndDownload/breakpointContinue','POST','','','',22,'p'),('p','66','/backend/fileUploadAndDownload/breakpointContinueFinish','POST','','','',23,'p'),('p','66','/backend/fileUploadAndDownload/removeChunk','POST','','','',24,'p'),('p','66','/backend/tag/createTag','POST','','','',25,'p'),('p','66','/backend/tag/deleteTag/:id','DELETE','','','',26,'p'),('p','66','/backend/tag/deleteTagByIds','DELETE','','','',27,'p'),('p','66','/backend/tag/updateTag','PUT','','','',28,'p'),('p','66','/backend/tag/findTag/:id','GET','','','',29,'p'),('p','66','/backend/tag/getTagList','GET','','','',30,'p'),('p','66','/backend/article/createArticle','POST','','','',31,'p'),('p','66','/backend/article/deleteArticle/:id','DELETE','','','',32,'p'),('p','66','/backend/article/deleteArticleByIds','DELETE','','','',33,'p'),('p','66','/backend/article/updateArticle/:id','PUT','','','',34,'p'),('p','66','/backend/article/findArticle/:id','GET','','','',35,'p'),('p','66','/backend/article/getArticleList','GET','','','',36,'p'),('p','66','/backend/article/putArticleByIds','PUT','','','',37,'p'),('p','66','/backend/article/getArticleReading','GET','','','',38,'p'),('p','66','/backend/comment/createComment','POST','','','',39,'p'),('p','66','/backend/comment/deleteComment','DELETE','','','',40,'p'),('p','66','/backend/comment/deleteCommentByIds','DELETE','','','',41,'p'),('p','66','/backend/comment/updateComment','PUT','','','',42,'p'),('p','66','/backend/comment/findComment','GET','','','',43,'p'),('p','66','/backend/comment/getCommentList','GET','','','',44,'p'),('p','66','/backend/comment/getCommentTreeList','GET','','','',45,'p'),('p','998','/backend/article/getArticleList','GET','','','',46,'p'),('p','66','/backend/comment/pariseComment','PUT','','','',47,'p'),('p','66','/backend/base_message/getBaseMessage/:id','GET','','','',48,'p'),('p','998','/backend/comment/createComment','POST','','','',49,'p'),('p','66','/backend/base_message/createBaseMessage','POST','','','',50,'p'),('p','998','/backend/comment/deleteComment','DELETE','','','',51,'p'),('p','66','/backend/base_message/updateBaseMessage','PUT','','','',52,'p'),('p','66','/backend/base_message/upload_file','POST','','','',53,'p'),('p','66','/backend/github/createGithub','POST','','','',54,'p'),('p','66','/backend/github/getGithubList','GET','','','',55,'p'),('p','998','/backend/base/login','POST','','','',56,'p'),('p','998','/backend/comment/deleteCommentByIds','DELETE','','','',57,'p'),('p','998','/backend/jwt/jsonInBlacklist','POST','','','',58,'p'),('p','998','/backend/comment/updateComment','PUT','','','',59,'p'),('p','998','/backend/user/deleteUser','DELETE','','','',60,'p'),('p','998','/backend/comment/findComment','GET','','','',61,'p'),('p','998','/backend/user/admin_register','POST','','','',62,'p'),('p','998','/backend/comment/getCommentList','GET','','','',63,'p'),('p','998','/backend/base_message/createBaseMessage','POST','','','',64,'p'),('p','998','/backend/base_message/updateBaseMessage','PUT','','','',65,'p'),('p','998','/backend/user/getUserList','GET','','','',69,'p'),('p','998','/backend/user/setUserInfo','PUT','','','',71,'p'),('p','998','/backend/user/setSelfInfo','PUT','','','',73,'p'),('p','998','/backend/user/getUserInfo','GET','','','',75,'p'),('p','998','/backend/user/setUserAuthorities','POST','','','',77,'p'),('p','998','/backend/user/changePassword','POST','','','',79,'p'),('p','998','/backend/user/setUserAuthority','POST','','','',80,'p'),('p','998','/backend/user/resetPassword','POST','','','',82,'p'),('p','998','/backend/user/getUserCount','GET','','','',83,'p'),('p','998','/backend/api/createApi','POST','','','',88,'p'),('p','998','/backend/api/deleteApi/:id','DELETE','','','',90,'p'),('p','998','/backend/api/updateApi/:id','PUT','','','',92,'p'),('p','998','/backend/api/getApiList','GET','','','',94,'p'),('p','998','/backend/api/getAllApis','GET','','','',96,'p'),('p','998','/backend/api/getApiById/:id','GET','','','',97,'p'),('p','998','/backend/api/deleteApisByIds','DELETE','','','',98,'p'),('p','998','/backend/api/getAllApisList','GET','','','',99,'p'),('p','998','/backend/authority/copyAuthority','POST','','','',100,'p'),('p','998','/backend/authority/createAuthority','POST','','','',101,'p'),('p','998','/backend/authority/deleteAuthority','DELETE','','','',102,'p'),('p','998','/backend/authority/updateAuthority','PUT','','','',103,'p'),('p','998','/backend/authority/getAuthorityList','GET','','','',104,'p'),('p','998','/backend/authority/setDataAuthority','POST','','','',105,'p'),('p','998','/backend/casbin/updateCasbin','POST','','','',106,'p'),('p','998','/backend/casbin/getPolicyPathByAuthorityId/:id','GET','','','',107,'p'),('p','998','/backend/menu/addBaseMenu','POST','','','',108,'p'),('p','998','/backend/menu/getMenu','GET','','','',109,'p'),('p','998','/backend/menu/deleteBaseMenu/:id','DELETE','','','',110,'p'),('p','998','/backend/menu/updateBaseMenu','PUT','','','',111,'p'),('p','998','/backend/menu/getBaseMenuById/:id','GET','','','',112,'p'),('p','998','/backend/menu/getMenuList','GET','','','',113,'p'),('p','998','/backend/menu/getBaseMenuTree','GET','','','',114,'p'),('p','998','/backend/menu/getMenuAuthority','GET','','','',115,'p'),('p','998','/backend/menu/addMenuAuthority','POST','','','',116,'p'),('p','998','/backend/sysDictionary/createSysDictionary','POST','','','',117,'p'),('p','998','/backend/sysDictionary/deleteSysDictionary','DELETE','','','',118,'p'),('p','998','/backend/sysDictionary/updateSysDictionary','PUT','','','',119,'p'),('p','998','/backend/sysDictionary/findSysDictionary','GET','','','',120,'p'),('p','998','/backend/sysDictionary/getSysDictionaryList','GET','','','',121,'p'),('p','998','/backend/sysOperationRecord/createSysOperationRecord','POST','','','',122,'p'),('p','998','/backend/sysOperationRecord/findSysOperationRecord','GET','','','',123,'p'),('p','998','/backend/sysOperationRecord/getSysOperationRecordList','GET','','','',124,'p'),('p','998','/backend/sysOperationRecord/deleteSysOperationRecord','DELETE','','','',125,'p'),('p','998','/backend/sysOperationRecord/deleteSysOperationRecordByIds','DELETE','','','',126,'p'),('p','998','/backend/simpleUploader/upload','POST','','','',127,'p'),('p','998','/backend/simpleUploader/checkFileMd5','GET','','','',128,'p'),('p','998','/backend/simpleUploader/mergeFileMd5','GET','','','',129,'p'),('p','998','/backend/email/emailTest','POST','','','',130,'p'),('p','998','/backend/email/emailSend','POST','','','',131,'p'),('p','998','/backend/authorityBtn/setAuthorityBtn','POST','','','',132,'p'),('p','998','/backend/authorityBtn/getAuthorityBtn','GET','','','',137,'p'),('p','998','/backend/authorityBtn/canRemoveAuthorityBtn/:id','DELETE','','','',138,'p'),('p','998','/backend/tag/createTag','POST','','','',140,'p'),('p','998','/backend/tag/deleteTag/:id','DELETE','','','',142,'p'),('p','998','/backend/tag/deleteTagByIds','DELETE','','','',144,'p'),('p','998','/backend/tag/updateTag','PUT','','','',146,'p'),('p','9528','/backend/user/getFlow','GET','','','',1903,'p'),('p','998','/backend/user/getFlow','GET','','','',1904,'p'),('p','66','/backend/user/getFlow','GET','','','',1905,'p'),('p','8881','/backend/menu/getMenu','GET','','','',1906,'p'),('p','66','/backend/menu/getMenu','GET','','','',1907,'p'),(NULL,'888','/backend/base/login','POST','','','',2208,'p'),(NULL,'888','/backend/jwt/jsonInBlacklist','POST','','','',2209,'p'),(NULL,'888','/backend/user/deleteUser','DELETE','','','',2210,'p'),(NULL,'888','/backend/user/admin_register','POST','','','',2211,'p'),(NULL,'888','/backend/user/getUserList','GET','','','',2212,'p'),(NULL,'888','/backend/user/setUserInfo','PUT','','','',2213,'p'),(NULL,'888','/backend/user/setSelfInfo','PUT','','','',2214,'p'),(NULL,'888','/backend/user/getUserInfo','GET','','','',2215,'p'),(NULL,'888','/backend/user/setUserAuthorities','POST','','','',2216,'p'),(NULL,'888','/backend/user/changePassword','POST','','','',2217,'p'),(NULL,'888','/backend/user/setUserAuthority','POST','','','',2218,'p'),(NULL,'888','/backend/user/resetPassword','POST','','','',2219,'p'),(NULL,'888','/backend/user/getUserCount','GET','','','',2220,'p'),(NULL,'888','/backend/api/createApi','POST','','','',2221,'p'),(NULL,'888','/backend/api/deleteApi/:id','DELETE','','','',2222,'p'),(NULL,'888','/backend/api/updateApi/:id','PUT','','','',2223,'p'),(NULL,'888','/backend/api/getApiList','GET','','','',2224,'p'),(NULL,'888','/backend/api/getAllApis','GET','','','',2225,'p'),(NULL,'888','/backend/api/getApiById/:id','GET','','','',2226,'p'),(NULL,'888','/backend/api/deleteApisByIds','DELETE','','','',2227,'p'),(NULL,'888','/backend/api/getAllApisList','GET','','','',2228,'p'),(NULL,'888','/backend/authority/copyAuthority','POST','','','',2229,'p'),(NULL,'888','/backend/authority/createAuthority','POST','','','',2230,'p'),(NULL,'888','/backend/authority/deleteAuthority','DELETE','','','',2231,'p'),(NULL,'888','/backend/authority/updateAuthority','PUT','','','',2232,'p'),(NULL,'888','/backend/authority/getAuthorityList','GET','','','',2233,'p'),(NULL,'888','/backend/authority/setDataAuthority','POST','','','',2234,'p'),(NULL,'888','/backend/casbin/updateCasbin','POST','','','',2235,'p'),(NULL,'888','/backend/casbin/getPolicyPathByAuthorityId/:id','GET','','','',2236,'p'),(NULL,'888','/backend/menu/addBaseMenu','POST','','','',2237,'p'),(NULL,'888','/backend/menu/getMenu','GET','','','',2238,'p'),(NULL,'888','/backend/menu/deleteBaseMenu/:id','DELETE','','','',2239,'p'),(NULL,'888','/backend/menu/updateBaseMenu','PUT','','','',2240,'p'),(NULL,'888','/backend/menu/getBaseMenuById/:id','GET','','','',2241,'p'),(NULL,'888','/backend/menu/getMenuList','GET','','','',2242,'p'),(NULL,'888','/backend/menu/getBaseMenuTree','GET','','','',2243,'p'),(NULL,'888','/backend/menu/getMenuAuthority','GET','','','',2244,'p'),(NULL,'888','/backend/menu/addMenuAuthority','POST','','','',2245,'p'),(NULL,'888','/backend/fileUploadAndDownload/findFile','GET','','','',2246,'p'),(NULL,'888','/backend/fileUploadAndDownload/breakpointContinue','POST','','','',2247,'p'),(NULL,'888','/backend/fileUploadAndDownload/breakpointContinueFinish','POST','','','',2248,'p'),(NULL,'888','/backend/fileUploadAndDownload/removeChunk','POST','','','',2249,'p'),(NULL,'888',' /backend/fileUploadAndDownload/deleteFileBreakpoint/:id','DELETE','','','',2250,'p'),(NULL,'888','/backend/fileUploadAndDownload/getFileBreakpoint','GET','','','',2251,'p'),(NULL,'888','/backend/fileUploadAndDownload/upload','POST','','','',2252,'p'),(NULL,'888','/backend/fileUploadAndDownload/deleteFile/:id','DELETE','','','',2253,'p'),(NULL,'888','/backend/fileUploadAndDownload/editFileName','PUT','','','',2254,'p'),(NULL,'888','/backend/fileUploadAndDownload/getFileList','GET','','','',2255,'p'),(NULL,'888','/backend/system/getServerInfo','GET','','','',2256,'p'),(NULL,'888','/backend/system/getSystemConfig','GET','','','',2257,'p'),(NULL,'888','/backend/system/setSystemConfig','PUT','','','',2258,'p'),(NULL,'888','/backend/customer/customer/:id','PUT','','','',2259,'p'),(NULL,'888','/backend/customer/customer','POST','','','',2260,'p'),(NULL,'888','/backend/customer/customer/:id','DELETE','','','',2261,'p'),(NULL,'888','/backend/customer/customer/:id','GET','','','',2262,'p'),(NULL,'888','/backend/customer/customerList','GET','','','',2263,'p'),(NULL,'888','/backend/autoCode/getDB','GET','','','',2264,'p'),(NULL,'888','/backend/autoCode/getTables','GET','','','',2265,'p'),(NULL,'888','/backend/autoCode/createTemp','POST','','','',2266,'p'),(NULL,'888','/backend/autoCode/preview','POST','','','',2267,'p'),(NULL,'888','/backend/autoCode/getColumn','GET','','','',2268,'p'),(NULL,'888','/backend/autoCode/createPackage','POST','','','',2269,'p'),(NULL,'888','/backend/autoCode/getPackage','POST','','','',2270,'p'),(NULL,'888','/backend/autoCode/delPackage','POST','','','',2271,'p'),(NULL,'888','/backend/autoCode/getMeta','POST','','','',2272,'p'),(NULL,'888','/backend/autoCode/rollback','POST','','','',2273,'p'),(NULL,'888','/backend/autoCode/getSysHistory','POST','','','',2274,'p'),(NULL,'888','/backend/autoCode/delSysHistory','POST','','','',2275,'p'),(NULL,'888','/backend/sysDictionaryDetail/updateSysDictionaryDetail','PUT','','','',2276,'p'),(NULL,'888','/backend/sysDictionaryDetail/createSysDictionaryDetail','POST','','','',2277,'p'),(NULL,'888','/backend/sysDictionaryDetail/deleteSysDictionaryDetail','DELETE','','','',2278,'p'),(NULL,'888','/backend/sysDictionaryDetail/findSysDictionaryDetail','GET','','','',2279,'p'),(NULL,'888','/backend/sysDictionaryDetail/getSysDictionaryDetailList','GET','','','',2280,'p'),(NULL,'888','/backend/sysDictionary/createSysDictionary','POST','','','',2281,'p'),(NULL,'888','/backend/sysDictionary/deleteSysDictionary','DELETE','','','',2282,'p'),(NULL,'888','/backend/sysDictionary/updateSysDictionary','PUT','','','',2283,'p'),(NULL,'888','/backend/sysDictionary/findSysDictionary','GET','','','',2284,'p'),(NULL,'888','/backend/sysDictionary/getSysDictionaryList','GET','','','',2285,'p'),(NULL,'888','/backend/sysOperationRecord/createSysOperationRecord','POST','','','',2286,'p'),(NULL,'888','/backend/sysOperationRecord/findSysOperationRecord','GET','','','',2287,'p'),(NULL,'888','/backend/sysOperationRecord/getSysOperationRecordList','GET','','','',2288,'p'),(NULL,'888','/backend/sysOperationRecord/deleteSysOperationRecord','DELETE','','','',2289,'p'),(NULL,'888','/backend/sysOperationRecord/deleteSysOperationRecordByIds','DELETE','','','',2290,'p'),(NULL,'888','/backend/simpleUploader/upload','POST','','','',2291,'p'),(NULL,'888','/backend/simpleUploader/checkFileMd5','GET','','','',2292,'p'),(NULL,'888','/backend/simpleUploader/mergeFileMd5','GET','','','',2293,'p'),(NULL,'888','/backend/email/emailTest','POST','','','',2294,'p'),(NULL,'888','/backend/email/emailSend','POST','','','',2295,'p'),(NULL,'888','/backend/excel/importExcel','POST','','','',2296,'p'),(NULL,'888','/backend/excel/loadExcel','GET','','','',2297,'p'),(NULL,'888','/backend/excel/exportExcel','POST','','','',2298,'p'),(NULL,'888','/backend/excel/downloadTemplate','GET','','','',2299,'p'),(NULL,'888','/backend/excel/getFileInfoList','GET','','','',2300,'p'),(NULL,'888','/backend/authorityBtn/setAuthorityBtn','POST','','','',2301,'p'),(NULL,'888','/backend/authorityBtn/getAuthorityBtn','GET','','','',2302,'p'),(NULL,'888','/backend/authorityBtn/canRemoveAuthorityBtn/:id','DELETE','','','',2303,'p'),(NULL,'888','/backend/tag/createTag','POST','','','',2304,'p'),(NULL,'888','/backend/tag/deleteTag/:id','DELETE','','','',2305,'p'),(NULL,'888','/backend/tag/deleteTagByIds','DELETE','','','',2306,'p'),(NULL,'888','/backend/tag/updateTag','PUT','','','',2307,'p'),(NULL,'888','/backend/tag/findTag/:id','GET','','','',2308,'p'),(NULL,'888','/backend/tag/getTagList','GET','','','',2309,'p'),(NULL,'888','/backend/article/createArticle','POST','','','',2310,'p'),(NULL,'888','/backend/article/deleteArticle/:id','DELETE','','','',2311,'p'),(NULL,'888','/backend/article/deleteArticleByIds','DELETE','','','',2312,'p'),(NULL,'888','/backend/article/updateArticle/:id','PUT','','','',2313,'p'),(NULL,'888','/backend/article/findArticle/:id','GET','','','',2314,'p'),(NULL,'888','/backend/article/getArticleList','GET','','','',2315,'p'),(NULL,'888','/backend/article/putArticleByIds','PUT','','','',2316,'p'),(NULL,'888','/backend/article/getArticleReading','GET','','','',2317,'p'),(NULL,'888','/backend/comment/createComment','POST','','','',2318,'p'),(NULL,'888','/backend/comment/deleteComment/:id','DELETE','','','',2319,'p'),(NULL,'888','/backend/comment/deleteCommentByIds','DELETE','','','',2320,'p'),(NULL,'888','/backend/comment/updateComment/:id','PUT','','','',2321,'p'),(NULL,'888','/backend/comment/getComment/:id','GET','','','',2322,'p'),(NULL,'888','/backend/comment/getCommentList','GET','','','',2323,'p'),(NULL,'888','/backend/comment/getCommentTreeList','GET','','','',2324,'p'),(NULL,'888','/backend/comment/pariseComment','PUT','','','',2325,'p'),(NULL,'888','/backend/base_message/getBaseMessage/:id','GET','','','',2326,'p'),(NULL,'888','/backend/base_message/createBaseMessage','POST','','','',2327,'p'),(NULL,'888','/backend/base_message/updateBaseMessage','PUT','','','',2328,'p'),(NULL,'888','/backend/base_message/upload_file','POST','','','',2329,'p'),(NULL,'888','/backend/problem/getIsSetting/:id','GET','','','',2330,'p'),(NULL,'888','/backend/problem/updateProblem','PUT','','','',2331,'p'),(NULL,'888','/backend/problem/getProblemList/:id','GET','','','',2332,'p'),(NULL,'888','/backend/problem/verifyAnswer','POST','','','',2333,'p'),(NULL,'888','/backend/tasking/start','GET','','','',2334,'p'),(NULL,'888','/backend/user/getFlow','GET','','','',2335,'p'),(NULL,'888','/backend/frontend-user/createUser','POST','','','',2336,'p'),(NULL,'888','/backend/frontend-user/findUser/:id','GET','','','',2337,'p'),(NULL,'888','/backend/frontend-user/deleteUser/:id','DELETE','','','',2338,'p'),(NULL,'888','/backend/frontend-user/deleteUserByIds','DELETE','','','',2339,'p'),(NULL,'888','/backend/frontend-user/getUserList','GET','','','',2340,'p'),(NULL,'888','/backend/frontend-user/updateUser/:id','PUT','','','',2341,'p'),(NULL,'888','/backend/system/reloadSystem','POST','','','',2342,'p'),(NULL,'888','/backend/github/createGithub','GET','','','',2343,'p'),(NULL,'888','/backend/github/getGithubList','GET','','','',2344,'p'),(NULL,'888','/moblieUser/createMoblieUser','POST','','','',2345,'p'),(NULL,'888','/moblieUser/deleteMoblieUser','DELETE','','','',2346,'p'),(NULL,'888','/moblieUser/deleteMoblieUserByIds','DELETE','','','',2347,'p'),(NULL,'888','/moblieUser/updateMoblieUser','PUT','','','',2348,'p'),(NULL,'888','/moblieUser/findMoblieUser','GET','','','',2349,'p'),(NULL,'888','/moblieUser/getMoblieUserList','GET','','','',2350,'p'),(NULL,'888','/backend/mobile/createMobileUser','POST','','','',2351,'p'),(NULL,'888','/backend/mobile/deleteMobileUser/:id','DELETE','','','',2352,'p'),(NULL,'888','/backend/mobile/deleteMobileUserByIds','DELETE','','','',2353,'p'),(NULL,'888','/backend/mobile/updateMobileUser/:id','PUT','','','',2354,'p'),(NULL,'888','/backend/mobile/findMobileUser/:id','GET','','','',2355,'p'),(NULL,'888','/backend/mobile/getMobileUserList','GET','','','',2356,'p'),(NULL,'888','/backend/api/getAllApisList','POST','','','',2357,'p') ON DUPLICATE KEY UPDATE `p_type` = VALUES(`p_type`),`v0` = VALUES(`v0`),`v1` = VALUES(`v1`),`v2` = VALUES(`v2`),`v3` = VALUES(`v3`),`v4` = VALUES(`v4`),`v5` = VALUES(`v5`),`id` = VALUES(`id`),`ptype` = VALUES(`ptype`);
+INSERT INTO `server-fiber`.`casbin_rule` (`p_type`,`v0`,`v1`,`v2`,`v3`,`v4`,`v5`,`id`,`ptype`) VALUES ('p','998','/backend/tag/findTag/:id','GET','','','',1,'p'),('p','998','/backend/tag/getTagList','GET','','','',2,'p'),('p','9528','/backend/backend/user/setUserAuthority','POST','','','',3,'p'),('p','9528','/backend/base/login','POST','','','',4,'p'),('p','998','/backend/article/createArticle','POST','','','',5,'p'),('p','9528','/backend/jwt/jsonInBlacklist','POST','','','',6,'p'),('p','998','/backend/article/deleteArticle/:id','DELETE','','','',7,'p'),('p','9528','/backend/user/getUserInfo','GET','','','',8,'p'),('p','998','/backend/article/deleteArticleByIds','DELETE','','','',9,'p'),('p','9528','/backend/user/getUserCount','GET','','','',10,'p'),('p','998','/backend/article/updateArticle/:id','PUT','','','',11,'p'),('p','9528','/backend/menu/getMenu','GET','','','',12,'p'),('p','998','/backend/article/findArticle/:id','GET','','','',13,'p'),('p','8881','/backend/base/login','POST','','','',14,'p'),('p','8881','/backend/jwt/jsonInBlacklist','POST','','','',15,'p'),('p','8881','/backend/user/setSelfInfo','PUT','','','',16,'p'),('p','8881','/backend/user/getUserInfo','GET','','','',17,'p'),('p','8881','/backend/user/changePassword','POST','','','',18,'p'),('p','8881','/backend/user/setUserAuthority','POST','','','',19,'p'),('p','66','/backend/base/login','POST','','','',20,'p'),('p','66','/backend/fileUploadAndDownload/findFile','GET','','','',21,'p'),('p','66','/backend/fileUploadAndDownload/breakpointContinue','POST','','','',22,'p'),('p','66','/backend/fileUploadAndDownload/breakpointContinueFinish','POST','','','',23,'p'),('p','66','/backend/fileUploadAndDownload/removeChunk','POST','','','',24,'p'),('p','66','/backend/tag/createTag','POST','','','',25,'p'),('p','66','/backend/tag/deleteTag/:id','DELETE','','','',26,'p'),('p','66','/backend/tag/deleteTagByIds','DELETE','','','',27,'p'),('p','66','/backend/tag/updateTag','PUT','','','',28,'p'),('p','66','/backend/tag/findTag/:id','GET','','','',29,'p'),('p','66','/backend/tag/getTagList','GET','','','',30,'p'),('p','66','/backend/article/createArticle','POST','','','',31,'p'),('p','66','/backend/article/deleteArticle/:id','DELETE','','','',32,'p'),('p','66','/backend/article/deleteArticleByIds','DELETE','','','',33,'p'),('p','66','/backend/article/updateArticle/:id','PUT','','','',34,'p'),('p','66','/backend/article/findArticle/:id','GET','','','',35,'p'),('p','66','/backend/article/getArticleList','GET','','','',36,'p'),('p','66','/backend/article/putArticleByIds','PUT','','','',37,'p'),('p','66','/backend/article/getArticleReading','GET','','','',38,'p'),('p','66','/backend/comment/createComment','POST','','','',39,'p'),('p','66','/backend/comment/deleteComment','DELETE','','','',40,'p'),('p','66','/backend/comment/deleteCommentByIds','DELETE','','','',41,'p'),('p','66','/backend/comment/updateComment','PUT','','','',42,'p'),('p','66','/backend/comment/findComment','GET','','','',43,'p'),('p','66','/backend/comment/getCommentList','GET','','','',44,'p'),('p','66','/backend/comment/getCommentTreeList','GET','','','',45,'p'),('p','998','/backend/article/getArticleList','GET','','','',46,'p'),('p','66','/backend/comment/pariseComment','PUT','','','',47,'p'),('p','66','/backend/base_message/getBaseMessage/:id','GET','','','',48,'p'),('p','998','/backend/comment/createComment','POST','','','',49,'p'),('p','66','/backend/base_message/createBaseMessage','POST','','','',50,'p'),('p','998','/backend/comment/deleteComment','DELETE','','','',51,'p'),('p','66','/backend/base_message/updateBaseMessage/:id','PUT','','','',52,'p'),('p','66','/backend/base_message/upload_file','POST','','','',53,'p'),('p','66','/backend/github/createGithub','POST','','','',54,'p'),('p','66','/backend/github/getGithubList','GET','','','',55,'p'),('p','998','/backend/base/login','POST','','','',56,'p'),('p','998','/backend/comment/deleteCommentByIds','DELETE','','','',57,'p'),('p','998','/backend/jwt/jsonInBlacklist','POST','','','',58,'p'),('p','998','/backend/comment/updateComment','PUT','','','',59,'p'),('p','998','/backend/user/deleteUser','DELETE','','','',60,'p'),('p','998','/backend/comment/findComment','GET','','','',61,'p'),('p','998','/backend/user/admin_register','POST','','','',62,'p'),('p','998','/backend/comment/getCommentList','GET','','','',63,'p'),('p','998','/backend/base_message/createBaseMessage','POST','','','',64,'p'),('p','998','/backend/base_message/updateBaseMessage/:id','PUT','','','',65,'p'),('p','998','/backend/user/getUserList','GET','','','',69,'p'),('p','998','/backend/user/setUserInfo','PUT','','','',71,'p'),('p','998','/backend/user/setSelfInfo','PUT','','','',73,'p'),('p','998','/backend/user/getUserInfo','GET','','','',75,'p'),('p','998','/backend/user/setUserAuthorities','POST','','','',77,'p'),('p','998','/backend/user/changePassword','POST','','','',79,'p'),('p','998','/backend/user/setUserAuthority','POST','','','',80,'p'),('p','998','/backend/user/resetPassword','POST','','','',82,'p'),('p','998','/backend/user/getUserCount','GET','','','',83,'p'),('p','998','/backend/api/createApi','POST','','','',88,'p'),('p','998','/backend/api/deleteApi/:id','DELETE','','','',90,'p'),('p','998','/backend/api/updateApi/:id','PUT','','','',92,'p'),('p','998','/backend/api/getApiList','GET','','','',94,'p'),('p','998','/backend/api/getAllApis','GET','','','',96,'p'),('p','998','/backend/api/getApiById/:id','GET','','','',97,'p'),('p','998','/backend/api/deleteApisByIds','DELETE','','','',98,'p'),('p','998','/backend/api/getAllApisList','GET','','','',99,'p'),('p','998','/backend/authority/copyAuthority','POST','','','',100,'p'),('p','998','/backend/authority/createAuthority','POST','','','',101,'p'),('p','998','/backend/authority/deleteAuthority','DELETE','','','',102,'p'),('p','998','/backend/authority/updateAuthority','PUT','','','',103,'p'),('p','998','/backend/authority/getAuthorityList','GET','','','',104,'p'),('p','998','/backend/authority/setDataAuthority','POST','','','',105,'p'),('p','998','/backend/casbin/updateCasbin','POST','','','',106,'p'),('p','998','/backend/casbin/getPolicyPathByAuthorityId/:id','GET','','','',107,'p'),('p','998','/backend/menu/addBaseMenu','POST','','','',108,'p'),('p','998','/backend/menu/getMenu','GET','','','',109,'p'),('p','998','/backend/menu/deleteBaseMenu/:id','DELETE','','','',110,'p'),('p','998','/backend/menu/updateBaseMenu','PUT','','','',111,'p'),('p','998','/backend/menu/getBaseMenuById/:id','GET','','','',112,'p'),('p','998','/backend/menu/getMenuList','GET','','','',113,'p'),('p','998','/backend/menu/getBaseMenuTree','GET','','','',114,'p'),('p','998','/backend/menu/getMenuAuthority','GET','','','',115,'p'),('p','998','/backend/menu/addMenuAuthority','POST','','','',116,'p'),('p','998','/backend/sysDictionary/createSysDictionary','POST','','','',117,'p'),('p','998','/backend/sysDictionary/deleteSysDictionary','DELETE','','','',118,'p'),('p','998','/backend/sysDictionary/updateSysDictionary','PUT','','','',119,'p'),('p','998','/backend/sysDictionary/findSysDictionary','GET','','','',120,'p'),('p','998','/backend/sysDictionary/getSysDictionaryList','GET','','','',121,'p'),('p','998','/backend/sysOperationRecord/createSysOperationRecord','POST','','','',122,'p'),('p','998','/backend/sysOperationRecord/findSysOperationRecord','GET','','','',123,'p'),('p','998','/backend/sysOperationRecord/getSysOperationRecordList','GET','','','',124,'p'),('p','998','/backend/sysOperationRecord/deleteSysOperationRecord','DELETE','','','',125,'p'),('p','998','/backend/sysOperationRecord/deleteSysOperationRecordByIds','DELETE','','','',126,'p'),('p','998','/backend/simpleUploader/upload','POST','','','',127,'p'),('p','998','/backend/simpleUploader/checkFileMd5','GET','','','',128,'p'),('p','998','/backend/simpleUploader/mergeFileMd5','GET','','','',129,'p'),('p','998','/backend/email/emailTest','POST','','','',130,'p'),('p','998','/backend/email/emailSend','POST','','','',131,'p'),('p','998','/backend/authorityBtn/setAuthorityBtn','POST','','','',132,'p'),('p','998','/backend/authorityBtn/getAuthorityBtn','GET','','','',137,'p'),('p','998','/backend/authorityBtn/canRemoveAuthorityBtn/:id','DELETE','','','',138,'p'),('p','998','/backend/tag/createTag','POST','','','',140,'p'),('p','998','/backend/tag/deleteTag/:id','DELETE','','','',142,'p'),('p','998','/backend/tag/deleteTagByIds','DELETE','','','',144,'p'),('p','998','/backend/tag/updateTag','PUT','','','',146,'p'),('p','9528','/backend/user/getFlow','GET','','','',1903,'p'),('p','998','/backend/user/getFlow','GET','','','',1904,'p'),('p','66','/backend/user/getFlow','GET','','','',1905,'p'),('p','8881','/backend/menu/getMenu','GET','','','',1906,'p'),('p','66','/backend/menu/getMenu','GET','','','',1907,'p'),(NULL,'888','/backend/base/login','POST','','','',2208,'p'),(NULL,'888','/backend/jwt/jsonInBlacklist','POST','','','',2209,'p'),(NULL,'888','/backend/user/deleteUser','DELETE','','','',2210,'p'),(NULL,'888','/backend/user/admin_register','POST','','','',2211,'p'),(NULL,'888','/backend/user/getUserList','GET','','','',2212,'p'),(NULL,'888','/backend/user/setUserInfo','PUT','','','',2213,'p'),(NULL,'888','/backend/user/setSelfInfo','PUT','','','',2214,'p'),(NULL,'888','/backend/user/getUserInfo','GET','','','',2215,'p'),(NULL,'888','/backend/user/setUserAuthorities','POST','','','',2216,'p'),(NULL,'888','/backend/user/changePassword','POST','','','',2217,'p'),(NULL,'888','/backend/user/setUserAuthority','POST','','','',2218,'p'),(NULL,'888','/backend/user/resetPassword','POST','','','',2219,'p'),(NULL,'888','/backend/user/getUserCount','GET','','','',2220,'p'),(NULL,'888','/backend/api/createApi','POST','','','',2221,'p'),(NULL,'888','/backend/api/deleteApi/:id','DELETE','','','',2222,'p'),(NULL,'888','/backend/api/updateApi/:id','PUT','','','',2223,'p'),(NULL,'888','/backend/api/getApiList','GET','','','',2224,'p'),(NULL,'888','/backend/api/getAllApis','GET','','','',2225,'p'),(NULL,'888','/backend/api/getApiById/:id','GET','','','',2226,'p'),(NULL,'888','/backend/api/deleteApisByIds','DELETE','','','',2227,'p'),(NULL,'888','/backend/api/getAllApisList','GET','','','',2228,'p'),(NULL,'888','/backend/authority/copyAuthority','POST','','','',2229,'p'),(NULL,'888','/backend/authority/createAuthority','POST','','','',2230,'p'),(NULL,'888','/backend/authority/deleteAuthority','DELETE','','','',2231,'p'),(NULL,'888','/backend/authority/updateAuthority','PUT','','','',2232,'p'),(NULL,'888','/backend/authority/getAuthorityList','GET','','','',2233,'p'),(NULL,'888','/backend/authority/setDataAuthority','POST','','','',2234,'p'),(NULL,'888','/backend/casbin/updateCasbin','POST','','','',2235,'p'),(NULL,'888','/backend/casbin/getPolicyPathByAuthorityId/:id','GET','','','',2236,'p'),(NULL,'888','/backend/menu/addBaseMenu','POST','','','',2237,'p'),(NULL,'888','/backend/menu/getMenu','GET','','','',2238,'p'),(NULL,'888','/backend/menu/deleteBaseMenu/:id','DELETE','','','',2239,'p'),(NULL,'888','/backend/menu/updateBaseMenu','PUT','','','',2240,'p'),(NULL,'888','/backend/menu/getBaseMenuById/:id','GET','','','',2241,'p'),(NULL,'888','/backend/menu/getMenuList','GET','','','',2242,'p'),(NULL,'888','/backend/menu/getBaseMenuTree','GET','','','',2243,'p'),(NULL,'888','/backend/menu/getMenuAuthority','GET','','','',2244,'p'),(NULL,'888','/backend/menu/addMenuAuthority','POST','','','',2245,'p'),(NULL,'888','/backend/fileUploadAndDownload/findFile','GET','','','',2246,'p'),(NULL,'888','/backend/fileUploadAndDownload/breakpointContinue','POST','','','',2247,'p'),(NULL,'888','/backend/fileUploadAndDownload/breakpointContinueFinish','POST','','','',2248,'p'),(NULL,'888','/backend/fileUploadAndDownload/removeChunk','POST','','','',2249,'p'),(NULL,'888',' /backend/fileUploadAndDownload/deleteFileBreakpoint/:id','DELETE','','','',2250,'p'),(NULL,'888','/backend/fileUploadAndDownload/getFileBreakpoint','GET','','','',2251,'p'),(NULL,'888','/backend/fileUploadAndDownload/upload','POST','','','',2252,'p'),(NULL,'888','/backend/fileUploadAndDownload/deleteFile/:id','DELETE','','','',2253,'p'),(NULL,'888','/backend/fileUploadAndDownload/editFileName','PUT','','','',2254,'p'),(NULL,'888','/backend/fileUploadAndDownload/getFileList','GET','','','',2255,'p'),(NULL,'888','/backend/system/getServerInfo','GET','','','',2256,'p'),(NULL,'888','/backend/system/getSystemConfig','GET','','','',2257,'p'),(NULL,'888','/backend/system/setSystemConfig','PUT','','','',2258,'p'),(NULL,'888','/backend/customer/customer/:id','PUT','','','',2259,'p'),(NULL,'888','/backend/customer/customer','POST','','','',2260,'p'),(NULL,'888','/backend/customer/customer/:id','DELETE','','','',2261,'p'),(NULL,'888','/backend/customer/customer/:id','GET','','','',2262,'p'),(NULL,'888','/backend/customer/customerList','GET','','','',2263,'p'),(NULL,'888','/backend/autoCode/getDB','GET','','','',2264,'p'),(NULL,'888','/backend/autoCode/getTables','GET','','','',2265,'p'),(NULL,'888','/backend/autoCode/createTemp','POST','','','',2266,'p'),(NULL,'888','/backend/autoCode/preview','POST','','','',2267,'p'),(NULL,'888','/backend/autoCode/getColumn','GET','','','',2268,'p'),(NULL,'888','/backend/autoCode/createPackage','POST','','','',2269,'p'),(NULL,'888','/backend/autoCode/getPackage','POST','','','',2270,'p'),(NULL,'888','/backend/autoCode/delPackage','POST','','','',2271,'p'),(NULL,'888','/backend/autoCode/getMeta','POST','','','',2272,'p'),(NULL,'888','/backend/autoCode/rollback','POST','','','',2273,'p'),(NULL,'888','/backend/autoCode/getSysHistory','POST','','','',2274,'p'),(NULL,'888','/backend/autoCode/delSysHistory','POST','','','',2275,'p'),(NULL,'888','/backend/sysDictionaryDetail/updateSysDictionaryDetail','PUT','','','',2276,'p'),(NULL,'888','/backend/sysDictionaryDetail/createSysDictionaryDetail','POST','','','',2277,'p'),(NULL,'888','/backend/sysDictionaryDetail/deleteSysDictionaryDetail','DELETE','','','',2278,'p'),(NULL,'888','/backend/sysDictionaryDetail/findSysDictionaryDetail','GET','','','',2279,'p'),(NULL,'888','/backend/sysDictionaryDetail/getSysDictionaryDetailList','GET','','','',2280,'p'),(NULL,'888','/backend/sysDictionary/createSysDictionary','POST','','','',2281,'p'),(NULL,'888','/backend/sysDictionary/deleteSysDictionary','DELETE','','','',2282,'p'),(NULL,'888','/backend/sysDictionary/updateSysDictionary','PUT','','','',2283,'p'),(NULL,'888','/backend/sysDictionary/findSysDictionary','GET','','','',2284,'p'),(NULL,'888','/backend/sysDictionary/getSysDictionaryList','GET','','','',2285,'p'),(NULL,'888','/backend/sysOperationRecord/createSysOperationRecord','POST','','','',2286,'p'),(NULL,'888','/backend/sysOperationRecord/findSysOperationRecord','GET','','','',2287,'p'),(NULL,'888','/backend/sysOperationRecord/getSysOperationRecordList','GET','','','',2288,'p'),(NULL,'888','/backend/sysOperationRecord/deleteSysOperationRecord','DELETE','','','',2289,'p'),(NULL,'888','/backend/sysOperationRecord/deleteSysOperationRecordByIds','DELETE','','','',2290,'p'),(NULL,'888','/backend/simpleUploader/upload','POST','','','',2291,'p'),(NULL,'888','/backend/simpleUploader/checkFileMd5','GET','','','',2292,'p'),(NULL,'888','/backend/simpleUploader/mergeFileMd5','GET','','','',2293,'p'),(NULL,'888','/backend/email/emailTest','POST','','','',2294,'p'),(NULL,'888','/backend/email/emailSend','POST','','','',2295,'p'),(NULL,'888','/backend/excel/importExcel','POST','','','',2296,'p'),(NULL,'888','/backend/excel/loadExcel','GET','','','',2297,'p'),(NULL,'888','/backend/excel/exportExcel','POST','','','',2298,'p'),(NULL,'888','/backend/excel/downloadTemplate','GET','','','',2299,'p'),(NULL,'888','/backend/excel/getFileInfoList','GET','','','',2300,'p'),(NULL,'888','/backend/authorityBtn/setAuthorityBtn','POST','','','',2301,'p'),(NULL,'888','/backend/authorityBtn/getAuthorityBtn','GET','','','',2302,'p'),(NULL,'888','/backend/authorityBtn/canRemoveAuthorityBtn/:id','DELETE','','','',2303,'p'),(NULL,'888','/backend/tag/createTag','POST','','','',2304,'p'),(NULL,'888','/backend/tag/deleteTag/:id','DELETE','','','',2305,'p'),(NULL,'888','/backend/tag/deleteTagByIds','DELETE','','','',2306,'p'),(NULL,'888','/backend/tag/updateTag','PUT','','','',2307,'p'),(NULL,'888','/backend/tag/findTag/:id','GET','','','',2308,'p'),(NULL,'888','/backend/tag/getTagList','GET','','','',2309,'p'),(NULL,'888','/backend/article/createArticle','POST','','','',2310,'p'),(NULL,'888','/backend/article/deleteArticle/:id','DELETE','','','',2311,'p'),(NULL,'888','/backend/article/deleteArticleByIds','DELETE','','','',2312,'p'),(NULL,'888','/backend/article/updateArticle/:id','PUT','','','',2313,'p'),(NULL,'888','/backend/article/findArticle/:id','GET','','','',2314,'p'),(NULL,'888','/backend/article/getArticleList','GET','','','',2315,'p'),(NULL,'888','/backend/article/putArticleByIds','PUT','','','',2316,'p'),(NULL,'888','/backend/article/getArticleReading','GET','','','',2317,'p'),(NULL,'888','/backend/comment/createComment','POST','','','',2318,'p'),(NULL,'888','/backend/comment/deleteComment/:id','DELETE','','','',2319,'p'),(NULL,'888','/backend/comment/deleteCommentByIds','DELETE','','','',2320,'p'),(NULL,'888','/backend/comment/updateComment/:id','PUT','','','',2321,'p'),(NULL,'888','/backend/comment/getComment/:id','GET','','','',2322,'p'),(NULL,'888','/backend/comment/getCommentList','GET','','','',2323,'p'),(NULL,'888','/backend/comment/getCommentTreeList','GET','','','',2324,'p'),(NULL,'888','/backend/comment/pariseComment','PUT','','','',2325,'p'),(NULL,'888','/backend/base_message/getBaseMessage/:id','GET','','','',2326,'p'),(NULL,'888','/backend/base_message/createBaseMessage','POST','','','',2327,'p'),(NULL,'888','/backend/base_message/updateBaseMessage/:id','PUT','','','',2328,'p'),(NULL,'888','/backend/base_message/upload_file','POST','','','',2329,'p'),(NULL,'888','/backend/problem/getIsSetting/:id','GET','','','',2330,'p'),(NULL,'888','/backend/problem/updateProblem','PUT','','','',2331,'p'),(NULL,'888','/backend/problem/getProblemList/:id','GET','','','',2332,'p'),(NULL,'888','/backend/problem/verifyAnswer','POST','','','',2333,'p'),(NULL,'888','/backend/tasking/start','GET','','','',2334,'p'),(NULL,'888','/backend/user/getFlow','GET','','','',2335,'p'),(NULL,'888','/backend/frontend-user/createUser','POST','','','',2336,'p'),(NULL,'888','/backend/frontend-user/findUser/:id','GET','','','',2337,'p'),(NULL,'888','/backend/frontend-user/deleteUser/:id','DELETE','','','',2338,'p'),(NULL,'888','/backend/frontend-user/deleteUserByIds','DELETE','','','',2339,'p'),(NULL,'888','/backend/frontend-user/getUserList','GET','','','',2340,'p'),(NULL,'888','/backend/frontend-user/updateUser/:id','PUT','','','',2341,'p'),(NULL,'888','/backend/system/reloadSystem','POST','','','',2342,'p'),(NULL,'888','/backend/github/createGithub','GET','','','',2343,'p'),(NULL,'888','/backend/github/getGithubList','GET','','','',2344,'p'),(NULL,'888','/moblieUser/createMoblieUser','POST','','','',2345,'p'),(NULL,'888','/moblieUser/deleteMoblieUser','DELETE','','','',2346,'p'),(NULL,'888','/moblieUser/deleteMoblieUserByIds','DELETE','','','',2347,'p'),(NULL,'888','/moblieUser/updateMoblieUser','PUT','','','',2348,'p'),(NULL,'888','/moblieUser/findMoblieUser','GET','','','',2349,'p'),(NULL,'888','/moblieUser/getMoblieUserList','GET','','','',2350,'p'),(NULL,'888','/backend/mobile/createMobileUser','POST','','','',2351,'p'),(NULL,'888','/backend/mobile/deleteMobileUser/:id','DELETE','','','',2352,'p'),(NULL,'888','/backend/mobile/deleteMobileUserByIds','DELETE','','','',2353,'p'),(NULL,'888','/backend/mobile/updateMobileUser/:id','PUT','','','',2354,'p'),(NULL,'888','/backend/mobile/findMobileUser/:id','GET','','','',2355,'p'),(NULL,'888','/backend/mobile/getMobileUserList','GET','','','',2356,'p'),(NULL,'888','/backend/api/getAllApisList','POST','','','',2357,'p') ON DUPLICATE KEY UPDATE `p_type` = VALUES(`p_type`),`v0` = VALUES(`v0`),`v1` = VALUES(`v1`),`v2` = VALUES(`v2`),`v3` = VALUES(`v3`),`v4` = VALUES(`v4`),`v5` = VALUES(`v5`),`id` = VALUES(`id`),`ptype` = VALUES(`ptype`);
 -- server-fiber.comments DML
 INSERT INTO `server-fiber`.`comments` (`id`,`created_at`,`updated_at`,`deleted_at`,`post_id`,`article_id`,`parent_id`,`content`,`user_id`,`to_user_id`) VALUES (1,'2026-03-13 03:11:04.369','2026-03-13 03:11:04.369','2026-05-18 14:34:17.322',NULL,5,0,'test',3,0),(2,'2026-03-13 03:11:17.281','2026-03-13 03:11:17.281','2026-05-18 14:34:17.322',NULL,5,0,'test抱抱抱抱',3,0),(3,'2026-05-18 14:42:04.662','2026-05-18 14:42:04.662',NULL,NULL,5,0,'test',3,0),(4,'2026-05-18 15:21:42.955','2026-05-18 15:21:42.955',NULL,NULL,5,0,'柔柔弱弱',3,0),(5,'2026-05-18 18:01:22.008','2026-05-18 18:01:22.008',NULL,NULL,19,0,'test',5,0),(6,'2026-05-18 18:01:27.170','2026-05-18 18:01:27.170',NULL,NULL,19,5,'test',5,5),(7,'2026-05-18 18:01:38.738','2026-05-18 18:01:38.738',NULL,NULL,19,6,'testyyyy',5,5),(8,'2026-05-18 18:01:50.195','2026-05-18 18:01:50.195',NULL,NULL,19,5,'testyyyy',5,5),(9,'2026-05-18 18:01:59.500','2026-05-18 18:01:59.500',NULL,NULL,19,8,'test',5,5),(10,'2026-05-18 18:02:09.533','2026-05-18 18:02:09.533',NULL,NULL,19,8,'test',5,5),(11,'2026-05-19 08:26:15.893','2026-05-19 08:26:15.893',NULL,NULL,19,8,'test',5,5),(12,'2026-05-19 10:01:30.276','2026-05-19 10:01:30.276',NULL,NULL,5,0,'[吓][奋斗]',5,0),(13,'2026-05-19 10:01:40.691','2026-05-19 10:01:40.691',NULL,NULL,5,0,'[脸红][大哭]',5,0),(14,'2026-05-19 10:03:11.060','2026-05-19 10:03:11.060',NULL,NULL,5,0,'[tv_闭嘴]',5,0),(15,'2026-05-19 10:09:15.403','2026-05-19 10:09:15.403',NULL,NULL,19,6,'[惊喜]',5,5),(16,'2026-05-19 10:10:11.701','2026-05-19 10:10:11.701',NULL,NULL,5,14,'[tv_大哭]',5,5),(17,'2026-05-19 10:12:43.114','2026-05-19 10:12:43.114',NULL,NULL,5,14,'[tv_打脸]',5,5),(18,'2026-05-19 10:39:47.248','2026-05-19 10:39:47.248',NULL,NULL,9,0,'[惊喜]',5,0),(19,'2026-05-19 10:40:38.554','2026-05-19 10:40:38.554',NULL,NULL,9,18,'[辣眼睛]',5,5),(20,'2026-05-19 10:47:22.995','2026-05-19 10:47:22.995',NULL,NULL,9,18,'[打call]',5,5) ON DUPLICATE KEY UPDATE `id` = VALUES(`id`),`created_at` = VALUES(`created_at`),`updated_at` = VALUES(`updated_at`),`deleted_at` = VALUES(`deleted_at`),`post_id` = VALUES(`post_id`),`article_id` = VALUES(`article_id`),`parent_id` = VALUES(`parent_id`),`content` = VALUES(`content`),`user_id` = VALUES(`user_id`),`to_user_id` = VALUES(`to_user_id`);
 -- server-fiber.comments_bak DML
@@ -561,7 +561,7 @@ INSERT INTO `server-fiber`.`mobile_users` (`id`,`created_at`,`updated_at`,`delet
 -- server-fiber.praise DML
 INSERT INTO `server-fiber`.`praise` (`comment_id`,`user_id`,`id`,`created_at`,`updated_at`,`deleted_at`) VALUES (1,1,1,NULL,NULL,NULL),(1,3,2,NULL,NULL,NULL),(2,1,3,NULL,NULL,NULL),(2,3,4,NULL,NULL,NULL),(6,1,5,NULL,NULL,NULL),(6,3,6,NULL,NULL,NULL),(7,1,7,NULL,NULL,NULL),(7,3,8,NULL,NULL,NULL),(10,1,9,NULL,NULL,NULL),(10,3,10,NULL,NULL,NULL),(11,1,11,NULL,NULL,NULL),(11,3,12,NULL,NULL,NULL),(4,5,13,'2026-05-19 09:55:38.243','2026-05-19 09:55:48.445',NULL),(3,5,14,'2026-05-19 09:55:47.301','2026-05-19 09:55:47.301',NULL),(6,5,15,'2026-05-19 09:55:51.197','2026-05-19 09:55:52.429',NULL),(7,5,16,'2026-05-19 09:55:53.294','2026-05-19 09:55:53.294',NULL),(10,5,17,'2026-05-19 09:56:21.215','2026-05-19 09:56:21.215','2026-05-19 09:56:21.671'),(18,5,18,'2026-05-19 10:40:01.583','2026-05-19 10:40:01.583',NULL),(19,5,19,'2026-05-19 10:41:01.909','2026-05-19 10:41:04.539',NULL),(20,5,20,'2026-05-19 10:47:29.761','2026-05-19 10:47:29.761',NULL) ON DUPLICATE KEY UPDATE `comment_id` = VALUES(`comment_id`),`user_id` = VALUES(`user_id`),`id` = VALUES(`id`),`created_at` = VALUES(`created_at`),`updated_at` = VALUES(`updated_at`),`deleted_at` = VALUES(`deleted_at`);
 -- server-fiber.sys_apis DML
-INSERT INTO `server-fiber`.`sys_apis` (`id`,`created_at`,`updated_at`,`deleted_at`,`path`,`description`,`api_group`,`method`) VALUES (1,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/base/login','用户登录(必选)','base','POST'),(2,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/jwt/jsonInBlacklist','jwt加入黑名单(退出，必选)','jwt','POST'),(3,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/user/deleteUser','删除用户','系统用户','DELETE'),(4,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/user/admin_register','用户注册','系统用户','POST'),(5,'2022-04-15 17:31:14.854','2022-11-08 11:47:02.250',NULL,'/backend/user/getUserList','获取用户列表','系统用户','GET'),(6,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/user/setUserInfo','设置用户信息','系统用户','PUT'),(7,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/user/setSelfInfo','设置自身信息(必选)','系统用户','PUT'),(8,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/user/getUserInfo','获取自身信息(必选)','系统用户','GET'),(9,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/user/setUserAuthorities','设置权限组','系统用户','POST'),(10,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/user/changePassword','修改密码（建议选择)','系统用户','POST'),(11,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/user/setUserAuthority','修改用户角色(必选)','系统用户','POST'),(12,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/user/resetPassword','重置用户密码','系统用户','POST'),(13,'2022-04-15 17:31:14.854','2022-11-08 11:46:00.241',NULL,'/backend/api/createApi','创建api','api','POST'),(14,'2022-04-15 17:31:14.854','2022-05-27 17:51:11.682',NULL,'/backend/api/deleteApi/:id','删除Api','api','DELETE'),(15,'2022-04-15 17:31:14.854','2022-12-26 15:06:01.545',NULL,'/backend/api/updateApi/:id','更新Api','api','PUT'),(16,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/api/getApiList','获取api列表','api','GET'),(17,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/api/getAllApis','获取所有api','api','GET'),(18,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/api/getApiById/:id','获取api详细信息','api','GET'),(19,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/api/deleteApisByIds','批量删除api','api','DELETE'),(20,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/authority/copyAuthority','拷贝角色','角色','POST'),(21,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/authority/createAuthority','创建角色','角色','POST'),(22,'2022-04-15 17:31:14.854','2023-01-12 14:10:12.284',NULL,'/backend/authority/deleteAuthority','删除角色','角色','DELETE'),(23,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/authority/updateAuthority','更新角色信息','角色','PUT'),(24,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/authority/getAuthorityList','获取角色列表','角色','GET'),(25,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/authority/setDataAuthority','设置角色资源权限','角色','POST'),(26,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/casbin/updateCasbin','更改角色api权限','casbin','POST'),(27,'2022-04-15 17:31:14.854','2022-11-22 08:37:50.114',NULL,'/backend/casbin/getPolicyPathByAuthorityId/:id','获取权限列表','casbin','GET'),(28,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/menu/addBaseMenu','新增菜单','菜单','POST'),(29,'2022-04-15 17:31:14.854','2022-10-14 15:41:03.128',NULL,'/backend/menu/getMenu','获取菜单树(必选)','菜单','GET'),(30,'2022-04-15 17:31:14.854','2022-11-08 12:04:46.417',NULL,'/backend/menu/deleteBaseMenu/:id','删除菜单','菜单','DELETE'),(31,'2022-04-15 17:31:14.854','2022-10-14 15:41:22.678',NULL,'/backend/menu/updateBaseMenu','更新菜单','菜单','PUT'),(32,'2022-04-15 17:31:14.854','2022-10-14 15:41:48.505',NULL,'/backend/menu/getBaseMenuById/:id','根据id获取菜单','菜单','GET'),(33,'2022-04-15 17:31:14.854','2022-11-08 11:50:07.775',NULL,'/backend/menu/getMenuList','分页获取基础menu列表','菜单','GET'),(34,'2022-04-15 17:31:14.854','2022-11-08 11:50:14.770',NULL,'/backend/menu/getBaseMenuTree','获取用户动态路由','菜单','GET'),(35,'2022-04-15 17:31:14.854','2022-11-08 11:50:24.567',NULL,'/backend/menu/getMenuAuthority','获取指定角色menu','菜单','GET'),(36,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/menu/addMenuAuthority','增加menu和角色关联关系','菜单','POST'),(37,'2022-04-15 17:31:14.854','2022-11-08 14:00:08.362',NULL,'/backend/fileUploadAndDownload/findFile','寻找目标文件（秒传）','分片上传','GET'),(38,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/fileUploadAndDownload/breakpointContinue','断点续传','分片上传','POST'),(39,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/fileUploadAndDownload/breakpointContinueFinish','断点续传完成','分片上传','POST'),(40,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/fileUploadAndDownload/removeChunk','上传完成移除文件','分片上传','POST'),(41,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/fileUploadAndDownload/upload','文件上传示例','文件上传与下载','POST'),(42,'2022-04-15 17:31:14.854','2022-11-08 13:51:20.334',NULL,'/backend/fileUploadAndDownload/deleteFile/:id','删除文件','文件上传与下载','DELETE'),(43,'2022-04-15 17:31:14.854','2022-11-08 13:49:02.690',NULL,'/backend/fileUploadAndDownload/editFileName','文件名或者备注编辑','文件上传与下载','PUT'),(44,'2022-04-15 17:31:14.854','2022-11-08 13:47:18.201',NULL,'/backend/fileUploadAndDownload/getFileList','获取上传文件列表','文件上传与下载','GET'),(45,'2022-04-15 17:31:14.854','2022-11-22 08:36:52.059',NULL,'/backend/system/getServerInfo','获取服务器信息','系统服务','GET'),(46,'2022-04-15 17:31:14.854','2022-11-22 08:37:12.745',NULL,'/backend/system/getSystemConfig','获取配置文件内容','系统服务','GET'),(47,'2022-04-15 17:31:14.854','2022-11-22 14:39:43.724',NULL,'/backend/system/setSystemConfig','设置配置文件内容','系统服务','PUT'),(48,'2022-04-15 17:31:14.854','2026-05-20 02:06:19.549',NULL,'/backend/customer/customer/:id','更新客户','客户','PUT'),(49,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/customer/customer','创建客户','客户','POST'),(50,'2022-04-15 17:31:14.854','2026-05-20 02:06:07.090',NULL,'/backend/customer/customer/:id','删除客户','客户','DELETE'),(51,'2022-04-15 17:31:14.854','2026-05-20 02:08:07.649',NULL,'/backend/customer/customer/:id','获取单一客户','客户','GET'),(52,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/customer/customerList','获取客户列表','客户','GET'),(53,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/getDB','获取所有数据库','代码生成器','GET'),(54,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/getTables','获取数据库表','代码生成器','GET'),(55,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/createTemp','自动化代码','代码生成器','POST'),(56,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/preview','预览自动化代码','代码生成器','POST'),(57,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/getColumn','获取所选table的所有字段','代码生成器','GET'),(58,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/createPackage','生成包(package)','包（pkg）生成器','POST'),(59,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/getPackage','获取所有包(package)','包（pkg）生成器','POST'),(60,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/delPackage','删除包(package)','包（pkg）生成器','POST'),(61,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/getMeta','获取meta信息','代码生成器历史','POST'),(62,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/rollback','回滚自动生成代码','代码生成器历史','POST'),(63,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/getSysHistory','查询回滚记录','代码生成器历史','POST'),(64,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/delSysHistory','删除回滚记录','代码生成器历史','POST'),(65,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysDictionaryDetail/updateSysDictionaryDetail','更新字典内容','系统字典详情','PUT'),(66,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysDictionaryDetail/createSysDictionaryDetail','新增字典内容','系统字典详情','POST'),(67,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysDictionaryDetail/deleteSysDictionaryDetail','删除字典内容','系统字典详情','DELETE'),(68,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysDictionaryDetail/findSysDictionaryDetail','根据ID获取字典内容','系统字典详情','GET'),(69,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysDictionaryDetail/getSysDictionaryDetailList','获取字典内容列表','系统字典详情','GET'),(70,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysDictionary/createSysDictionary','新增字典','系统字典','POST'),(71,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysDictionary/deleteSysDictionary','删除字典','系统字典','DELETE'),(72,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysDictionary/updateSysDictionary','更新字典','系统字典','PUT'),(73,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysDictionary/findSysDictionary','根据ID获取字典','系统字典','GET'),(74,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysDictionary/getSysDictionaryList','获取字典列表','系统字典','GET'),(75,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysOperationRecord/createSysOperationRecord','新增操作记录','操作记录','POST'),(76,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysOperationRecord/findSysOperationRecord','根据ID获取操作记录','操作记录','GET'),(77,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysOperationRecord/getSysOperationRecordList','获取操作记录列表','操作记录','GET'),(78,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysOperationRecord/deleteSysOperationRecord','删除操作记录','操作记录','DELETE'),(79,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysOperationRecord/deleteSysOperationRecordByIds','批量删除操作历史','操作记录','DELETE'),(80,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/simpleUploader/upload','插件版分片上传','断点续传(插件版)','POST'),(81,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/simpleUploader/checkFileMd5','文件完整度验证','断点续传(插件版)','GET'),(82,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/simpleUploader/mergeFileMd5','上传完成合并文件','断点续传(插件版)','GET'),(83,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/email/emailTest','发送测试邮件','email','POST'),(84,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/email/emailSend','发送邮件示例','email','POST'),(85,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/excel/importExcel','导入excel','excel','POST'),(86,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/excel/loadExcel','下载excel','excel','GET'),(87,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/excel/exportExcel','导出excel','excel','POST'),(88,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/excel/downloadTemplate','下载excel模板','excel','GET'),(89,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/authorityBtn/setAuthorityBtn','设置按钮权限','按钮权限','POST'),(90,'2022-04-15 17:31:14.854','2022-11-08 14:36:04.360',NULL,'/backend/authorityBtn/getAuthorityBtn','获取已有按钮权限','按钮权限','GET'),(91,'2022-04-15 17:31:14.854','2022-11-08 14:36:27.844',NULL,'/backend/authorityBtn/canRemoveAuthorityBtn/:id','删除按钮','按钮权限','DELETE'),(92,'2022-04-19 10:34:25.564','2022-12-26 14:55:53.316',NULL,'/backend/api/getAllApisList','获取api列表','api','GET'),(93,'2022-05-10 18:03:11.745','2022-05-10 18:03:11.745','2022-05-11 08:55:31.209','/backend/appTab/createAppTab','新增appTab表','appTab','POST'),(94,'2022-05-10 18:03:11.745','2022-05-10 18:03:11.745','2022-05-11 08:55:31.209','/backend/appTab/deleteAppTab','删除appTab表','appTab','DELETE'),(95,'2022-05-10 18:03:11.746','2022-05-10 18:03:11.746','2022-05-11 08:55:31.209','/backend/appTab/deleteAppTabByIds','批量删除appTab表','appTab','DELETE'),(96,'2022-05-10 18:03:11.746','2022-05-10 18:03:11.746','2022-05-11 08:55:31.209','/backend/appTab/updateAppTab','更新appTab表','appTab','PUT'),(97,'2022-05-10 18:03:11.747','2022-05-10 18:03:11.747','2022-05-11 08:55:31.209','/backend/appTab/findAppTab','根据ID获取appTab表','appTab','GET'),(98,'2022-05-10 18:03:11.747','2022-05-10 18:03:11.747','2022-05-11 08:55:31.209','/backend/appTab/getAppTabList','获取appTab表列表','appTab','GET'),(99,'2022-05-11 09:18:35.689','2022-12-21 01:33:58.479',NULL,'/backend/tag/createTag','新增tag表','tag','POST'),(100,'2022-05-11 09:18:35.690','2022-12-21 02:05:25.087',NULL,'/backend/tag/deleteTag/:id','删除tag表','tag','DELETE'),(101,'2022-05-11 09:18:35.690','2022-12-21 01:34:39.702',NULL,'/backend/tag/deleteTagByIds','批量删除tag表','tag','DELETE'),(102,'2022-05-11 09:18:35.691','2022-12-21 01:34:55.171',NULL,'/backend/tag/updateTag','更新tag表','tag','PUT'),(103,'2022-05-11 09:18:35.691','2022-12-21 02:05:33.927',NULL,'/backend/tag/findTag/:id','根据ID获取tag表','tag','GET'),(104,'2022-05-11 09:18:35.692','2022-12-21 01:35:42.252',NULL,'/backend/tag/getTagList','获取Tag列表','tag','GET'),(105,'2022-05-20 17:58:53.064','2022-05-20 17:58:53.064','2022-05-20 18:02:08.045','/backend/etst','test','test','GET'),(106,'2022-05-23 11:47:09.354','2022-05-23 11:47:09.354','2022-05-23 11:47:15.383','/backend/.test','test','test','GET'),(107,'2022-05-27 09:34:46.984','2022-05-27 09:48:27.713','2022-05-27 10:05:22.628','/backend/test','test12312312325345345','test','GET'),(108,'2022-05-27 10:28:46.044','2022-05-27 10:28:52.359','2022-05-27 10:28:56.824','/backend/test111','test55555566666','test','GET'),(109,'2022-07-28 19:05:14.572','2022-07-28 19:05:14.572',NULL,'/backend/article/createArticle','创建article','article','POST'),(110,'2022-07-28 19:06:09.029','2022-12-26 10:21:44.001',NULL,'/backend/article/deleteArticle/:id','delete article','article','DELETE'),(111,'2022-07-28 19:07:12.764','2022-07-28 19:07:12.764',NULL,'/backend/article/deleteArticleByIds','delete ids article','article','DELETE'),(112,'2022-07-28 19:07:45.765','2022-12-26 10:21:51.934',NULL,'/backend/article/updateArticle/:id','update article','article','PUT'),(113,'2022-07-28 19:08:30.000','2022-12-26 10:21:22.170',NULL,'/backend/article/findArticle/:id','查询单个 article','article','GET'),(114,'2022-07-28 19:09:03.036','2022-07-28 19:10:32.208',NULL,'/backend/article/getArticleList','article 列表','article','GET'),(115,'2022-09-05 09:07:20.267','2022-09-05 09:07:20.267',NULL,'/backend/comment/createComment','新增comment表','comment','POST'),(116,'2022-09-05 09:07:20.268','2024-05-16 14:04:29.817',NULL,'/backend/comment/deleteComment/:id','删除comment表','comment','DELETE'),(117,'2022-09-05 09:07:20.269','2022-09-05 09:07:20.269',NULL,'/backend/comment/deleteCommentByIds','批量删除comment表','comment','DELETE'),(118,'2022-09-05 09:07:20.269','2024-05-16 14:04:42.804',NULL,'/backend/comment/updateComment/:id','更新comment表','comment','PUT'),(119,'2022-09-05 09:07:20.270','2024-05-16 14:04:59.103',NULL,'/backend/comment/getComment/:id','根据ID获取comment表','comment','GET'),(120,'2022-09-05 09:07:20.270','2022-09-05 09:07:20.270',NULL,'/backend/comment/getCommentList','获取comment表列表','comment','GET'),(121,'2022-10-17 11:30:49.955','2023-01-05 19:25:53.575',NULL,'/backend/base_message/getBaseMessage/:id','获取基本信息','基本信息','GET'),(122,'2022-10-17 14:46:50.062','2022-10-17 14:46:50.062',NULL,'/backend/base_message/createBaseMessage','创建基本信息','基本信息','POST'),(123,'2022-10-17 14:47:48.621','2022-10-17 14:47:48.621',NULL,'/backend/base_message/updateBaseMessage','更新基本信息','基本信息','PUT'),(124,'2022-11-08 10:07:56.877','2022-11-08 10:07:56.877',NULL,'/backend/user/getUserCount','用户数量','系统用户','GET'),(125,'2022-11-08 13:43:56.277','2022-11-16 14:14:47.125',NULL,'/backend/comment/getCommentTreeList','Tree comment列表','comment','GET'),(126,'2022-11-16 14:14:07.166','2022-11-16 14:14:07.166',NULL,'/backend/comment/pariseComment','comment点赞','comment','PUT'),(127,'2022-11-16 18:25:24.972','2022-11-16 18:25:24.972',NULL,'/backend/base_message/upload_file','上传图片','基本信息','POST'),(128,'2022-11-17 11:35:28.558','2022-11-17 11:40:27.539',NULL,'/backend/problem/getIsSetting/:id','是否用户已设置问题','用户问题设置','GET'),(129,'2022-11-23 10:29:17.569','2022-11-23 10:29:17.569',NULL,'/backend/tasking/start','开启任务','任务','GET'),(130,'2022-11-25 16:17:22.075','2022-11-25 16:17:34.313',NULL,'/backend/problem/updateProblem','设置密保','用户问题设置','PUT'),(131,'2022-11-25 16:20:20.485','2022-11-25 16:20:27.995',NULL,'/backend/problem/getProblemList/:id','获取密保列表','用户问题设置','GET'),(132,'2022-11-25 16:21:18.766','2022-11-25 16:21:25.469',NULL,'/backend/problem/verifyAnswer','验证问题','用户问题设置','POST'),(133,'2023-01-12 16:06:36.228','2026-05-21 00:25:51.938',NULL,'/backend/user/getFlow','ip的流量访问','流量','GET'),(134,'2023-02-08 17:05:22.019','2023-02-08 17:05:22.019',NULL,'/backend/article/putArticleByIds','批量更新 是否首页显示','article','PUT'),(135,'2023-03-29 15:29:29.482','2023-03-29 15:29:29.482',NULL,'/backend/frontend-user/createUser','创建frontend-user','frontend-user','POST'),(136,'2023-03-29 15:29:29.482','2023-03-29 15:29:29.482',NULL,'/backend/frontend-user/findUser/:id','查看frontend-user','frontend-user','GET'),(137,'2023-03-29 15:29:29.482','2023-03-29 15:29:29.482',NULL,'/backend/frontend-user/deleteUser/:id','删除frontend-user','frontend-user','DELETE'),(138,'2023-03-29 15:29:29.482','2023-03-29 15:29:29.482',NULL,'/backend/frontend-user/deleteUserByIds','批量删除frontend-user','frontend-user','DELETE'),(139,'2023-03-29 15:29:29.482','2023-03-29 15:29:29.482',NULL,'/backend/frontend-user/getUserList','查询frontend-user','frontend-user','GET'),(140,'2023-03-29 15:29:29.482','2023-04-06 11:24:42.368',NULL,'/backend/frontend-user/updateUser/:id','更新frontend-user','frontend-user','PUT'),(141,'2023-03-31 16:53:33.150','2023-03-31 16:53:33.150',NULL,'/backend/article/getArticleReading','文章阅读总量','article','GET'),(142,'2023-05-08 08:20:05.313','2023-05-08 08:20:05.313',NULL,'/backend/system/reloadSystem','系统服务重启','服务重启','POST'),(143,'2023-06-13 12:01:09.810','2023-06-21 17:58:51.373',NULL,'/backend/github/createGithub','更新Github commit 到本地','GithubCommit','GET'),(144,'2023-06-13 12:03:15.611','2023-06-13 12:03:15.611',NULL,'/backend/github/getGithubList','获取github commit 记录','GithubCommit','GET'),(145,'2023-06-30 15:18:13.651','2023-06-30 15:18:13.651',NULL,'/moblieUser/createMoblieUser','新增moblieUser表','moblieUser','POST'),(146,'2023-06-30 15:18:13.652','2023-06-30 15:18:13.652',NULL,'/moblieUser/deleteMoblieUser','删除moblieUser表','moblieUser','DELETE'),(147,'2023-06-30 15:18:13.652','2023-06-30 15:18:13.652',NULL,'/moblieUser/deleteMoblieUserByIds','批量删除moblieUser表','moblieUser','DELETE'),(148,'2023-06-30 15:18:13.653','2023-06-30 15:18:13.653',NULL,'/moblieUser/updateMoblieUser','更新moblieUser表','moblieUser','PUT'),(149,'2023-06-30 15:18:13.654','2023-06-30 15:18:13.654',NULL,'/moblieUser/findMoblieUser','根据ID获取moblieUser表','moblieUser','GET'),(150,'2023-06-30 15:18:13.655','2023-06-30 15:18:13.655',NULL,'/moblieUser/getMoblieUserList','获取moblieUser表列表','moblieUser','GET'),(151,'2023-06-30 16:13:35.368','2023-06-30 17:18:02.940',NULL,'/backend/mobile/createMobileUser','创建mobile_user','mobile_user','POST'),(152,'2023-06-30 16:15:10.665','2023-06-30 17:18:10.761',NULL,'/backend/mobile/deleteMobileUser/:id','删除mobile_user','mobile_user','DELETE'),(153,'2023-06-30 16:15:56.673','2023-06-30 17:18:17.908',NULL,'/backend/mobile/deleteMobileUserByIds','批量删除mobile_user','mobile_user','DELETE'),(154,'2023-06-30 16:22:25.207','2023-06-30 17:18:27.557',NULL,'/backend/mobile/updateMobileUser/:id','更新mobile_user','mobile_user','PUT'),(155,'2023-06-30 16:24:09.063','2023-06-30 17:18:38.429',NULL,'/backend/mobile/findMobileUser/:id','查询mobile_user','mobile_user','GET'),(156,'2023-06-30 16:24:46.376','2023-06-30 17:18:48.259',NULL,'/backend/mobile/getMobileUserList','查询mobile_user','mobile_user','GET'),(157,'2023-10-08 17:26:48.736','2023-10-08 17:26:48.736',NULL,'/backend/api/getAllApisList','test','test','POST'),(158,'2023-11-24 15:51:10.912','2023-11-24 15:51:10.912',NULL,'/backend/excel/getFileInfoList','上传文件列表','excel','GET'),(159,'2023-11-24 17:58:02.484','2024-05-31 16:56:25.554',NULL,' /backend/fileUploadAndDownload/deleteFileBreakpoint/:id','删除上传文件','分片上传','DELETE'),(160,'2024-05-28 15:58:14.693','2024-05-28 15:58:14.693','2024-05-28 17:12:58.215','/exaFiles/createExaFiles','新增exaFiles表','exaFiles','POST'),(161,'2024-05-28 15:58:14.694','2024-05-28 15:58:14.694','2024-05-29 10:12:42.000','/exaFiles/deleteExaFiles','删除exaFiles表','exaFiles','DELETE'),(162,'2024-05-28 15:58:14.695','2024-05-28 15:58:14.695','2024-05-29 10:12:43.000','/exaFiles/deleteExaFilesByIds','批量删除exaFiles表','exaFiles','DELETE'),(163,'2024-05-28 15:58:14.696','2024-05-28 15:58:14.696','2024-05-29 10:12:44.000','/exaFiles/updateExaFiles','更新exaFiles表','exaFiles','PUT'),(164,'2024-05-28 15:58:14.697','2024-05-28 15:58:14.697','2024-05-29 10:12:46.000','/exaFiles/findExaFiles','根据ID获取exaFiles表','exaFiles','GET'),(165,'2024-05-28 15:58:14.698','2024-05-28 15:58:14.698','2024-05-29 10:12:47.000','/exaFiles/getExaFilesList','获取exaFiles表列表','exaFiles','GET'),(166,'2024-05-29 11:36:07.146','2024-05-31 08:42:35.201',NULL,'/backend/fileUploadAndDownload/getFileBreakpoint','断点上传文件','分片上传','GET') ON DUPLICATE KEY UPDATE `id` = VALUES(`id`),`created_at` = VALUES(`created_at`),`updated_at` = VALUES(`updated_at`),`deleted_at` = VALUES(`deleted_at`),`path` = VALUES(`path`),`description` = VALUES(`description`),`api_group` = VALUES(`api_group`),`method` = VALUES(`method`);
+INSERT INTO `server-fiber`.`sys_apis` (`id`,`created_at`,`updated_at`,`deleted_at`,`path`,`description`,`api_group`,`method`) VALUES (1,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/base/login','用户登录(必选)','base','POST'),(2,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/jwt/jsonInBlacklist','jwt加入黑名单(退出，必选)','jwt','POST'),(3,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/user/deleteUser','删除用户','系统用户','DELETE'),(4,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/user/admin_register','用户注册','系统用户','POST'),(5,'2022-04-15 17:31:14.854','2022-11-08 11:47:02.250',NULL,'/backend/user/getUserList','获取用户列表','系统用户','GET'),(6,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/user/setUserInfo','设置用户信息','系统用户','PUT'),(7,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/user/setSelfInfo','设置自身信息(必选)','系统用户','PUT'),(8,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/user/getUserInfo','获取自身信息(必选)','系统用户','GET'),(9,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/user/setUserAuthorities','设置权限组','系统用户','POST'),(10,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/user/changePassword','修改密码（建议选择)','系统用户','POST'),(11,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/user/setUserAuthority','修改用户角色(必选)','系统用户','POST'),(12,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/user/resetPassword','重置用户密码','系统用户','POST'),(13,'2022-04-15 17:31:14.854','2022-11-08 11:46:00.241',NULL,'/backend/api/createApi','创建api','api','POST'),(14,'2022-04-15 17:31:14.854','2022-05-27 17:51:11.682',NULL,'/backend/api/deleteApi/:id','删除Api','api','DELETE'),(15,'2022-04-15 17:31:14.854','2022-12-26 15:06:01.545',NULL,'/backend/api/updateApi/:id','更新Api','api','PUT'),(16,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/api/getApiList','获取api列表','api','GET'),(17,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/api/getAllApis','获取所有api','api','GET'),(18,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/api/getApiById/:id','获取api详细信息','api','GET'),(19,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/api/deleteApisByIds','批量删除api','api','DELETE'),(20,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/authority/copyAuthority','拷贝角色','角色','POST'),(21,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/authority/createAuthority','创建角色','角色','POST'),(22,'2022-04-15 17:31:14.854','2023-01-12 14:10:12.284',NULL,'/backend/authority/deleteAuthority','删除角色','角色','DELETE'),(23,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/authority/updateAuthority','更新角色信息','角色','PUT'),(24,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/authority/getAuthorityList','获取角色列表','角色','GET'),(25,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/authority/setDataAuthority','设置角色资源权限','角色','POST'),(26,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/casbin/updateCasbin','更改角色api权限','casbin','POST'),(27,'2022-04-15 17:31:14.854','2022-11-22 08:37:50.114',NULL,'/backend/casbin/getPolicyPathByAuthorityId/:id','获取权限列表','casbin','GET'),(28,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/menu/addBaseMenu','新增菜单','菜单','POST'),(29,'2022-04-15 17:31:14.854','2022-10-14 15:41:03.128',NULL,'/backend/menu/getMenu','获取菜单树(必选)','菜单','GET'),(30,'2022-04-15 17:31:14.854','2022-11-08 12:04:46.417',NULL,'/backend/menu/deleteBaseMenu/:id','删除菜单','菜单','DELETE'),(31,'2022-04-15 17:31:14.854','2022-10-14 15:41:22.678',NULL,'/backend/menu/updateBaseMenu','更新菜单','菜单','PUT'),(32,'2022-04-15 17:31:14.854','2022-10-14 15:41:48.505',NULL,'/backend/menu/getBaseMenuById/:id','根据id获取菜单','菜单','GET'),(33,'2022-04-15 17:31:14.854','2022-11-08 11:50:07.775',NULL,'/backend/menu/getMenuList','分页获取基础menu列表','菜单','GET'),(34,'2022-04-15 17:31:14.854','2022-11-08 11:50:14.770',NULL,'/backend/menu/getBaseMenuTree','获取用户动态路由','菜单','GET'),(35,'2022-04-15 17:31:14.854','2022-11-08 11:50:24.567',NULL,'/backend/menu/getMenuAuthority','获取指定角色menu','菜单','GET'),(36,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/menu/addMenuAuthority','增加menu和角色关联关系','菜单','POST'),(37,'2022-04-15 17:31:14.854','2022-11-08 14:00:08.362',NULL,'/backend/fileUploadAndDownload/findFile','寻找目标文件（秒传）','分片上传','GET'),(38,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/fileUploadAndDownload/breakpointContinue','断点续传','分片上传','POST'),(39,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/fileUploadAndDownload/breakpointContinueFinish','断点续传完成','分片上传','POST'),(40,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/fileUploadAndDownload/removeChunk','上传完成移除文件','分片上传','POST'),(41,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/fileUploadAndDownload/upload','文件上传示例','文件上传与下载','POST'),(42,'2022-04-15 17:31:14.854','2022-11-08 13:51:20.334',NULL,'/backend/fileUploadAndDownload/deleteFile/:id','删除文件','文件上传与下载','DELETE'),(43,'2022-04-15 17:31:14.854','2022-11-08 13:49:02.690',NULL,'/backend/fileUploadAndDownload/editFileName','文件名或者备注编辑','文件上传与下载','PUT'),(44,'2022-04-15 17:31:14.854','2022-11-08 13:47:18.201',NULL,'/backend/fileUploadAndDownload/getFileList','获取上传文件列表','文件上传与下载','GET'),(45,'2022-04-15 17:31:14.854','2022-11-22 08:36:52.059',NULL,'/backend/system/getServerInfo','获取服务器信息','系统服务','GET'),(46,'2022-04-15 17:31:14.854','2022-11-22 08:37:12.745',NULL,'/backend/system/getSystemConfig','获取配置文件内容','系统服务','GET'),(47,'2022-04-15 17:31:14.854','2022-11-22 14:39:43.724',NULL,'/backend/system/setSystemConfig','设置配置文件内容','系统服务','PUT'),(48,'2022-04-15 17:31:14.854','2026-05-20 02:06:19.549',NULL,'/backend/customer/customer/:id','更新客户','客户','PUT'),(49,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/customer/customer','创建客户','客户','POST'),(50,'2022-04-15 17:31:14.854','2026-05-20 02:06:07.090',NULL,'/backend/customer/customer/:id','删除客户','客户','DELETE'),(51,'2022-04-15 17:31:14.854','2026-05-20 02:08:07.649',NULL,'/backend/customer/customer/:id','获取单一客户','客户','GET'),(52,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/customer/customerList','获取客户列表','客户','GET'),(53,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/getDB','获取所有数据库','代码生成器','GET'),(54,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/getTables','获取数据库表','代码生成器','GET'),(55,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/createTemp','自动化代码','代码生成器','POST'),(56,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/preview','预览自动化代码','代码生成器','POST'),(57,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/getColumn','获取所选table的所有字段','代码生成器','GET'),(58,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/createPackage','生成包(package)','包（pkg）生成器','POST'),(59,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/getPackage','获取所有包(package)','包（pkg）生成器','POST'),(60,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/delPackage','删除包(package)','包（pkg）生成器','POST'),(61,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/getMeta','获取meta信息','代码生成器历史','POST'),(62,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/rollback','回滚自动生成代码','代码生成器历史','POST'),(63,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/getSysHistory','查询回滚记录','代码生成器历史','POST'),(64,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/autoCode/delSysHistory','删除回滚记录','代码生成器历史','POST'),(65,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysDictionaryDetail/updateSysDictionaryDetail','更新字典内容','系统字典详情','PUT'),(66,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysDictionaryDetail/createSysDictionaryDetail','新增字典内容','系统字典详情','POST'),(67,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysDictionaryDetail/deleteSysDictionaryDetail','删除字典内容','系统字典详情','DELETE'),(68,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysDictionaryDetail/findSysDictionaryDetail','根据ID获取字典内容','系统字典详情','GET'),(69,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysDictionaryDetail/getSysDictionaryDetailList','获取字典内容列表','系统字典详情','GET'),(70,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysDictionary/createSysDictionary','新增字典','系统字典','POST'),(71,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysDictionary/deleteSysDictionary','删除字典','系统字典','DELETE'),(72,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysDictionary/updateSysDictionary','更新字典','系统字典','PUT'),(73,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysDictionary/findSysDictionary','根据ID获取字典','系统字典','GET'),(74,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysDictionary/getSysDictionaryList','获取字典列表','系统字典','GET'),(75,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysOperationRecord/createSysOperationRecord','新增操作记录','操作记录','POST'),(76,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysOperationRecord/findSysOperationRecord','根据ID获取操作记录','操作记录','GET'),(77,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysOperationRecord/getSysOperationRecordList','获取操作记录列表','操作记录','GET'),(78,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysOperationRecord/deleteSysOperationRecord','删除操作记录','操作记录','DELETE'),(79,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/sysOperationRecord/deleteSysOperationRecordByIds','批量删除操作历史','操作记录','DELETE'),(80,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/simpleUploader/upload','插件版分片上传','断点续传(插件版)','POST'),(81,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/simpleUploader/checkFileMd5','文件完整度验证','断点续传(插件版)','GET'),(82,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/simpleUploader/mergeFileMd5','上传完成合并文件','断点续传(插件版)','GET'),(83,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/email/emailTest','发送测试邮件','email','POST'),(84,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/email/emailSend','发送邮件示例','email','POST'),(85,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/excel/importExcel','导入excel','excel','POST'),(86,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/excel/loadExcel','下载excel','excel','GET'),(87,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/excel/exportExcel','导出excel','excel','POST'),(88,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/excel/downloadTemplate','下载excel模板','excel','GET'),(89,'2022-04-15 17:31:14.854','2022-04-15 17:31:14.854',NULL,'/backend/authorityBtn/setAuthorityBtn','设置按钮权限','按钮权限','POST'),(90,'2022-04-15 17:31:14.854','2022-11-08 14:36:04.360',NULL,'/backend/authorityBtn/getAuthorityBtn','获取已有按钮权限','按钮权限','GET'),(91,'2022-04-15 17:31:14.854','2022-11-08 14:36:27.844',NULL,'/backend/authorityBtn/canRemoveAuthorityBtn/:id','删除按钮','按钮权限','DELETE'),(92,'2022-04-19 10:34:25.564','2022-12-26 14:55:53.316',NULL,'/backend/api/getAllApisList','获取api列表','api','GET'),(93,'2022-05-10 18:03:11.745','2022-05-10 18:03:11.745','2022-05-11 08:55:31.209','/backend/appTab/createAppTab','新增appTab表','appTab','POST'),(94,'2022-05-10 18:03:11.745','2022-05-10 18:03:11.745','2022-05-11 08:55:31.209','/backend/appTab/deleteAppTab','删除appTab表','appTab','DELETE'),(95,'2022-05-10 18:03:11.746','2022-05-10 18:03:11.746','2022-05-11 08:55:31.209','/backend/appTab/deleteAppTabByIds','批量删除appTab表','appTab','DELETE'),(96,'2022-05-10 18:03:11.746','2022-05-10 18:03:11.746','2022-05-11 08:55:31.209','/backend/appTab/updateAppTab','更新appTab表','appTab','PUT'),(97,'2022-05-10 18:03:11.747','2022-05-10 18:03:11.747','2022-05-11 08:55:31.209','/backend/appTab/findAppTab','根据ID获取appTab表','appTab','GET'),(98,'2022-05-10 18:03:11.747','2022-05-10 18:03:11.747','2022-05-11 08:55:31.209','/backend/appTab/getAppTabList','获取appTab表列表','appTab','GET'),(99,'2022-05-11 09:18:35.689','2022-12-21 01:33:58.479',NULL,'/backend/tag/createTag','新增tag表','tag','POST'),(100,'2022-05-11 09:18:35.690','2022-12-21 02:05:25.087',NULL,'/backend/tag/deleteTag/:id','删除tag表','tag','DELETE'),(101,'2022-05-11 09:18:35.690','2022-12-21 01:34:39.702',NULL,'/backend/tag/deleteTagByIds','批量删除tag表','tag','DELETE'),(102,'2022-05-11 09:18:35.691','2022-12-21 01:34:55.171',NULL,'/backend/tag/updateTag','更新tag表','tag','PUT'),(103,'2022-05-11 09:18:35.691','2022-12-21 02:05:33.927',NULL,'/backend/tag/findTag/:id','根据ID获取tag表','tag','GET'),(104,'2022-05-11 09:18:35.692','2022-12-21 01:35:42.252',NULL,'/backend/tag/getTagList','获取Tag列表','tag','GET'),(105,'2022-05-20 17:58:53.064','2022-05-20 17:58:53.064','2022-05-20 18:02:08.045','/backend/etst','test','test','GET'),(106,'2022-05-23 11:47:09.354','2022-05-23 11:47:09.354','2022-05-23 11:47:15.383','/backend/.test','test','test','GET'),(107,'2022-05-27 09:34:46.984','2022-05-27 09:48:27.713','2022-05-27 10:05:22.628','/backend/test','test12312312325345345','test','GET'),(108,'2022-05-27 10:28:46.044','2022-05-27 10:28:52.359','2022-05-27 10:28:56.824','/backend/test111','test55555566666','test','GET'),(109,'2022-07-28 19:05:14.572','2022-07-28 19:05:14.572',NULL,'/backend/article/createArticle','创建article','article','POST'),(110,'2022-07-28 19:06:09.029','2022-12-26 10:21:44.001',NULL,'/backend/article/deleteArticle/:id','delete article','article','DELETE'),(111,'2022-07-28 19:07:12.764','2022-07-28 19:07:12.764',NULL,'/backend/article/deleteArticleByIds','delete ids article','article','DELETE'),(112,'2022-07-28 19:07:45.765','2022-12-26 10:21:51.934',NULL,'/backend/article/updateArticle/:id','update article','article','PUT'),(113,'2022-07-28 19:08:30.000','2022-12-26 10:21:22.170',NULL,'/backend/article/findArticle/:id','查询单个 article','article','GET'),(114,'2022-07-28 19:09:03.036','2022-07-28 19:10:32.208',NULL,'/backend/article/getArticleList','article 列表','article','GET'),(115,'2022-09-05 09:07:20.267','2022-09-05 09:07:20.267',NULL,'/backend/comment/createComment','新增comment表','comment','POST'),(116,'2022-09-05 09:07:20.268','2024-05-16 14:04:29.817',NULL,'/backend/comment/deleteComment/:id','删除comment表','comment','DELETE'),(117,'2022-09-05 09:07:20.269','2022-09-05 09:07:20.269',NULL,'/backend/comment/deleteCommentByIds','批量删除comment表','comment','DELETE'),(118,'2022-09-05 09:07:20.269','2024-05-16 14:04:42.804',NULL,'/backend/comment/updateComment/:id','更新comment表','comment','PUT'),(119,'2022-09-05 09:07:20.270','2024-05-16 14:04:59.103',NULL,'/backend/comment/getComment/:id','根据ID获取comment表','comment','GET'),(120,'2022-09-05 09:07:20.270','2022-09-05 09:07:20.270',NULL,'/backend/comment/getCommentList','获取comment表列表','comment','GET'),(121,'2022-10-17 11:30:49.955','2023-01-05 19:25:53.575',NULL,'/backend/base_message/getBaseMessage/:id','获取基本信息','基本信息','GET'),(122,'2022-10-17 14:46:50.062','2022-10-17 14:46:50.062',NULL,'/backend/base_message/createBaseMessage','创建基本信息','基本信息','POST'),(123,'2022-10-17 14:47:48.621','2022-10-17 14:47:48.621',NULL,'/backend/base_message/updateBaseMessage/:id','更新基本信息','基本信息','PUT'),(124,'2022-11-08 10:07:56.877','2022-11-08 10:07:56.877',NULL,'/backend/user/getUserCount','用户数量','系统用户','GET'),(125,'2022-11-08 13:43:56.277','2022-11-16 14:14:47.125',NULL,'/backend/comment/getCommentTreeList','Tree comment列表','comment','GET'),(126,'2022-11-16 14:14:07.166','2022-11-16 14:14:07.166',NULL,'/backend/comment/pariseComment','comment点赞','comment','PUT'),(127,'2022-11-16 18:25:24.972','2022-11-16 18:25:24.972',NULL,'/backend/base_message/upload_file','上传图片','基本信息','POST'),(128,'2022-11-17 11:35:28.558','2022-11-17 11:40:27.539',NULL,'/backend/problem/getIsSetting/:id','是否用户已设置问题','用户问题设置','GET'),(129,'2022-11-23 10:29:17.569','2022-11-23 10:29:17.569',NULL,'/backend/tasking/start','开启任务','任务','GET'),(130,'2022-11-25 16:17:22.075','2022-11-25 16:17:34.313',NULL,'/backend/problem/updateProblem','设置密保','用户问题设置','PUT'),(131,'2022-11-25 16:20:20.485','2022-11-25 16:20:27.995',NULL,'/backend/problem/getProblemList/:id','获取密保列表','用户问题设置','GET'),(132,'2022-11-25 16:21:18.766','2022-11-25 16:21:25.469',NULL,'/backend/problem/verifyAnswer','验证问题','用户问题设置','POST'),(133,'2023-01-12 16:06:36.228','2026-05-21 00:25:51.938',NULL,'/backend/user/getFlow','ip的流量访问','流量','GET'),(134,'2023-02-08 17:05:22.019','2023-02-08 17:05:22.019',NULL,'/backend/article/putArticleByIds','批量更新 是否首页显示','article','PUT'),(135,'2023-03-29 15:29:29.482','2023-03-29 15:29:29.482',NULL,'/backend/frontend-user/createUser','创建frontend-user','frontend-user','POST'),(136,'2023-03-29 15:29:29.482','2023-03-29 15:29:29.482',NULL,'/backend/frontend-user/findUser/:id','查看frontend-user','frontend-user','GET'),(137,'2023-03-29 15:29:29.482','2023-03-29 15:29:29.482',NULL,'/backend/frontend-user/deleteUser/:id','删除frontend-user','frontend-user','DELETE'),(138,'2023-03-29 15:29:29.482','2023-03-29 15:29:29.482',NULL,'/backend/frontend-user/deleteUserByIds','批量删除frontend-user','frontend-user','DELETE'),(139,'2023-03-29 15:29:29.482','2023-03-29 15:29:29.482',NULL,'/backend/frontend-user/getUserList','查询frontend-user','frontend-user','GET'),(140,'2023-03-29 15:29:29.482','2023-04-06 11:24:42.368',NULL,'/backend/frontend-user/updateUser/:id','更新frontend-user','frontend-user','PUT'),(141,'2023-03-31 16:53:33.150','2023-03-31 16:53:33.150',NULL,'/backend/article/getArticleReading','文章阅读总量','article','GET'),(142,'2023-05-08 08:20:05.313','2023-05-08 08:20:05.313',NULL,'/backend/system/reloadSystem','系统服务重启','服务重启','POST'),(143,'2023-06-13 12:01:09.810','2023-06-21 17:58:51.373',NULL,'/backend/github/createGithub','更新Github commit 到本地','GithubCommit','GET'),(144,'2023-06-13 12:03:15.611','2023-06-13 12:03:15.611',NULL,'/backend/github/getGithubList','获取github commit 记录','GithubCommit','GET'),(145,'2023-06-30 15:18:13.651','2023-06-30 15:18:13.651',NULL,'/moblieUser/createMoblieUser','新增moblieUser表','moblieUser','POST'),(146,'2023-06-30 15:18:13.652','2023-06-30 15:18:13.652',NULL,'/moblieUser/deleteMoblieUser','删除moblieUser表','moblieUser','DELETE'),(147,'2023-06-30 15:18:13.652','2023-06-30 15:18:13.652',NULL,'/moblieUser/deleteMoblieUserByIds','批量删除moblieUser表','moblieUser','DELETE'),(148,'2023-06-30 15:18:13.653','2023-06-30 15:18:13.653',NULL,'/moblieUser/updateMoblieUser','更新moblieUser表','moblieUser','PUT'),(149,'2023-06-30 15:18:13.654','2023-06-30 15:18:13.654',NULL,'/moblieUser/findMoblieUser','根据ID获取moblieUser表','moblieUser','GET'),(150,'2023-06-30 15:18:13.655','2023-06-30 15:18:13.655',NULL,'/moblieUser/getMoblieUserList','获取moblieUser表列表','moblieUser','GET'),(151,'2023-06-30 16:13:35.368','2023-06-30 17:18:02.940',NULL,'/backend/mobile/createMobileUser','创建mobile_user','mobile_user','POST'),(152,'2023-06-30 16:15:10.665','2023-06-30 17:18:10.761',NULL,'/backend/mobile/deleteMobileUser/:id','删除mobile_user','mobile_user','DELETE'),(153,'2023-06-30 16:15:56.673','2023-06-30 17:18:17.908',NULL,'/backend/mobile/deleteMobileUserByIds','批量删除mobile_user','mobile_user','DELETE'),(154,'2023-06-30 16:22:25.207','2023-06-30 17:18:27.557',NULL,'/backend/mobile/updateMobileUser/:id','更新mobile_user','mobile_user','PUT'),(155,'2023-06-30 16:24:09.063','2023-06-30 17:18:38.429',NULL,'/backend/mobile/findMobileUser/:id','查询mobile_user','mobile_user','GET'),(156,'2023-06-30 16:24:46.376','2023-06-30 17:18:48.259',NULL,'/backend/mobile/getMobileUserList','查询mobile_user','mobile_user','GET'),(157,'2023-10-08 17:26:48.736','2023-10-08 17:26:48.736',NULL,'/backend/api/getAllApisList','test','test','POST'),(158,'2023-11-24 15:51:10.912','2023-11-24 15:51:10.912',NULL,'/backend/excel/getFileInfoList','上传文件列表','excel','GET'),(159,'2023-11-24 17:58:02.484','2024-05-31 16:56:25.554',NULL,' /backend/fileUploadAndDownload/deleteFileBreakpoint/:id','删除上传文件','分片上传','DELETE'),(160,'2024-05-28 15:58:14.693','2024-05-28 15:58:14.693','2024-05-28 17:12:58.215','/exaFiles/createExaFiles','新增exaFiles表','exaFiles','POST'),(161,'2024-05-28 15:58:14.694','2024-05-28 15:58:14.694','2024-05-29 10:12:42.000','/exaFiles/deleteExaFiles','删除exaFiles表','exaFiles','DELETE'),(162,'2024-05-28 15:58:14.695','2024-05-28 15:58:14.695','2024-05-29 10:12:43.000','/exaFiles/deleteExaFilesByIds','批量删除exaFiles表','exaFiles','DELETE'),(163,'2024-05-28 15:58:14.696','2024-05-28 15:58:14.696','2024-05-29 10:12:44.000','/exaFiles/updateExaFiles','更新exaFiles表','exaFiles','PUT'),(164,'2024-05-28 15:58:14.697','2024-05-28 15:58:14.697','2024-05-29 10:12:46.000','/exaFiles/findExaFiles','根据ID获取exaFiles表','exaFiles','GET'),(165,'2024-05-28 15:58:14.698','2024-05-28 15:58:14.698','2024-05-29 10:12:47.000','/exaFiles/getExaFilesList','获取exaFiles表列表','exaFiles','GET'),(166,'2024-05-29 11:36:07.146','2024-05-31 08:42:35.201',NULL,'/backend/fileUploadAndDownload/getFileBreakpoint','断点上传文件','分片上传','GET') ON DUPLICATE KEY UPDATE `id` = VALUES(`id`),`created_at` = VALUES(`created_at`),`updated_at` = VALUES(`updated_at`),`deleted_at` = VALUES(`deleted_at`),`path` = VALUES(`path`),`description` = VALUES(`description`),`api_group` = VALUES(`api_group`),`method` = VALUES(`method`);
 -- server-fiber.sys_authorities DML
 INSERT INTO `server-fiber`.`sys_authorities` (`created_at`,`updated_at`,`deleted_at`,`authority_id`,`authority_name`,`parent_id`,`default_router`) VALUES ('2023-06-16 10:51:17.273','2023-06-16 10:51:32.698',NULL,'66','前台用户','0','about'),('2022-04-15 17:31:14.865','2026-05-20 02:01:59.803',NULL,'888','后台用户','0','dashboard'),('2022-04-15 17:31:14.865','2023-07-18 10:21:19.743',NULL,'8881','后台用户子角色','888','dashboard'),('2022-04-15 17:31:14.865','2022-11-23 17:51:35.614',NULL,'9528','测试角色','0','dashboard'),('2022-10-27 14:37:37.070','2023-08-30 16:01:51.066',NULL,'998','专门角色','8881','dashboard') ON DUPLICATE KEY UPDATE `created_at` = VALUES(`created_at`),`updated_at` = VALUES(`updated_at`),`deleted_at` = VALUES(`deleted_at`),`authority_id` = VALUES(`authority_id`),`authority_name` = VALUES(`authority_name`),`parent_id` = VALUES(`parent_id`),`default_router` = VALUES(`default_router`);
 -- server-fiber.sys_authority_btns DML
@@ -11,7 +11,11 @@ import (
 )
 
 // GetClaims 从fiber的Context中获取JWT token并解析出CustomClaims结构体
+// 已经由 JWTAuth 校验过的请求(包括使用 API Key 的请求)直接使用其中的 claims
 func GetClaims(c fiber.Ctx) (*systemReq.CustomClaims, error) {
+	if claims, ok := c.Locals("claims").(*systemReq.CustomClaims); ok && claims.BaseClaims.ID != 0 {
+		return claims, nil
+	}
 	tokenString := c.Get("Authorization", "") // 从请求头中获取token字符串
 	// fmt.Println("tokenString: ", tokenString)
 	if tokenString == "" {
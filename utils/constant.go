@@ -4,4 +4,8 @@ const (
 	ConfigEnv  = "./conf/"
 	ConfigFile = "config.yaml"
 	ConfigEnvD = "./conf.d/"
+
+	ConfigDirEnv    = "SERVER_CONFIG" // 配置目录环境变量
+	ConfigEnvPrefix = "SERVER"        // 覆盖配置项的环境变量前缀
+
 )
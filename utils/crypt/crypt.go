@@ -0,0 +1,160 @@
+// Package crypt 敏感字段加密存储
+//
+// 字段通过 `gorm:"serializer:encrypted"` 声明, 写入时用 encryption.current 版本的密钥做 AES-GCM 加密,
+// 存储为 enc:<版本>:<base64(nonce+密文)>, 读取时按前缀中的版本选择密钥, 轮换密钥后旧数据仍可读取;
+// 不带前缀的值视为尚未加密的旧数据原样返回. 加密后无法按值查询, 需要精确查询的字段另存 BlindIndex
+package crypt
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	global "server/model"
+
+	"gorm.io/gorm/schema"
+)
+
+// SerializerName gorm 标签中使用的序列化器名称
+const SerializerName = "encrypted"
+
+const prefix = "enc:"
+
+var (
+	ErrUnknownKey = errors.New("加密密钥版本未配置")
+	ErrCiphertext = errors.New("密文格式错误")
+)
+
+func init() {
+	schema.RegisterSerializer(SerializerName, Serializer{})
+}
+
+// Enabled 是否开启了加密, 未开启时新写入的数据按明文存储
+func Enabled() bool {
+	return global.CONFIG.Encryption.Current != ""
+}
+
+// IsEncrypted 是否为 Encrypt 生成的密文
+func IsEncrypted(s string) bool {
+	return strings.HasPrefix(s, prefix)
+}
+
+// Version 密文使用的密钥版本, 明文返回空
+func Version(s string) string {
+	if !IsEncrypted(s) {
+		return ""
+	}
+	version, _, _ := strings.Cut(s[len(prefix):], ":")
+	return version
+}
+
+// Encrypt 使用当前版本的密钥加密, 空值和未开启加密时原样返回
+func Encrypt(plain string) (string, error) {
+	version := global.CONFIG.Encryption.Current
+	if plain == "" || version == "" {
+		return plain, nil
+	}
+	gcm, err := aead(version)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plain), nil)
+	return prefix + version + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt 按密文前缀中的版本解密, 不是密文时原样返回
+func Decrypt(s string) (string, error) {
+	if !IsEncrypted(s) {
+		return s, nil
+	}
+	version, data, ok := strings.Cut(s[len(prefix):], ":")
+	if !ok {
+		return "", ErrCiphertext
+	}
+	gcm, err := aead(version)
+	if err != nil {
+		return "", err
+	}
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil || len(raw) < gcm.NonceSize() {
+		return "", ErrCiphertext
+	}
+	plain, err := gcm.Open(nil, raw[:gcm.NonceSize()], raw[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrCiphertext, err)
+	}
+	return string(plain), nil
+}
+
+// BlindIndex 精确查询使用的盲索引, 对去掉首尾空白并转小写后的值做 HMAC-SHA256, 空值返回空
+func BlindIndex(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(global.CONFIG.Encryption.IndexKey))
+	mac.Write([]byte(s))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func aead(version string) (cipher.AEAD, error) {
+	for _, k := range global.CONFIG.Encryption.Keys {
+		if k.Version != version {
+			continue
+		}
+		key, err := base64.StdEncoding.DecodeString(k.Key)
+		if err != nil {
+			return nil, fmt.Errorf("加密密钥 %s 不是有效的 base64: %w", version, err)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewGCM(block)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownKey, version)
+}
+
+// Serializer 字符串字段的加密序列化器
+type Serializer struct{}
+
+// Scan 读取时解密
+func (Serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue any) error {
+	var s string
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("字段 %s 不支持解密 %T", field.Name, dbValue)
+	}
+	plain, err := Decrypt(s)
+	if err != nil {
+		return fmt.Errorf("字段 %s 解密失败: %w", field.Name, err)
+	}
+	return field.Set(ctx, dst, plain)
+}
+
+// Value 写入时加密
+func (Serializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue any) (any, error) {
+	s, ok := fieldValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("字段 %s 不支持加密 %T", field.Name, fieldValue)
+	}
+	return Encrypt(s)
+}
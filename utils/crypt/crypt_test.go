@@ -0,0 +1,74 @@
+package crypt
+
+import (
+	"strings"
+	"testing"
+
+	"server/config"
+	global "server/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupKeys(t *testing.T, current string) {
+	old := global.CONFIG.Encryption
+	global.CONFIG.Encryption = config.Encryption{
+		Current: current,
+		Keys: []config.EncryptionKey{
+			{Version: "v1", Key: "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="},
+			{Version: "v2", Key: "ZmVkY2JhOTg3NjU0MzIxMGZlZGNiYTk4NzY1NDMyMTA="},
+		},
+		IndexKey: "index",
+	}
+	t.Cleanup(func() { global.CONFIG.Encryption = old })
+}
+
+func TestEncrypt(t *testing.T) {
+	setupKeys(t, "v1")
+
+	enc, err := Encrypt("13800008000")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(enc, "enc:v1:"))
+	assert.Equal(t, "v1", Version(enc))
+	other, _ := Encrypt("13800008000")
+	assert.NotEqual(t, enc, other, "每次加密使用随机 nonce")
+
+	plain, err := Decrypt(enc)
+	require.NoError(t, err)
+	assert.Equal(t, "13800008000", plain)
+
+	// 轮换后旧版本的密文仍可解密
+	global.CONFIG.Encryption.Current = "v2"
+	plain, err = Decrypt(enc)
+	require.NoError(t, err)
+	assert.Equal(t, "13800008000", plain)
+
+	// 未加密的旧数据原样返回
+	plain, err = Decrypt("13800008000")
+	require.NoError(t, err)
+	assert.Equal(t, "13800008000", plain)
+
+	_, err = Decrypt(enc[:len(enc)-4] + "AAAA")
+	assert.ErrorIs(t, err, ErrCiphertext)
+	_, err = Decrypt("enc:v3:" + enc[len("enc:v1:"):])
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	// 未开启加密时按明文存储
+	global.CONFIG.Encryption.Current = ""
+	enc, err = Encrypt("13800008000")
+	require.NoError(t, err)
+	assert.Equal(t, "13800008000", enc)
+}
+
+func TestBlindIndex(t *testing.T) {
+	setupKeys(t, "v1")
+	idx := BlindIndex("Admin@Example.com ")
+	assert.Len(t, idx, 64)
+	assert.Equal(t, idx, BlindIndex("admin@example.com"))
+	assert.NotEqual(t, idx, BlindIndex("other@example.com"))
+	assert.Empty(t, BlindIndex(" "))
+
+	global.CONFIG.Encryption.IndexKey = "rotated"
+	assert.NotEqual(t, idx, BlindIndex("admin@example.com"))
+}
@@ -78,6 +78,8 @@ func AesDeCrypt(cypted []byte, key []byte) ([]byte, error) {
 }
 
 // 加密base64
+//
+// Deprecated: PwdKey 为硬编码密钥且 CBC 模式以密钥作为 IV, 敏感字段请使用 server/utils/crypt
 func EnPwdCode(pwd []byte) (string, error) {
 	result, err := AesEcrypt(pwd, PwdKey)
 	if err != nil {
@@ -87,6 +89,8 @@ func EnPwdCode(pwd []byte) (string, error) {
 }
 
 // 解密
+//
+// Deprecated: 见 EnPwdCode
 func DePwdCode(pwd string) ([]byte, error) {
 	//解密base64字符串
 	pwdByte, err := base64.StdEncoding.DecodeString(pwd)
@@ -12,15 +12,43 @@ import (
 type JWT struct {
 	PrivateKey *rsa.PrivateKey
 	PublicKey  *rsa.PublicKey
+	Kid        string                    // 签名使用的 kid
+	PublicKeys map[string]*rsa.PublicKey // 验签可用的公钥
 }
 
 func NewJWT() *JWT {
 	return &JWT{
 		PrivateKey: global.RunCONFIG.JWT.PrivateKey,
 		PublicKey:  global.RunCONFIG.JWT.PublicKey,
+		Kid:        global.RunCONFIG.JWT.Kid,
+		PublicKeys: global.RunCONFIG.JWT.PublicKeys,
 	}
 }
 
+// KeyFunc 按 token 头部的 kid 选择验签公钥, 没有 kid 的旧 token 使用当前公钥
+func (j *JWT) KeyFunc(token *jwt.Token) (any, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+		return nil, jwt.ErrTokenSignatureInvalid
+	}
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return j.PublicKey, nil
+	}
+	if key, ok := j.PublicKeys[kid]; ok {
+		return key, nil
+	}
+	return nil, jwt.ErrTokenUnverifiable
+}
+
+// Sign 使用当前 key 签名并写入 kid, 各端自定义的 claims 都通过这里签发
+func (j *JWT) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims) // 使用RS256算法
+	if j.Kid != "" {
+		token.Header["kid"] = j.Kid
+	}
+	return token.SignedString(j.PrivateKey)
+}
+
 func (j *JWT) CreateClaims(baseClaims request.BaseClaims) request.CustomClaims {
 	claims := request.CustomClaims{
 		BaseClaims: baseClaims,
@@ -31,10 +59,10 @@ func (j *JWT) CreateClaims(baseClaims request.BaseClaims) request.CustomClaims {
 		// 	Issuer:    global.CONFIG.JWT.Issuer,                          // 签名的发行者
 		// },
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(global.CONFIG.JWT.ExpiresTime) * time.Hour)), // 过期时间
-			IssuedAt:  jwt.NewNumericDate(time.Now()),                                                               // 签发时间
-			NotBefore: jwt.NewNumericDate(time.Now()),                                                               // 生效时间
-			Issuer:    global.CONFIG.JWT.Issuer,                                                                     // 签名的发行者
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(global.CONFIG.JWT.AccessExpires())), // 过期时间
+			IssuedAt:  jwt.NewNumericDate(time.Now()),                                        // 签发时间
+			NotBefore: jwt.NewNumericDate(time.Now()),                                        // 生效时间
+			Issuer:    global.CONFIG.JWT.Issuer,                                              // 签名的发行者
 		},
 	}
 	return claims
@@ -42,8 +70,7 @@ func (j *JWT) CreateClaims(baseClaims request.BaseClaims) request.CustomClaims {
 
 // 创建一个token
 func (j *JWT) CreateToken(claims request.CustomClaims) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims) // 使用RS256算法
-	return token.SignedString(j.PrivateKey)
+	return j.Sign(claims)
 }
 
 // CreateTokenByOldToken 旧token 换新token 使用归并回源避免并发问题
@@ -56,9 +83,7 @@ func (j *JWT) CreateToken(claims request.CustomClaims) (string, error) {
 
 // 解析 token 校验token
 func (j *JWT) ParseToken(tokenString string) (*request.CustomClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &request.CustomClaims{}, func(token *jwt.Token) (i any, e error) {
-		return j.PublicKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &request.CustomClaims{}, j.KeyFunc)
 	if err != nil {
 		return nil, ReportError(err)
 	}
@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"server/config"
+	global "server/model"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+// JSONWebKey RSA 公钥的 JWK 表示
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// JSONWebKeySet /.well-known/jwks.json 返回结构
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// LoadJWTKeys 从配置加载 RS256 密钥
+// 退役的 key 直接忽略; 没有任何 key 时返回错误, 由调用方决定是否生成临时 key
+func LoadJWTKeys(c config.PrivacyJWT) (config.JWTConfig, error) {
+	keys := config.JWTConfig{PublicKeys: map[string]*rsa.PublicKey{}}
+	privateKeys := map[string]*rsa.PrivateKey{}
+	var firstSigner string
+	for i, k := range c.Keys {
+		if k.Retired {
+			continue
+		}
+		private, public, err := parseJWTKey(k)
+		if err != nil {
+			return keys, fmt.Errorf("jwt.keys[%d]: %w", i, err)
+		}
+		kid := k.Kid
+		if kid == "" {
+			kid = JWKThumbprint(public)
+		}
+		if _, ok := keys.PublicKeys[kid]; ok {
+			return keys, fmt.Errorf("jwt.keys[%d]: kid %s 重复", i, kid)
+		}
+		keys.PublicKeys[kid] = public
+		if private != nil {
+			privateKeys[kid] = private
+			if firstSigner == "" {
+				firstSigner = kid
+			}
+		}
+	}
+	if len(keys.PublicKeys) == 0 {
+		return keys, errors.New("未配置可用的 jwt 密钥")
+	}
+	keys.Kid = c.ActiveKid
+	if keys.Kid == "" {
+		keys.Kid = firstSigner
+	}
+	private, ok := privateKeys[keys.Kid]
+	if !ok {
+		return keys, fmt.Errorf("jwt 签名 key %q 不存在、已退役或没有私钥", keys.Kid)
+	}
+	keys.PrivateKey, keys.PublicKey = private, &private.PublicKey
+	return keys, nil
+}
+
+// GenerateJWTKeys 生成临时 RS256 密钥, 仅用于未配置密钥的本地开发, 重启后已签发的 token 全部失效
+func GenerateJWTKeys() (config.JWTConfig, error) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return config.JWTConfig{}, err
+	}
+	kid := JWKThumbprint(&private.PublicKey)
+	return config.JWTConfig{
+		PrivateKey: private,
+		PublicKey:  &private.PublicKey,
+		Kid:        kid,
+		PublicKeys: map[string]*rsa.PublicKey{kid: &private.PublicKey},
+	}, nil
+}
+
+func parseJWTKey(k config.JWTKey) (private *rsa.PrivateKey, public *rsa.PublicKey, err error) {
+	privatePEM, err := pemContent(k.PrivateKey, k.PrivateKeyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if privatePEM != nil {
+		if private, err = jwt.ParseRSAPrivateKeyFromPEM(privatePEM); err != nil {
+			return nil, nil, err
+		}
+		return private, &private.PublicKey, nil
+	}
+	publicPEM, err := pemContent(k.PublicKey, k.PublicKeyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if publicPEM == nil {
+		return nil, nil, errors.New("未配置私钥或公钥")
+	}
+	public, err = jwt.ParseRSAPublicKeyFromPEM(publicPEM)
+	return nil, public, err
+}
+
+func pemContent(content, file string) ([]byte, error) {
+	if content != "" {
+		return []byte(content), nil
+	}
+	if file == "" {
+		return nil, nil
+	}
+	return os.ReadFile(file)
+}
+
+// JWKThumbprint RFC 7638 公钥指纹, 用作默认 kid
+func JWKThumbprint(public *rsa.PublicKey) string {
+	n, e := jwkBase64(public)
+	sum := sha256.Sum256([]byte(`{"e":"` + e + `","kty":"RSA","n":"` + n + `"}`))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func jwkBase64(public *rsa.PublicKey) (n, e string) {
+	return base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+}
+
+// JWKS 当前所有可用于验签的公钥
+func JWKS() JSONWebKeySet {
+	set := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for kid, public := range global.RunCONFIG.JWT.PublicKeys {
+		n, e := jwkBase64(public)
+		set.Keys = append(set.Keys, JSONWebKey{Kty: "RSA", Use: "sig", Alg: jwt.SigningMethodRS256.Alg(), Kid: kid, N: n, E: e})
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+	return set
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"server/config"
+	global "server/model"
+	"server/model/system/request"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKeyPEM(t *testing.T) (privatePEM, publicPEM string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	pub, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub}))
+}
+
+func TestLoadJWTKeys(t *testing.T) {
+	oldPrivate, oldPublic := newTestKeyPEM(t)
+	newPrivate, _ := newTestKeyPEM(t)
+
+	t.Run("未配置密钥", func(t *testing.T) {
+		_, err := LoadJWTKeys(config.PrivacyJWT{})
+		assert.Error(t, err)
+	})
+
+	t.Run("默认使用第一个带私钥的key", func(t *testing.T) {
+		keys, err := LoadJWTKeys(config.PrivacyJWT{Keys: []config.JWTKey{
+			{Kid: "old", PublicKey: oldPublic},
+			{Kid: "new", PrivateKey: newPrivate},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, "new", keys.Kid)
+		assert.Len(t, keys.PublicKeys, 2)
+	})
+
+	t.Run("active-kid没有私钥", func(t *testing.T) {
+		_, err := LoadJWTKeys(config.PrivacyJWT{ActiveKid: "old", Keys: []config.JWTKey{{Kid: "old", PublicKey: oldPublic}}})
+		assert.Error(t, err)
+	})
+
+	t.Run("kid为空时使用指纹", func(t *testing.T) {
+		keys, err := LoadJWTKeys(config.PrivacyJWT{Keys: []config.JWTKey{{PrivateKey: oldPrivate}}})
+		require.NoError(t, err)
+		assert.Equal(t, JWKThumbprint(keys.PublicKey), keys.Kid)
+	})
+}
+
+func TestJWTKeyRotation(t *testing.T) {
+	oldPrivate, _ := newTestKeyPEM(t)
+	newPrivate, _ := newTestKeyPEM(t)
+	defer func(c config.JWTConfig) { global.RunCONFIG.JWT = c }(global.RunCONFIG.JWT)
+	global.CONFIG.JWT.AccessExpiresMinutes = 1
+
+	use := func(active string, keys ...config.JWTKey) {
+		c, err := LoadJWTKeys(config.PrivacyJWT{ActiveKid: active, Keys: keys})
+		require.NoError(t, err)
+		global.RunCONFIG.JWT = c
+	}
+	use("old", config.JWTKey{Kid: "old", PrivateKey: oldPrivate})
+	j := NewJWT()
+	oldToken, err := j.CreateToken(j.CreateClaims(request.BaseClaims{ID: 1, Username: "admin"}))
+	require.NoError(t, err)
+
+	t.Run("轮换后旧token仍然有效", func(t *testing.T) {
+		use("new", config.JWTKey{Kid: "new", PrivateKey: newPrivate}, config.JWTKey{Kid: "old", PrivateKey: oldPrivate})
+		claims, err := NewJWT().ParseToken(oldToken)
+		require.NoError(t, err)
+		assert.Equal(t, "admin", claims.Username)
+
+		set := JWKS()
+		require.Len(t, set.Keys, 2)
+		assert.Equal(t, "RS256", set.Keys[0].Alg)
+	})
+
+	t.Run("退役后旧token失效", func(t *testing.T) {
+		use("new", config.JWTKey{Kid: "new", PrivateKey: newPrivate}, config.JWTKey{Kid: "old", PrivateKey: oldPrivate, Retired: true})
+		_, err := NewJWT().ParseToken(oldToken)
+		assert.Error(t, err)
+	})
+}
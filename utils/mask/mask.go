@@ -0,0 +1,192 @@
+// Package mask 按 `mask` 标签对模型中的敏感字段脱敏
+//
+// 字段通过 `mask:"phone"` 一类的标签声明脱敏方式, 策略按 模型名.字段名 决定显示方式:
+// full 原样显示, partial 部分显示(如 138****8000), hidden 不显示
+package mask
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// 显示方式
+const (
+	Full    = "full"
+	Partial = "partial"
+	Hidden  = "hidden"
+)
+
+// 标签取值, 决定 partial 的显示效果
+const (
+	KindPhone = "phone"
+	KindEmail = "email"
+	KindName  = "name"
+)
+
+// LocalsKey 中间件把当前请求的策略放在 fiber Locals 中的 key
+const LocalsKey = "masking"
+
+// Policy 模型名.字段名 -> 显示方式, 模型名.* 和 * 作为通配, 未匹配的字段原样显示
+type Policy map[string]string
+
+func (p Policy) mode(model, field string) string {
+	if m, ok := p[model+"."+field]; ok {
+		return m
+	}
+	if m, ok := p[model+".*"]; ok {
+		return m
+	}
+	if m, ok := p["*"]; ok {
+		return m
+	}
+	return Full
+}
+
+// Apply 返回脱敏后的数据, 需要脱敏的部分会复制一份, 不修改传入的数据
+func Apply(data any, p Policy) any {
+	if data == nil || len(p) == 0 {
+		return data
+	}
+	v := reflect.ValueOf(data)
+	if !needMask(v.Type()) {
+		return data
+	}
+	return apply(v, p).Interface()
+}
+
+// String 按标签和显示方式处理单个值
+func String(kind, s, mode string) string {
+	switch mode {
+	case Hidden:
+		return ""
+	case Partial:
+	default:
+		return s
+	}
+	if s == "" {
+		return s
+	}
+	switch kind {
+	case KindPhone:
+		return keep(s, 3, 4)
+	case KindEmail:
+		at := strings.LastIndex(s, "@")
+		if at <= 0 {
+			return keep(s, 1, 0)
+		}
+		return keep(s[:at], 1, 0) + s[at:]
+	case KindName:
+		return keep(s, 1, 0)
+	default:
+		n := utf8.RuneCountInString(s) / 4
+		return keep(s, n, n)
+	}
+}
+
+// keep 保留前 head 个和后 tail 个字符, 中间替换为 *, 字符串过短时只保留第一个字符
+func keep(s string, head, tail int) string {
+	r := []rune(s)
+	if head+tail >= len(r) {
+		head, tail = 1, 0
+		if len(r) <= 1 {
+			return "*"
+		}
+	}
+	return string(r[:head]) + strings.Repeat("*", len(r)-head-tail) + string(r[len(r)-tail:])
+}
+
+var needCache sync.Map // reflect.Type -> bool
+
+// needMask 类型中是否包含带 mask 标签的字段
+func needMask(t reflect.Type) bool {
+	if v, ok := needCache.Load(t); ok {
+		return v.(bool)
+	}
+	// 自引用的类型(如评论的子评论)先按需要处理, 避免无限递归
+	needCache.Store(t, true)
+	need := false
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		need = needMask(t.Elem())
+	case reflect.Map:
+		need = needMask(t.Elem())
+	case reflect.Interface:
+		need = true
+	case reflect.Struct:
+		for i := 0; i < t.NumField() && !need; i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			need = (f.Tag.Get("mask") != "" && f.Type.Kind() == reflect.String) || needMask(f.Type)
+		}
+	}
+	needCache.Store(t, need)
+	return need
+}
+
+func apply(v reflect.Value, p Policy) reflect.Value {
+	if !needMask(v.Type()) {
+		return v
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		n := reflect.New(v.Type().Elem())
+		n.Elem().Set(apply(v.Elem(), p))
+		return n
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		n := reflect.New(v.Type()).Elem()
+		n.Set(apply(v.Elem(), p))
+		return n
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		n := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			n.Index(i).Set(apply(v.Index(i), p))
+		}
+		return n
+	case reflect.Array:
+		n := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			n.Index(i).Set(apply(v.Index(i), p))
+		}
+		return n
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		n := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			n.SetMapIndex(iter.Key(), apply(iter.Value(), p))
+		}
+		return n
+	case reflect.Struct:
+		t := v.Type()
+		n := reflect.New(t).Elem()
+		n.Set(v)
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			if kind := f.Tag.Get("mask"); kind != "" && f.Type.Kind() == reflect.String {
+				n.Field(i).SetString(String(kind, v.Field(i).String(), p.mode(t.Name(), f.Name)))
+			} else if needMask(f.Type) {
+				n.Field(i).Set(apply(v.Field(i), p))
+			}
+		}
+		return n
+	}
+	return v
+}
@@ -0,0 +1,65 @@
+package mask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type maskUser struct {
+	ID       uint
+	Phone    string `mask:"phone"`
+	Email    string `mask:"email"`
+	Realname string `mask:"name"`
+}
+
+type maskComment struct {
+	Content  string
+	User     maskUser
+	Children []maskComment
+	Parent   *maskUser
+}
+
+func TestString(t *testing.T) {
+	for _, c := range []struct {
+		kind, in, mode, out string
+	}{
+		{KindPhone, "13800008000", Partial, "138****8000"},
+		{KindPhone, "13800008000", Full, "13800008000"},
+		{KindPhone, "13800008000", Hidden, ""},
+		{KindPhone, "123", Partial, "1**"},
+		{KindEmail, "admin@example.com", Partial, "a****@example.com"},
+		{KindName, "张三丰", Partial, "张**"},
+		{"", "abcdefgh", Partial, "ab****gh"},
+		{KindName, "", Partial, ""},
+	} {
+		assert.Equal(t, c.out, String(c.kind, c.in, c.mode), "%s %s %s", c.kind, c.in, c.mode)
+	}
+}
+
+func TestApply(t *testing.T) {
+	users := []maskUser{{ID: 1, Phone: "13800008000", Email: "admin@example.com", Realname: "张三"}}
+	policy := Policy{"maskUser.Phone": Partial, "maskUser.*": Hidden}
+
+	masked := Apply(users, policy).([]maskUser)
+	assert.Equal(t, maskUser{ID: 1, Phone: "138****8000"}, masked[0])
+	// 不修改原数据
+	assert.Equal(t, "13800008000", users[0].Phone)
+
+	// 嵌套在 any、指针和自引用类型中的字段
+	comments := []maskComment{{
+		Content:  "hi",
+		User:     users[0],
+		Children: []maskComment{{User: users[0]}},
+		Parent:   &users[0],
+	}}
+	var list any = comments
+	out := Apply(map[string]any{"list": list}, Policy{"*": Partial}).(map[string]any)["list"].([]maskComment)
+	assert.Equal(t, "hi", out[0].Content)
+	assert.Equal(t, "138****8000", out[0].User.Phone)
+	assert.Equal(t, "138****8000", out[0].Children[0].User.Phone)
+	assert.Equal(t, "张*", out[0].Parent.Realname)
+	assert.Equal(t, "张三", users[0].Realname)
+
+	assert.Equal(t, comments, Apply(comments, nil))
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	global "server/model"
 	"server/model/mobile"
+	"server/model/system"
 	"time"
 
 	jwt "github.com/golang-jwt/jwt/v5"
@@ -16,34 +17,32 @@ type MobileClaims struct {
 	jwt.RegisteredClaims        // 注意!这是jwt-go的v4版本新增的，原先是jwt.StandardClaims
 }
 
-func (j *JWT) MakeToken(data mobile.Login, id uint) (tokenString string, expiresAt int64, err error) {
+// MakeToken jti 为会话id, 同一次登录刷新后签发的 token 沿用同一个 jti
+func (j *JWT) MakeToken(data mobile.Login, id uint, jti string) (tokenString string, expiresAt int64, err error) {
 	claim := MobileClaims{
 		ID:       id,
 		Username: data.Username,
 		Realname: data.Realname,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)), // 过期时间24小时
-			IssuedAt:  jwt.NewNumericDate(time.Now()),                     // 签发时间
-			NotBefore: jwt.NewNumericDate(time.Now()),                     // 生效时间
+			ID:        jti,
+			Audience:  jwt.ClaimStrings{system.RefreshChannelMobile},                         // 移动端令牌不能用于后台和前台接口
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(global.CONFIG.JWT.AccessExpires())), // 过期时间
+			IssuedAt:  jwt.NewNumericDate(time.Now()),                                        // 签发时间
+			NotBefore: jwt.NewNumericDate(time.Now()),                                        // 生效时间
 			Issuer:    global.CONFIG.JWT.Issuer,
 		},
 	}
 	// token := jwt.NewWithClaims(jwt.SigningMethodHS256, claim) // 使用HS256算法
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claim) // 使用RS256算法
-	tokenString, err = token.SignedString(j.PrivateKey)
+	tokenString, err = j.Sign(claim)
 	return tokenString, claim.ExpiresAt.Unix(), err
 }
 
 func (j *JWT) Secret() jwt.Keyfunc {
-	return func(token *jwt.Token) (any, error) {
-		return j.PublicKey, nil // 这是我的secret
-	}
+	return j.KeyFunc
 }
 
 func (j *JWT) ParseTokenMobile(tokenss string) (*MobileClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenss, &MobileClaims{}, func(token *jwt.Token) (any, error) {
-		return j.PublicKey, nil // 这是我的secret
-	})
+	token, err := jwt.ParseWithClaims(tokenss, &MobileClaims{}, j.KeyFunc)
 	if err != nil {
 		// return nil, err
 		// if ve, ok := err.(*jwt.ValidationError); ok {
@@ -0,0 +1,329 @@
+package utils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"server/config"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrOidcDiscovery = errors.New("获取身份提供方配置失败")
+	ErrOidcExchange  = errors.New("授权码换取令牌失败")
+	ErrOidcIDToken   = errors.New("id_token 校验失败")
+)
+
+// jwks 中没有对应 kid 时重新拉取的最小间隔, 避免被伪造的 kid 打满
+const oidcJwksRefresh = time.Minute
+
+// OidcClient OpenID Connect 授权码 + PKCE 客户端
+// 未配置各端点时首次使用从 issuer 的发现文档获取, 签名公钥按 kid 缓存, 遇到未知 kid 时重新拉取 jwks
+type OidcClient struct {
+	Provider   config.OidcProvider
+	HTTPClient *http.Client
+
+	mu            sync.Mutex
+	discovered    bool
+	keys          map[string]any
+	keysFetchedAt time.Time
+}
+
+// OidcIdentity 校验通过的 id_token 中的用户信息
+type OidcIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Claims        jwt.MapClaims
+}
+
+// Claim 读取字符串类型的 claim
+func (i OidcIdentity) Claim(name string) string {
+	s, _ := i.Claims[name].(string)
+	return s
+}
+
+func NewOidcClient(p config.OidcProvider) *OidcClient {
+	return &OidcClient{Provider: p, HTTPClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// PkceChallenge 按 S256 计算 code_challenge
+func PkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// AuthCodeURL 生成跳转到身份提供方的授权地址
+func (o *OidcClient) AuthCodeURL(ctx context.Context, redirectURL, state, nonce, verifier string) (string, error) {
+	if err := o.discover(ctx); err != nil {
+		return "", err
+	}
+	scopes := o.Provider.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "profile", "email"}
+	}
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {o.Provider.ClientId},
+		"redirect_uri":          {redirectURL},
+		"scope":                 {strings.Join(scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {PkceChallenge(verifier)},
+		"code_challenge_method": {"S256"},
+	}
+	sep := "?"
+	if strings.Contains(o.Provider.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return o.Provider.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+// Exchange 用授权码换取并校验 id_token
+func (o *OidcClient) Exchange(ctx context.Context, redirectURL, code, verifier, nonce string) (identity OidcIdentity, err error) {
+	if err = o.discover(ctx); err != nil {
+		return
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURL},
+		"client_id":     {o.Provider.ClientId},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.Provider.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if o.Provider.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(o.Provider.ClientId), url.QueryEscape(o.Provider.ClientSecret))
+	}
+	var token struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := o.doJSON(req, &token)
+	if err != nil {
+		return identity, fmt.Errorf("%w: %v", ErrOidcExchange, err)
+	}
+	if status != http.StatusOK || token.IDToken == "" {
+		return identity, fmt.Errorf("%w: %d %s %s", ErrOidcExchange, status, token.Error, token.ErrorDescription)
+	}
+	return o.VerifyIDToken(ctx, token.IDToken, nonce)
+}
+
+// VerifyIDToken 校验 id_token 的签名、issuer、audience、有效期和 nonce
+func (o *OidcClient) VerifyIDToken(ctx context.Context, raw, nonce string) (identity OidcIdentity, err error) {
+	if err = o.discover(ctx); err != nil {
+		return
+	}
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(raw, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return o.key(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(o.Provider.Issuer),
+		jwt.WithAudience(o.Provider.ClientId),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return identity, fmt.Errorf("%w: %v", ErrOidcIDToken, err)
+	}
+	identity.Claims = claims
+	if identity.Claim("nonce") != nonce {
+		return identity, fmt.Errorf("%w: nonce 不匹配", ErrOidcIDToken)
+	}
+	if identity.Subject = identity.Claim("sub"); identity.Subject == "" {
+		return identity, fmt.Errorf("%w: 缺少 sub", ErrOidcIDToken)
+	}
+	identity.Email = identity.Claim("email")
+	identity.Name = identity.Claim("name")
+	// 部分身份提供方把 email_verified 返回为字符串
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = v
+	case string:
+		identity.EmailVerified = v == "true"
+	}
+	return identity, nil
+}
+
+func (o *OidcClient) discover(ctx context.Context) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	p := &o.Provider
+	if o.discovered || (p.AuthorizationEndpoint != "" && p.TokenEndpoint != "" && p.JwksURI != "") {
+		o.discovered = true
+		return nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(p.Issuer, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return err
+	}
+	var doc struct {
+		Issuer                string `json:"issuer"`
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+		JwksURI               string `json:"jwks_uri"`
+	}
+	status, err := o.doJSON(req, &doc)
+	if err != nil || status != http.StatusOK {
+		return fmt.Errorf("%w: %d %v", ErrOidcDiscovery, status, err)
+	}
+	if doc.Issuer != p.Issuer {
+		return fmt.Errorf("%w: issuer 不匹配 %s", ErrOidcDiscovery, doc.Issuer)
+	}
+	if p.AuthorizationEndpoint == "" {
+		p.AuthorizationEndpoint = doc.AuthorizationEndpoint
+	}
+	if p.TokenEndpoint == "" {
+		p.TokenEndpoint = doc.TokenEndpoint
+	}
+	if p.JwksURI == "" {
+		p.JwksURI = doc.JwksURI
+	}
+	if p.AuthorizationEndpoint == "" || p.TokenEndpoint == "" || p.JwksURI == "" {
+		return fmt.Errorf("%w: 发现文档缺少端点", ErrOidcDiscovery)
+	}
+	o.discovered = true
+	return nil
+}
+
+func (o *OidcClient) key(ctx context.Context, kid string) (any, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if k, ok := o.keys[kid]; ok {
+		return k, nil
+	}
+	// 只有一个 key 且 token 没有 kid 时直接使用
+	if kid == "" && len(o.keys) == 1 {
+		for _, k := range o.keys {
+			return k, nil
+		}
+	}
+	if time.Since(o.keysFetchedAt) < oidcJwksRefresh {
+		return nil, fmt.Errorf("未知的 kid: %s", kid)
+	}
+	o.keysFetchedAt = time.Now()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.Provider.JwksURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+			Crv string `json:"crv"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		} `json:"keys"`
+	}
+	if status, err := o.doJSON(req, &set); err != nil || status != http.StatusOK {
+		return nil, fmt.Errorf("获取 jwks 失败: %d %v", status, err)
+	}
+	o.keys = map[string]any{}
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		var public any
+		var err error
+		switch k.Kty {
+		case "RSA":
+			public, err = jwkRSA(k.N, k.E)
+		case "EC":
+			public, err = jwkEC(k.Crv, k.X, k.Y)
+		default:
+			continue
+		}
+		if err == nil {
+			o.keys[k.Kid] = public
+		}
+	}
+	if k, ok := o.keys[kid]; ok {
+		return k, nil
+	}
+	if kid == "" && len(o.keys) == 1 {
+		for _, k := range o.keys {
+			return k, nil
+		}
+	}
+	return nil, fmt.Errorf("未知的 kid: %s", kid)
+}
+
+func (o *OidcClient) doJSON(req *http.Request, v any) (int, error) {
+	res, err := o.HTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return res.StatusCode, err
+	}
+	return res.StatusCode, json.Unmarshal(body, v)
+}
+
+func jwkRSA(n, e string) (*rsa.PublicKey, error) {
+	nb, err := base64.RawURLEncoding.DecodeString(n)
+	if err != nil {
+		return nil, err
+	}
+	eb, err := base64.RawURLEncoding.DecodeString(e)
+	if err != nil || len(eb) == 0 || len(eb) > 4 {
+		return nil, errors.New("jwk e 无效")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(nb), E: int(new(big.Int).SetBytes(eb).Int64())}, nil
+}
+
+func jwkEC(crv, x, y string) (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("不支持的曲线: %s", crv)
+	}
+	xb, err := base64.RawURLEncoding.DecodeString(x)
+	if err != nil {
+		return nil, err
+	}
+	yb, err := base64.RawURLEncoding.DecodeString(y)
+	if err != nil {
+		return nil, err
+	}
+	public := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(xb), Y: new(big.Int).SetBytes(yb)}
+	if !curve.IsOnCurve(public.X, public.Y) {
+		return nil, errors.New("jwk 坐标不在曲线上")
+	}
+	return public, nil
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"server/config"
+	"server/utils/oidctest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const oidcRedirect = "http://localhost/oidc/callback"
+
+func TestOidcClientExchange(t *testing.T) {
+	idp := oidctest.NewProvider("server")
+	defer idp.Close()
+	idp.ClientSecret = "secret"
+	idp.Login(map[string]any{"sub": "u-1", "email": "a@example.com", "email_verified": "true", "preferred_username": "alice"})
+
+	client := NewOidcClient(config.OidcProvider{Issuer: idp.Issuer(), ClientId: "server", ClientSecret: "secret"})
+	ctx := context.Background()
+	authURL, err := client.AuthCodeURL(ctx, oidcRedirect, "state-1", "nonce-1", "verifier-verifier-verifier-verifier-43")
+	require.NoError(t, err)
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+	assert.Equal(t, "openid profile email", u.Query().Get("scope"))
+
+	code, state, err := idp.Authorize(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, "state-1", state)
+
+	// code_verifier 不匹配
+	_, err = client.Exchange(ctx, oidcRedirect, code, "wrong-verifier", "nonce-1")
+	assert.ErrorIs(t, err, ErrOidcExchange)
+
+	code, _, err = idp.Authorize(authURL)
+	require.NoError(t, err)
+	identity, err := client.Exchange(ctx, oidcRedirect, code, "verifier-verifier-verifier-verifier-43", "nonce-1")
+	require.NoError(t, err)
+	assert.Equal(t, "u-1", identity.Subject)
+	assert.Equal(t, "a@example.com", identity.Email)
+	assert.True(t, identity.EmailVerified)
+	assert.Equal(t, "alice", identity.Claim("preferred_username"))
+}
+
+func TestOidcClientVerifyIDToken(t *testing.T) {
+	idp := oidctest.NewProvider("server")
+	defer idp.Close()
+	client := NewOidcClient(config.OidcProvider{Issuer: idp.Issuer(), ClientId: "server"})
+	ctx := context.Background()
+
+	for name, claims := range map[string]map[string]any{
+		"audience 不匹配": {"sub": "u", "aud": "other"},
+		"issuer 不匹配":   {"sub": "u", "iss": "https://evil"},
+		"已过期":          {"sub": "u", "exp": time.Now().Add(-time.Hour).Unix()},
+		"缺少 sub":       {"sub": ""},
+	} {
+		raw, err := idp.IDToken("n", claims)
+		require.NoError(t, err, name)
+		_, err = client.VerifyIDToken(ctx, raw, "n")
+		assert.ErrorIs(t, err, ErrOidcIDToken, name)
+	}
+
+	raw, err := idp.IDToken("n", map[string]any{"sub": "u"})
+	require.NoError(t, err)
+	_, err = client.VerifyIDToken(ctx, raw, "other")
+	assert.ErrorContains(t, err, "nonce")
+	identity, err := client.VerifyIDToken(ctx, raw, "n")
+	require.NoError(t, err)
+	assert.Equal(t, "u", identity.Subject)
+}
@@ -0,0 +1,178 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"server/config"
+	global "server/model"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// 密码哈希算法
+const (
+	PasswordArgon2id = "argon2id"
+	PasswordBcrypt   = "bcrypt"
+)
+
+const (
+	argon2SaltLen = 16
+	argon2KeyLen  = 32
+)
+
+var (
+	ErrPasswordEmpty     = errors.New("密码不能为空")
+	ErrPasswordAlgorithm = errors.New("不支持的密码哈希算法")
+
+	legacySha512 = regexp.MustCompile(`^[0-9a-f]{128}$`)
+	b64          = base64.RawStdEncoding
+)
+
+// PasswordHasher 统一的用户密码哈希
+// 新密码按配置使用 argon2id($argon2id$v=19$m=..,t=..,p=..$salt$hash) 或 bcrypt($2a$..),
+// 校验时兼容历史遗留的无盐 sha512 和明文, 这两种以及参数过期的哈希都会提示需要重新哈希
+type PasswordHasher struct {
+	Algorithm     string
+	BcryptCost    int
+	Argon2Memory  uint32
+	Argon2Time    uint32
+	Argon2Threads uint8
+}
+
+// NewPasswordHasher 按配置创建, 未配置的参数使用默认值
+func NewPasswordHasher() *PasswordHasher {
+	return newPasswordHasher(global.CONFIG.Password)
+}
+
+func newPasswordHasher(c config.Password) *PasswordHasher {
+	h := &PasswordHasher{
+		Algorithm:     c.Algorithm,
+		BcryptCost:    c.BcryptCost,
+		Argon2Memory:  c.Argon2Memory,
+		Argon2Time:    c.Argon2Time,
+		Argon2Threads: c.Argon2Threads,
+	}
+	if h.Algorithm == "" {
+		h.Algorithm = PasswordArgon2id
+	}
+	if h.BcryptCost == 0 {
+		h.BcryptCost = 12
+	}
+	if h.Argon2Memory == 0 {
+		h.Argon2Memory = 64 * 1024
+	}
+	if h.Argon2Time == 0 {
+		h.Argon2Time = 3
+	}
+	if h.Argon2Threads == 0 {
+		h.Argon2Threads = 2
+	}
+	return h
+}
+
+// Hash 生成带随机盐和算法前缀的密码哈希
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	if password == "" {
+		return "", ErrPasswordEmpty
+	}
+	switch h.Algorithm {
+	case PasswordArgon2id:
+		salt := make([]byte, argon2SaltLen)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+		key := argon2.IDKey([]byte(password), salt, h.Argon2Time, h.Argon2Memory, h.Argon2Threads, argon2KeyLen)
+		return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+			argon2.Version, h.Argon2Memory, h.Argon2Time, h.Argon2Threads, b64.EncodeToString(salt), b64.EncodeToString(key)), nil
+	case PasswordBcrypt:
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), h.BcryptCost)
+		return string(hash), err
+	default:
+		return "", fmt.Errorf("%w: %s", ErrPasswordAlgorithm, h.Algorithm)
+	}
+}
+
+// Verify 校验密码, needsRehash 为 true 时调用方应在校验通过后用 Hash 的结果更新数据库
+func (h *PasswordHasher) Verify(hashed, password string) (ok bool, needsRehash bool) {
+	if hashed == "" || password == "" {
+		return false, false
+	}
+	switch {
+	case strings.HasPrefix(hashed, "$argon2id$"):
+		p, ok := parseArgon2id(hashed)
+		if !ok {
+			return false, false
+		}
+		key := argon2.IDKey([]byte(password), p.salt, p.time, p.memory, p.threads, uint32(len(p.key)))
+		if subtle.ConstantTimeCompare(key, p.key) != 1 {
+			return false, false
+		}
+		return true, h.Algorithm != PasswordArgon2id || p.memory != h.Argon2Memory || p.time != h.Argon2Time ||
+			p.threads != h.Argon2Threads || len(p.salt) != argon2SaltLen || len(p.key) != argon2KeyLen
+	case strings.HasPrefix(hashed, "$2a$"), strings.HasPrefix(hashed, "$2b$"), strings.HasPrefix(hashed, "$2y$"):
+		if bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password)) != nil {
+			return false, false
+		}
+		cost, _ := bcrypt.Cost([]byte(hashed))
+		return true, h.Algorithm != PasswordBcrypt || cost != h.BcryptCost
+	case legacySha512.MatchString(hashed):
+		// 旧版无盐 sha512, 不接受直接提交哈希值当作密码
+		return subtle.ConstantTimeCompare([]byte(Sha512V(password)), []byte(hashed)) == 1, true
+	default:
+		// 旧版明文
+		return subtle.ConstantTimeCompare([]byte(password), []byte(hashed)) == 1, true
+	}
+}
+
+type argon2Params struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+	salt    []byte
+	key     []byte
+}
+
+func parseArgon2id(hashed string) (p argon2Params, ok bool) {
+	parts := strings.Split(hashed, "$")
+	if len(parts) != 6 {
+		return p, false
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, false
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.time, &p.threads); err != nil {
+		return p, false
+	}
+	var err error
+	if p.salt, err = b64.DecodeString(parts[4]); err != nil {
+		return p, false
+	}
+	if p.key, err = b64.DecodeString(parts[5]); err != nil || len(p.key) == 0 {
+		return p, false
+	}
+	return p, true
+}
+
+// IsPasswordHash 判断是否已经是当前支持的哈希格式(不含旧版 sha512)
+func IsPasswordHash(s string) bool {
+	return strings.HasPrefix(s, "$argon2id$") || strings.HasPrefix(s, "$2a$") ||
+		strings.HasPrefix(s, "$2b$") || strings.HasPrefix(s, "$2y$")
+}
+
+// HashPassword 按当前配置哈希密码
+func HashPassword(password string) (string, error) {
+	return NewPasswordHasher().Hash(password)
+}
+
+// VerifyPassword 按当前配置校验密码
+func VerifyPassword(hashed, password string) (ok bool, needsRehash bool) {
+	return NewPasswordHasher().Verify(hashed, password)
+}
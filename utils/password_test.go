@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"server/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 测试用低成本参数
+func testHasher(algorithm string) *PasswordHasher {
+	return newPasswordHasher(config.Password{Algorithm: algorithm, BcryptCost: 4, Argon2Memory: 1024, Argon2Time: 1, Argon2Threads: 1})
+}
+
+func TestPasswordHasher(t *testing.T) {
+	for _, algorithm := range []string{PasswordArgon2id, PasswordBcrypt} {
+		t.Run(algorithm, func(t *testing.T) {
+			h := testHasher(algorithm)
+			hashed, err := h.Hash("s3cret")
+			require.NoError(t, err)
+			assert.True(t, IsPasswordHash(hashed))
+			assert.LessOrEqual(t, len(hashed), 100, "需要放得下 varchar(100) 的密码列")
+
+			again, err := h.Hash("s3cret")
+			require.NoError(t, err)
+			assert.NotEqual(t, hashed, again, "每次哈希使用不同的盐")
+
+			ok, rehash := h.Verify(hashed, "s3cret")
+			assert.True(t, ok)
+			assert.False(t, rehash)
+
+			ok, _ = h.Verify(hashed, "wrong")
+			assert.False(t, ok)
+		})
+	}
+
+	t.Run("空密码", func(t *testing.T) {
+		_, err := testHasher(PasswordArgon2id).Hash("")
+		assert.ErrorIs(t, err, ErrPasswordEmpty)
+		ok, _ := testHasher(PasswordArgon2id).Verify("", "")
+		assert.False(t, ok)
+	})
+
+	t.Run("不支持的算法", func(t *testing.T) {
+		_, err := testHasher("md5").Hash("s3cret")
+		assert.ErrorIs(t, err, ErrPasswordAlgorithm)
+	})
+}
+
+func TestPasswordHasherRehash(t *testing.T) {
+	h := testHasher(PasswordArgon2id)
+
+	t.Run("旧版 sha512", func(t *testing.T) {
+		legacy := Sha512V("123456")
+		ok, rehash := h.Verify(legacy, "123456")
+		assert.True(t, ok)
+		assert.True(t, rehash)
+		ok, _ = h.Verify(legacy, legacy)
+		assert.False(t, ok, "不能直接提交哈希值登录")
+	})
+
+	t.Run("旧版明文", func(t *testing.T) {
+		ok, rehash := h.Verify("123456", "123456")
+		assert.True(t, ok)
+		assert.True(t, rehash)
+		ok, _ = h.Verify("123456", "1234567")
+		assert.False(t, ok)
+	})
+
+	t.Run("切换算法", func(t *testing.T) {
+		hashed, err := testHasher(PasswordBcrypt).Hash("s3cret")
+		require.NoError(t, err)
+		ok, rehash := h.Verify(hashed, "s3cret")
+		assert.True(t, ok)
+		assert.True(t, rehash)
+	})
+
+	t.Run("调整参数", func(t *testing.T) {
+		hashed, err := h.Hash("s3cret")
+		require.NoError(t, err)
+		stronger := testHasher(PasswordArgon2id)
+		stronger.Argon2Time = 2
+		ok, rehash := stronger.Verify(hashed, "s3cret")
+		assert.True(t, ok)
+		assert.True(t, rehash)
+	})
+
+	t.Run("损坏的哈希", func(t *testing.T) {
+		hashed, err := h.Hash("s3cret")
+		require.NoError(t, err)
+		ok, _ := h.Verify(strings.TrimSuffix(hashed, hashed[strings.LastIndex(hashed, "$"):]), "s3cret")
+		assert.False(t, ok)
+	})
+}
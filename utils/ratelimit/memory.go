@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	shardCount = 64
+	// 单个分片的令牌桶超过该数量时顺带清理已经回满的桶
+	shardSweepSize = 4096
+)
+
+// Memory 进程内的令牌桶, 按 key 分片加锁, 只在单实例内生效
+type Memory struct {
+	shards [shardCount]memoryShard
+	now    func() time.Time
+}
+
+type memoryShard struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	rate   float64 // 每秒恢复的令牌数
+	size   float64
+}
+
+func NewMemory() *Memory {
+	m := &Memory{now: time.Now}
+	for i := range m.shards {
+		m.shards[i].buckets = make(map[string]*bucket)
+	}
+	return m
+}
+
+// Allow 取出一个令牌, 桶的容量为 Rate 的突发次数
+func (m *Memory) Allow(_ context.Context, key string, rate Rate) (Result, error) {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	shard := &m.shards[h.Sum32()%shardCount]
+
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	now := m.now()
+	if len(shard.buckets) >= shardSweepSize {
+		for k, b := range shard.buckets {
+			if b.refill(now) >= b.size {
+				delete(shard.buckets, k)
+			}
+		}
+	}
+	size, perSecond := float64(rate.burst()), float64(rate.Limit)/rate.Period.Seconds()
+	b, ok := shard.buckets[key]
+	if !ok {
+		b = &bucket{tokens: size, last: now}
+		shard.buckets[key] = b
+	}
+	// 配置变更后按新的速率计算
+	b.rate, b.size = perSecond, size
+	b.tokens, b.last = b.refill(now), now
+
+	var result Result
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - b.tokens) / b.rate)
+	}
+	result.Remaining = int(math.Floor(b.tokens))
+	result.Reset = seconds((b.size - b.tokens) / b.rate)
+	return result, nil
+}
+
+func (b *bucket) refill(now time.Time) float64 {
+	return math.Min(b.size, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
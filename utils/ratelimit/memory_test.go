@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryAllow(t *testing.T) {
+	m := NewMemory()
+	now := time.Unix(1700000000, 0)
+	m.now = func() time.Time { return now }
+	ctx := context.Background()
+	rate := Rate{Limit: 10, Period: time.Minute, Burst: 3}
+
+	for i := 2; i >= 0; i-- {
+		r, err := m.Allow(ctx, "ip:1", rate)
+		require.NoError(t, err)
+		assert.True(t, r.Allowed)
+		assert.Equal(t, i, r.Remaining)
+	}
+	r, _ := m.Allow(ctx, "ip:1", rate)
+	assert.False(t, r.Allowed)
+	assert.Equal(t, 6*time.Second, r.RetryAfter)
+	assert.Equal(t, 18*time.Second, r.Reset)
+
+	// 其他 key 不受影响
+	r, _ = m.Allow(ctx, "ip:2", rate)
+	assert.True(t, r.Allowed)
+
+	// 每 6 秒恢复一次
+	now = now.Add(6 * time.Second)
+	r, _ = m.Allow(ctx, "ip:1", rate)
+	assert.True(t, r.Allowed)
+	assert.Equal(t, 0, r.Remaining)
+
+	// 回满后不超过突发次数
+	now = now.Add(time.Hour)
+	r, _ = m.Allow(ctx, "ip:1", rate)
+	assert.Equal(t, 2, r.Remaining)
+}
+
+func TestMemoryConcurrent(t *testing.T) {
+	m := NewMemory()
+	rate := Rate{Limit: 100, Period: time.Hour}
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		allowed int
+	)
+	for i := 0; i < 300; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r, _ := m.Allow(context.Background(), fmt.Sprint("user:", i%3), rate)
+			if r.Allowed {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 300, allowed)
+
+	r, _ := m.Allow(context.Background(), "user:0", rate)
+	assert.False(t, r.Allowed)
+}
@@ -0,0 +1,42 @@
+// Package ratelimit 限流器
+//
+// 按 Rate 描述的速率放行请求: 周期 Period 内允许 Limit 次, 允许突发 Burst 次.
+// Redis 使用 GCRA 算法的 lua 脚本保证多实例间计数原子, Memory 为进程内分片的令牌桶, 两者的结果含义一致
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Rate 限流速率
+type Rate struct {
+	Limit  int           // 周期内允许的请求数
+	Period time.Duration // 周期
+	Burst  int           // 允许的突发请求数, 为 0 时等于 Limit
+}
+
+func (r Rate) burst() int {
+	if r.Burst > 0 {
+		return r.Burst
+	}
+	return r.Limit
+}
+
+// interval 每恢复一次请求需要的时间
+func (r Rate) interval() time.Duration {
+	return r.Period / time.Duration(r.Limit)
+}
+
+// Result 一次请求的限流结果
+type Result struct {
+	Allowed    bool
+	Remaining  int           // 剩余可用次数
+	RetryAfter time.Duration // 被拒绝时需要等待的时间
+	Reset      time.Duration // 恢复到满额需要的时间
+}
+
+// Limiter 限流器, key 相同的请求共享计数
+type Limiter interface {
+	Allow(ctx context.Context, key string, rate Rate) (Result, error)
+}
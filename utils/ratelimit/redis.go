@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// gcra 以 redis 服务器时间计算, 各实例的时钟误差不影响结果;
+// key 保存理论到达时间 tat(微秒), 返回 {是否放行, 剩余次数, 需要等待的微秒数, 回满需要的微秒数}
+var gcra = redis.NewScript(`
+if redis.replicate_commands then redis.replicate_commands() end
+local interval = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+local tat = tonumber(redis.call('GET', KEYS[1]))
+if not tat or tat < now then
+	tat = now
+end
+local new_tat = tat + interval
+local diff = now - (new_tat - burst * interval)
+if diff < 0 then
+	return {0, 0, -diff, tat - now}
+end
+redis.call('SET', KEYS[1], new_tat, 'PX', math.ceil((new_tat - now) / 1000))
+return {1, math.floor(diff / interval), 0, new_tat - now}
+`)
+
+// Redis 基于 redis 的 GCRA 限流, 多实例共享计数
+type Redis struct {
+	client redis.Scripter
+	prefix string
+}
+
+func NewRedis(client redis.Scripter, prefix string) *Redis {
+	return &Redis{client: client, prefix: prefix}
+}
+
+func (r *Redis) Allow(ctx context.Context, key string, rate Rate) (Result, error) {
+	interval := rate.interval().Microseconds()
+	if interval <= 0 {
+		interval = 1
+	}
+	v, err := gcra.Run(ctx, r.client, []string{r.prefix + key}, interval, rate.burst()).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{
+		Allowed:    v[0] == 1,
+		Remaining:  int(v[1]),
+		RetryAfter: time.Duration(v[2]) * time.Microsecond,
+		Reset:      time.Duration(v[3]) * time.Microsecond,
+	}, nil
+}
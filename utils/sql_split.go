@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+var dollarQuoteTag = regexp.MustCompile(`^\$[A-Za-z_]*\$`)
+
+// SplitSQLStatements 按分号拆分 sql 脚本, 忽略字符串、标识符、注释和 pgsql $$ 函数体中的分号
+// 普通注释会被去掉, mysql 的 /*! ... */ 条件注释会保留
+func SplitSQLStatements(script string) []string {
+	var (
+		statements []string
+		buf        strings.Builder
+	)
+	flush := func() {
+		if s := strings.TrimSpace(buf.String()); s != "" {
+			statements = append(statements, s)
+		}
+		buf.Reset()
+	}
+	for i := 0; i < len(script); i++ {
+		ch := script[i]
+		switch {
+		case ch == '\'' || ch == '"' || ch == '`':
+			j := i + 1
+			for ; j < len(script); j++ {
+				if script[j] == '\\' && ch != '`' {
+					j++
+					continue
+				}
+				if script[j] == ch {
+					if j+1 < len(script) && script[j+1] == ch { // '' 形式的转义
+						j++
+						continue
+					}
+					break
+				}
+			}
+			j = min(j, len(script)-1)
+			buf.WriteString(script[i : j+1])
+			i = j
+		case ch == '$' && dollarQuoteTag.MatchString(script[i:]):
+			tag := dollarQuoteTag.FindString(script[i:])
+			end := strings.Index(script[i+len(tag):], tag)
+			if end < 0 {
+				buf.WriteString(script[i:])
+				i = len(script)
+				continue
+			}
+			end = i + len(tag) + end + len(tag)
+			buf.WriteString(script[i:end])
+			i = end - 1
+		case ch == '#' || ch == '-' && strings.HasPrefix(script[i:], "--"):
+			for i < len(script) && script[i] != '\n' {
+				i++
+			}
+			buf.WriteByte('\n')
+		case ch == '/' && strings.HasPrefix(script[i:], "/*"):
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				i = len(script)
+				continue
+			}
+			end = i + 2 + end + 2
+			if strings.HasPrefix(script[i:], "/*!") {
+				buf.WriteString(script[i:end])
+			}
+			i = end - 1
+		case ch == ';':
+			flush()
+		default:
+			buf.WriteByte(ch)
+		}
+	}
+	flush()
+	return statements
+}
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitSQLStatements(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []string
+	}{
+		{
+			name:   "多条语句",
+			script: "CREATE TABLE a (id int);\nINSERT INTO a VALUES (1);",
+			want:   []string{"CREATE TABLE a (id int)", "INSERT INTO a VALUES (1)"},
+		},
+		{
+			name:   "字符串中的分号",
+			script: "INSERT INTO a VALUES ('x;y', \"it\\\"s;\", 'it''s;');",
+			want:   []string{"INSERT INTO a VALUES ('x;y', \"it\\\"s;\", 'it''s;')"},
+		},
+		{
+			name:   "去掉普通注释保留条件注释",
+			script: "-- comment;\n# another;\n/* block; */\n/*!40101 SET NAMES utf8mb4 */;\nSELECT 1;",
+			want:   []string{"/*!40101 SET NAMES utf8mb4 */", "SELECT 1"},
+		},
+		{
+			name:   "pgsql 函数体",
+			script: "CREATE FUNCTION f() RETURNS void AS $body$ BEGIN PERFORM 1; END; $body$ LANGUAGE plpgsql;",
+			want:   []string{"CREATE FUNCTION f() RETURNS void AS $body$ BEGIN PERFORM 1; END; $body$ LANGUAGE plpgsql"},
+		},
+		{
+			name:   "空脚本",
+			script: "\n-- nothing\n",
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SplitSQLStatements(tt.script))
+		})
+	}
+}
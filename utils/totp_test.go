@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RFC 6238 附录 B 的 SHA1 测试向量, 取后 6 位
+func TestTotpCodeRFC6238(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	cases := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for ts, want := range cases {
+		code, err := TotpCode(secret, TotpStep(time.Unix(ts, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, want, code, "time %d", ts)
+	}
+}
+
+func TestValidateTotp(t *testing.T) {
+	secret, err := GenerateTotpSecret()
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+
+	code, err := TotpCode(secret, TotpStep(now)-1)
+	require.NoError(t, err)
+	step, ok := ValidateTotp(secret, code, now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, TotpStep(now)-1, step)
+
+	_, ok = ValidateTotp(secret, code, now.Add(2*TotpPeriod*time.Second), 1)
+	assert.False(t, ok, "超出允许的时钟偏差")
+
+	_, ok = ValidateTotp(secret, "12345", now, 1)
+	assert.False(t, ok)
+}
+
+func TestTotpURI(t *testing.T) {
+	uri := TotpURI("server", "admin", "JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "otpauth://totp/server:admin?")
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=server")
+}
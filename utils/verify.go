@@ -24,4 +24,6 @@ var (
 	MobileLoginVerify           = Rules{"Username": {NotEmpty()}, "Password": {NotEmpty(), Ge("6")}}
 	MobileUpdatePasswordVerify  = Rules{"ID": {NotEmpty()}, "Password": {NotEmpty(), Ge("6")}, "NewPassword": {NotEmpty(), Ge("6")}}
 	TokenLoginVerify            = Rules{"Username": {NotEmpty()}, "Password": {NotEmpty(), Ge("6")}}
+	ForgotPasswordVerify        = Rules{"Email": {NotEmpty()}}
+	ConfirmPasswordResetVerify  = Rules{"Token": {NotEmpty()}, "Password": {NotEmpty(), Ge("6")}}
 )